@@ -45,6 +45,11 @@ func newExporter(logger *zap.Logger, cfg *Config) (*clickhouseExporter, error) {
 		return nil, err
 	}
 
+	if err = createTable(client, createLogsTableSQL, cfg.LogsTableName, "Timestamp", cfg.TTLDays); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
 	insertLogsSQL := renderInsertLogsSQL(cfg)
 
 	return &clickhouseExporter{
@@ -115,7 +120,7 @@ func (e *clickhouseExporter) pushLogsData(ctx context.Context, ld plog.Logs) err
 func attributesToMap(attributes pcommon.Map) map[string]string {
 	m := make(map[string]string, attributes.Len())
 	attributes.Range(func(k string, v pcommon.Value) bool {
-		m[k] = v.StringVal()
+		m[k] = v.AsString()
 		return true
 	})
 	return m
@@ -182,17 +187,19 @@ func newClickhouseClient(cfg *Config) (*sql.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("sql.Open:%w", err)
 	}
-	// create table
-	query := fmt.Sprintf(createLogsTableSQL, cfg.LogsTableName, "")
-	if cfg.TTLDays > 0 {
-		query = fmt.Sprintf(createLogsTableSQL,
-			cfg.LogsTableName,
-			fmt.Sprintf(`TTL toDateTime(Timestamp) + toIntervalDay(%d)`, cfg.TTLDays))
+	return db, nil
+}
+
+// createTable creates the table from the given template, expiring the rows based on timeColumn when ttlDays is set.
+func createTable(db *sql.DB, template string, tableName string, timeColumn string, ttlDays uint) error {
+	var ttlExpr string
+	if ttlDays > 0 {
+		ttlExpr = fmt.Sprintf(`TTL toDateTime(%s) + toIntervalDay(%d)`, timeColumn, ttlDays)
 	}
-	if _, err := db.Exec(query); err != nil {
-		return nil, fmt.Errorf("exec create table sql: %w", err)
+	if _, err := db.Exec(fmt.Sprintf(template, tableName, ttlExpr)); err != nil {
+		return fmt.Errorf("exec create table sql: %w", err)
 	}
-	return db, nil
+	return nil
 }
 
 func renderInsertLogsSQL(cfg *Config) string {
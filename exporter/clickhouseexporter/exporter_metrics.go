@@ -0,0 +1,367 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// metricsTable describes the table holding the data points of a metric type.
+type metricsTable struct {
+	// suffix is appended to the metrics table name to get the name of the table
+	suffix string
+	// columns is the definition of the columns specific to the metric type
+	columns string
+	// names are the names of the columns specific to the metric type, in the order their values are inserted
+	names []string
+}
+
+var metricsTables = map[pmetric.MetricDataType]metricsTable{
+	pmetric.MetricDataTypeGauge: {
+		suffix: "_gauge",
+		columns: `
+     Value Float64 CODEC(ZSTD(1)),
+     Flags UInt32  CODEC(ZSTD(1)),`,
+		names: []string{"Value", "Flags"},
+	},
+	pmetric.MetricDataTypeSum: {
+		suffix: "_sum",
+		columns: `
+     Value Float64 CODEC(ZSTD(1)),
+     Flags UInt32  CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),
+     IsMonotonic Boolean CODEC(Delta, ZSTD(1)),`,
+		names: []string{"Value", "Flags", "AggTemp", "IsMonotonic"},
+	},
+	pmetric.MetricDataTypeHistogram: {
+		suffix: "_histogram",
+		columns: `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     BucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     ExplicitBounds Array(Float64) CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     Flags UInt32  CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),`,
+		names: []string{"Count", "Sum", "BucketCounts", "ExplicitBounds", "Min", "Max", "Flags", "AggTemp"},
+	},
+	pmetric.MetricDataTypeExponentialHistogram: {
+		suffix: "_exponential_histogram",
+		columns: `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     Scale Int32 CODEC(ZSTD(1)),
+     ZeroCount UInt64 CODEC(ZSTD(1)),
+     PositiveOffset Int32 CODEC(ZSTD(1)),
+     PositiveBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     NegativeOffset Int32 CODEC(ZSTD(1)),
+     NegativeBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     Flags UInt32  CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),`,
+		names: []string{"Count", "Sum", "Scale", "ZeroCount", "PositiveOffset", "PositiveBucketCounts",
+			"NegativeOffset", "NegativeBucketCounts", "Min", "Max", "Flags", "AggTemp"},
+	},
+	pmetric.MetricDataTypeSummary: {
+		suffix: "_summary",
+		columns: `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     ValueAtQuantiles Nested(
+         Quantile Float64,
+         Value Float64
+     ) CODEC(ZSTD(1)),
+     Flags UInt32  CODEC(ZSTD(1)),`,
+		names: []string{"Count", "Sum", "ValueAtQuantiles.Quantile", "ValueAtQuantiles.Value", "Flags"},
+	},
+}
+
+type metricsExporter struct {
+	client     *sql.DB
+	insertSQLs map[pmetric.MetricDataType]string
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*metricsExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newClickhouseClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	insertSQLs := make(map[pmetric.MetricDataType]string, len(metricsTables))
+	for typ, table := range metricsTables {
+		tableName := cfg.MetricsTableName + table.suffix
+		createSQL := fmt.Sprintf(createMetricsTableSQLTemplate, table.columns)
+		if err = createTable(client, createSQL, tableName, "TimeUnix", cfg.TTLDays); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+		insertSQLs[typ] = renderInsertMetricsSQL(tableName, table.names)
+	}
+
+	return &metricsExporter{
+		client:     client,
+		insertSQLs: insertSQLs,
+		logger:     logger,
+		cfg:        cfg,
+	}, nil
+}
+
+// Shutdown will shutdown the exporter.
+func (e *metricsExporter) Shutdown(_ context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+func (e *metricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	start := time.Now()
+	err := doWithTx(ctx, e.client, func(tx *sql.Tx) (err error) {
+		// the statements are prepared the first time a metric type is found
+		statements := make(map[pmetric.MetricDataType]*sql.Stmt)
+		defer func() {
+			for _, statement := range statements {
+				err = multierr.Append(err, statement.Close())
+			}
+		}()
+		exec := func(typ pmetric.MetricDataType, values []interface{}) error {
+			statement, ok := statements[typ]
+			if !ok {
+				statement, err = tx.PrepareContext(ctx, e.insertSQLs[typ])
+				if err != nil {
+					return fmt.Errorf("PrepareContext:%w", err)
+				}
+				statements[typ] = statement
+			}
+			if _, err = statement.ExecContext(ctx, values...); err != nil {
+				return fmt.Errorf("ExecContext:%w", err)
+			}
+			return nil
+		}
+
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			rm := md.ResourceMetrics().At(i)
+			res := rm.Resource()
+			resAttr := attributesToMap(res.Attributes())
+			var serviceName string
+			if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+				serviceName = v.StringVal()
+			}
+			for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+				sm := rm.ScopeMetrics().At(j)
+				scopeAttr := attributesToMap(sm.Scope().Attributes())
+				for k := 0; k < sm.Metrics().Len(); k++ {
+					m := sm.Metrics().At(k)
+					common := []interface{}{
+						resAttr,
+						rm.SchemaUrl(),
+						sm.Scope().Name(),
+						sm.Scope().Version(),
+						scopeAttr,
+						sm.SchemaUrl(),
+						serviceName,
+						m.Name(),
+						m.Description(),
+						m.Unit(),
+					}
+					if err = pushDataPoints(m, common, exec); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+	duration := time.Since(start)
+	e.logger.Debug("insert metrics", zap.Int("records", md.DataPointCount()),
+		zap.String("cost", duration.String()))
+	return err
+}
+
+// pushDataPoints executes the insertion of each data point of the metric, with the given values of the common columns.
+func pushDataPoints(m pmetric.Metric, common []interface{}, exec func(pmetric.MetricDataType, []interface{}) error) error {
+	values := func(attrs map[string]string, startTime time.Time, timestamp time.Time, specific ...interface{}) []interface{} {
+		v := make([]interface{}, 0, len(common)+3+len(specific))
+		v = append(v, common...)
+		v = append(v, attrs, startTime, timestamp)
+		return append(v, specific...)
+	}
+
+	typ := m.DataType()
+	switch typ {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if err := exec(typ, values(attributesToMap(dp.Attributes()), dp.StartTimestamp().AsTime(), dp.Timestamp().AsTime(),
+				numberValue(dp),
+				uint32(dp.Flags()),
+			)); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if err := exec(typ, values(attributesToMap(dp.Attributes()), dp.StartTimestamp().AsTime(), dp.Timestamp().AsTime(),
+				numberValue(dp),
+				uint32(dp.Flags()),
+				int32(m.Sum().AggregationTemporality()),
+				m.Sum().IsMonotonic(),
+			)); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if err := exec(typ, values(attributesToMap(dp.Attributes()), dp.StartTimestamp().AsTime(), dp.Timestamp().AsTime(),
+				dp.Count(),
+				dp.Sum(),
+				dp.BucketCounts().AsRaw(),
+				dp.ExplicitBounds().AsRaw(),
+				dp.Min(),
+				dp.Max(),
+				uint32(dp.Flags()),
+				int32(m.Histogram().AggregationTemporality()),
+			)); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if err := exec(typ, values(attributesToMap(dp.Attributes()), dp.StartTimestamp().AsTime(), dp.Timestamp().AsTime(),
+				dp.Count(),
+				dp.Sum(),
+				dp.Scale(),
+				dp.ZeroCount(),
+				dp.Positive().Offset(),
+				dp.Positive().BucketCounts().AsRaw(),
+				dp.Negative().Offset(),
+				dp.Negative().BucketCounts().AsRaw(),
+				dp.Min(),
+				dp.Max(),
+				uint32(dp.Flags()),
+				int32(m.ExponentialHistogram().AggregationTemporality()),
+			)); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			quantiles := make([]float64, 0, dp.QuantileValues().Len())
+			quantileValues := make([]float64, 0, dp.QuantileValues().Len())
+			for j := 0; j < dp.QuantileValues().Len(); j++ {
+				quantiles = append(quantiles, dp.QuantileValues().At(j).Quantile())
+				quantileValues = append(quantileValues, dp.QuantileValues().At(j).Value())
+			}
+			if err := exec(typ, values(attributesToMap(dp.Attributes()), dp.StartTimestamp().AsTime(), dp.Timestamp().AsTime(),
+				dp.Count(),
+				dp.Sum(),
+				quantiles,
+				quantileValues,
+				uint32(dp.Flags()),
+			)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntVal())
+	}
+	return dp.DoubleVal()
+}
+
+// metricsCommonColumns are the columns shared by the tables of all the metric types, in the order their values are inserted.
+var metricsCommonColumns = []string{
+	"ResourceAttributes",
+	"ResourceSchemaUrl",
+	"ScopeName",
+	"ScopeVersion",
+	"ScopeAttributes",
+	"ScopeSchemaUrl",
+	"ServiceName",
+	"MetricName",
+	"MetricDescription",
+	"MetricUnit",
+	"Attributes",
+	"StartTimeUnix",
+	"TimeUnix",
+}
+
+func renderInsertMetricsSQL(tableName string, names []string) string {
+	columns := append(append([]string{}, metricsCommonColumns...), names...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tableName, strings.Join(columns, ","), placeholders)
+}
+
+const (
+	// createMetricsTableSQLTemplate is completed with the columns specific to a metric type,
+	// resulting in a template similar to the one of the other signals.
+	// language=ClickHouse SQL
+	createMetricsTableSQLTemplate = `
+CREATE TABLE IF NOT EXISTS %%s (
+     ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     ResourceSchemaUrl String CODEC(ZSTD(1)),
+     ScopeName String CODEC(ZSTD(1)),
+     ScopeVersion String CODEC(ZSTD(1)),
+     ScopeAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     ScopeSchemaUrl String CODEC(ZSTD(1)),
+     ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+     MetricName String CODEC(ZSTD(1)),
+     MetricDescription String CODEC(ZSTD(1)),
+     MetricUnit String CODEC(ZSTD(1)),
+     Attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     StartTimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+     TimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),%s
+     INDEX idx_res_attr_key mapKeys(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_res_attr_value mapValues(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_attr_key mapKeys(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_attr_value mapValues(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1
+) ENGINE MergeTree()
+%%s
+PARTITION BY toDate(TimeUnix)
+ORDER BY (ServiceName, MetricName, toUnixTimestamp64Nano(TimeUnix))
+SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
+`
+)
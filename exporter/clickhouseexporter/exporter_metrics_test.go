@@ -0,0 +1,165 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExporter_pushMetricsData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		items := map[string]int{}
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				table := strings.Fields(query)[2]
+				items[table]++
+				require.Equal(t, strings.Count(query, "?"), len(values))
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+		mustPushMetricsData(t, exporter, simpleMetrics(2))
+
+		require.Equal(t, map[string]int{
+			"otel_metrics_gauge":                 3,
+			"otel_metrics_sum":                   3,
+			"otel_metrics_histogram":             3,
+			"otel_metrics_exponential_histogram": 3,
+			"otel_metrics_summary":               3,
+		}, items)
+	})
+	t.Run("sum values", func(t *testing.T) {
+		var inserted []driver.Value
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT INTO otel_metrics_sum ") {
+				inserted = values
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+
+		require.Len(t, inserted, 17)
+		require.Equal(t, "checkout", inserted[6])
+		require.Equal(t, "sum", inserted[7])
+		require.Equal(t, map[string]string{"k": "v"}, inserted[10])
+		require.Equal(t, float64(0), inserted[13])
+		require.Equal(t, int32(pmetric.MetricAggregationTemporalityCumulative), inserted[15])
+		require.Equal(t, true, inserted[16])
+	})
+}
+
+func TestExporter_createMetricsTables(t *testing.T) {
+	var queries []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		queries = append(queries, query)
+		return nil
+	})
+
+	newTestMetricsExporter(t, defaultDSN, func(cfg *Config) {
+		cfg.TTLDays = 3
+	})
+
+	require.Len(t, queries, 5)
+	for _, query := range queries {
+		require.Contains(t, query, "CREATE TABLE IF NOT EXISTS otel_metrics_")
+		require.Contains(t, query, "TTL toDateTime(TimeUnix) + toIntervalDay(3)")
+	}
+}
+
+func newTestMetricsExporter(t *testing.T, dsn string, fns ...func(*Config)) *metricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+// simpleMetrics returns one metric of each type, each holding count data points.
+func simpleMetrics(count int) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutString("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("library")
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("gauge")
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("sum")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("histogram")
+	expHistogram := sm.Metrics().AppendEmpty()
+	expHistogram.SetName("exponential_histogram")
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("summary")
+
+	gaugeDps := gauge.SetEmptyGauge().DataPoints()
+	histogramDps := histogram.SetEmptyHistogram().DataPoints()
+	expHistogramDps := expHistogram.SetEmptyExponentialHistogram().DataPoints()
+	summaryDps := summary.SetEmptySummary().DataPoints()
+	for i := 0; i < count; i++ {
+		gdp := gaugeDps.AppendEmpty()
+		gdp.SetTimestamp(now)
+		gdp.SetDoubleVal(float64(i))
+		gdp.Attributes().PutString("k", "v")
+
+		sdp := sum.Sum().DataPoints().AppendEmpty()
+		sdp.SetTimestamp(now)
+		sdp.SetIntVal(int64(i))
+		sdp.Attributes().PutString("k", "v")
+
+		hdp := histogramDps.AppendEmpty()
+		hdp.SetTimestamp(now)
+		hdp.SetCount(3)
+		hdp.SetSum(6)
+		hdp.ExplicitBounds().FromRaw([]float64{1, 2})
+		hdp.BucketCounts().FromRaw([]uint64{1, 1, 1})
+
+		edp := expHistogramDps.AppendEmpty()
+		edp.SetTimestamp(now)
+		edp.SetCount(2)
+		edp.SetScale(1)
+		edp.Positive().BucketCounts().FromRaw([]uint64{1, 1})
+
+		qdp := summaryDps.AppendEmpty()
+		qdp.SetTimestamp(now)
+		qdp.SetCount(1)
+		quantile := qdp.QuantileValues().AppendEmpty()
+		quantile.SetQuantile(0.5)
+		quantile.SetValue(1)
+	}
+	return metrics
+}
+
+func mustPushMetricsData(t *testing.T, exporter *metricsExporter, md pmetric.Metrics) {
+	err := exporter.pushMetricsData(context.TODO(), md)
+	require.NoError(t, err)
+}
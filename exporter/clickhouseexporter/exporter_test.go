@@ -20,6 +20,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -104,6 +105,24 @@ func TestExporter_pushLogsData(t *testing.T) {
 	})
 }
 
+func TestExporter_createTableWithTTL(t *testing.T) {
+	var queries []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		if strings.HasPrefix(strings.TrimSpace(query), "CREATE TABLE") {
+			queries = append(queries, query)
+		}
+		return nil
+	})
+
+	newTestExporter(t, defaultDSN, func(cfg *Config) {
+		cfg.TTLDays = 3
+	})
+
+	require.Len(t, queries, 1)
+	require.Contains(t, queries[0], "CREATE TABLE IF NOT EXISTS otel_logs")
+	require.Contains(t, queries[0], "TTL toDateTime(Timestamp) + toIntervalDay(3)")
+}
+
 func newTestExporter(t *testing.T, dsn string, fns ...func(*Config)) *clickhouseExporter {
 	exporter, err := newExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
 	require.NoError(t, err)
@@ -142,11 +161,18 @@ func mustPushLogsData(t *testing.T, exporter *clickhouseExporter, ld plog.Logs)
 
 const testDriverName = "clickhouse-test"
 
+var (
+	testDriver         = &testClickhouseDriver{}
+	registerTestDriver sync.Once
+)
+
+// initClickhouseTestServer registers the test driver the first time it's called, and replaces its recorder.
 func initClickhouseTestServer(_ *testing.T, recorder recorder) {
 	driverName = testDriverName
-	sql.Register(testDriverName, &testClickhouseDriver{
-		recorder: recorder,
+	registerTestDriver.Do(func() {
+		sql.Register(testDriverName, testDriver)
 	})
+	testDriver.recorder = recorder
 }
 
 type recorder func(query string, values []driver.Value) error
@@ -0,0 +1,124 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExporter_pushTracesData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		var items int
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			t.Logf("%d, values:%+v", items, values)
+			if strings.HasPrefix(query, "INSERT") {
+				items++
+			}
+			return nil
+		})
+
+		exporter := newTestTracesExporter(t, defaultDSN)
+		mustPushTracesData(t, exporter, simpleTraces(1))
+		mustPushTracesData(t, exporter, simpleTraces(2))
+
+		require.Equal(t, 3, items)
+	})
+	t.Run("events and links", func(t *testing.T) {
+		var inserted []driver.Value
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				inserted = values
+			}
+			return nil
+		})
+
+		exporter := newTestTracesExporter(t, defaultDSN)
+		mustPushTracesData(t, exporter, simpleTraces(1))
+
+		require.Len(t, inserted, 20)
+		require.Equal(t, "checkout", inserted[7])
+		require.Equal(t, map[string]string{"k": "v", "status_code": "200"}, inserted[9])
+		require.Equal(t, int64(time.Second), inserted[10])
+		require.Equal(t, "STATUS_CODE_ERROR", inserted[11])
+		require.Equal(t, []string{"event"}, inserted[14])
+		require.Equal(t, []map[string]string{{"level": "info"}}, inserted[15])
+		require.Equal(t, []string{"0102030405060708090a0b0c0d0e0f10"}, inserted[16])
+		require.Equal(t, []map[string]string{{}}, inserted[19])
+	})
+}
+
+func TestExporter_createTracesTable(t *testing.T) {
+	var queries []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		queries = append(queries, query)
+		return nil
+	})
+
+	newTestTracesExporter(t, defaultDSN, func(cfg *Config) {
+		cfg.TTLDays = 7
+		cfg.TracesTableName = "spans"
+	})
+
+	require.Len(t, queries, 1)
+	require.Contains(t, queries[0], "CREATE TABLE IF NOT EXISTS spans")
+	require.Contains(t, queries[0], "TTL toDateTime(Timestamp) + toIntervalDay(7)")
+}
+
+func newTestTracesExporter(t *testing.T, dsn string, fns ...func(*Config)) *tracesExporter {
+	exporter, err := newTracesExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+func simpleTraces(count int) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutString("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	for i := 0; i < count; i++ {
+		s := ss.Spans().AppendEmpty()
+		start := time.Now()
+		s.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		s.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Second)))
+		s.Attributes().PutString("k", "v")
+		s.Attributes().PutInt("status_code", 200)
+		s.Status().SetCode(ptrace.StatusCodeError)
+
+		event := s.Events().AppendEmpty()
+		event.SetName("event")
+		event.SetTimestamp(pcommon.NewTimestampFromTime(start))
+		event.Attributes().PutString("level", "info")
+
+		link := s.Links().AppendEmpty()
+		link.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	}
+	return traces
+}
+
+func mustPushTracesData(t *testing.T, exporter *tracesExporter, td ptrace.Traces) {
+	err := exporter.pushTraceData(context.TODO(), td)
+	require.NoError(t, err)
+}
@@ -26,6 +26,8 @@ type routingKey int
 const (
 	traceIDRouting routingKey = iota
 	svcRouting
+	attrRouting
+	streamIDRouting
 )
 
 // Config defines configuration for the exporter.
@@ -34,6 +36,9 @@ type Config struct {
 	Protocol                Protocol         `mapstructure:"protocol"`
 	Resolver                ResolverSettings `mapstructure:"resolver"`
 	RoutingKey              string           `mapstructure:"routing_key"`
+
+	// RoutingAttributes holds the resource attributes used to route the data when the routing key is "attributes"
+	RoutingAttributes []string `mapstructure:"routing_attributes"`
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -45,6 +50,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -59,3 +65,9 @@ type DNSResolver struct {
 	Interval time.Duration `mapstructure:"interval"`
 	Timeout  time.Duration `mapstructure:"timeout"`
 }
+
+// K8sSvcResolver defines the configuration for the resolver watching the endpoints of a Kubernetes service
+type K8sSvcResolver struct {
+	Service string  `mapstructure:"service"`
+	Ports   []int32 `mapstructure:"ports"`
+}
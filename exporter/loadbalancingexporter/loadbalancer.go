@@ -63,7 +63,7 @@ type loadBalancerImp struct {
 func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporter, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	if countResolvers(oCfg.Resolver) > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
@@ -85,6 +85,19 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 		}
 	}
 
+	if oCfg.Resolver.K8sSvc != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s service"))
+
+		clt, err := newInClusterClient()
+		if err != nil {
+			return nil, err
+		}
+		res, err = newK8sResolver(clt, k8sLogger, oCfg.Resolver.K8sSvc.Service, oCfg.Resolver.K8sSvc.Ports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
@@ -97,6 +110,20 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 	}, nil
 }
 
+func countResolvers(cfg ResolverSettings) int {
+	count := 0
+	if cfg.Static != nil {
+		count++
+	}
+	if cfg.DNS != nil {
+		count++
+	}
+	if cfg.K8sSvc != nil {
+		count++
+	}
+	return count
+}
+
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
@@ -171,9 +198,15 @@ func endpointFound(endpoint string, endpoints []string) bool {
 	return false
 }
 
-func (lb *loadBalancerImp) Shutdown(context.Context) error {
+func (lb *loadBalancerImp) Shutdown(ctx context.Context) error {
+	lb.updateLock.Lock()
+	stopped := lb.stopped
 	lb.stopped = true
-	return nil
+	lb.updateLock.Unlock()
+	if stopped {
+		return nil
+	}
+	return lb.res.shutdown(ctx)
 }
 
 func (lb *loadBalancerImp) Endpoint(identifier []byte) string {
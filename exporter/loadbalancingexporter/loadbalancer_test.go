@@ -153,6 +153,26 @@ func TestMultipleResolvers(t *testing.T) {
 	assert.Equal(t, errMultipleResolversProvided, err)
 }
 
+func TestMultipleResolversWithK8s(t *testing.T) {
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			DNS: &DNSResolver{
+				Hostname: "service-1",
+			},
+			K8sSvc: &K8sSvcResolver{
+				Service: "lb.observability",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
+
 func TestStartFailureStaticResolver(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
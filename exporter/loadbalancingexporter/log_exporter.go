@@ -68,10 +68,10 @@ func (e *logExporterImp) Start(ctx context.Context, host component.Host) error {
 	return e.loadBalancer.Start(ctx, host)
 }
 
-func (e *logExporterImp) Shutdown(context.Context) error {
+func (e *logExporterImp) Shutdown(ctx context.Context) error {
 	e.stopped = true
 	e.shutdownWg.Wait()
-	return nil
+	return e.loadBalancer.Shutdown(ctx)
 }
 
 func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+var _ component.MetricsExporter = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   routingKey
+	routingAttrs []string
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params component.ExporterCreateSettings, cfg config.Exporter) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter := metricExporterImp{loadBalancer: lb, routingKey: streamIDRouting}
+
+	switch cfg.(*Config).RoutingKey {
+	case "service":
+		metricExporter.routingKey = svcRouting
+	case "attributes":
+		if len(cfg.(*Config).RoutingAttributes) == 0 {
+			return nil, errNoRoutingAttributes
+		}
+		metricExporter.routingKey = attrRouting
+		metricExporter.routingAttrs = cfg.(*Config).RoutingAttributes
+	case "streamID", "":
+	default:
+		return nil, fmt.Errorf("unsupported routing_key for metrics: %s", cfg.(*Config).RoutingKey)
+	}
+	return &metricExporter, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(ctx context.Context) error {
+	return e.loadBalancer.Shutdown(ctx)
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var errs error
+	batches := make(map[string]*metricsBatch)
+	batchFor := func(id string) *metricsBatch {
+		endpoint := e.loadBalancer.Endpoint([]byte(id))
+		batch, ok := batches[endpoint]
+		if !ok {
+			batch = newMetricsBatch()
+			batches[endpoint] = batch
+		}
+		return batch
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if e.routingKey != streamIDRouting {
+			id, err := resourceRoutingID(rm.Resource(), e.routingKey, e.routingAttrs)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			rm.CopyTo(batchFor(id).md.ResourceMetrics().AppendEmpty())
+			continue
+		}
+
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				splitDataPoints(rm, sm, ms.At(k), [3]int{i, j, k}, batchFor)
+			}
+		}
+	}
+
+	for endpoint, batch := range batches {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, endpoint, batch.md))
+	}
+	return errs
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, endpoint string, md pmetric.Metrics) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(component.MetricsExporter)
+	if !ok {
+		expectType := (*component.MetricsExporter)(nil)
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected %T but got %T", expectType, exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	if err == nil {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
+
+// splitDataPoints copies each data point of the given metric to the batch of the stream it belongs to.
+func splitDataPoints(rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, m pmetric.Metric, pos [3]int, batchFor func(string) *metricsBatch) {
+	stream := func(attrs pcommon.Map) *metricsBatch {
+		return batchFor(streamID(rm.Resource(), sm.Scope(), m.Name(), attrs))
+	}
+
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dp := dps.At(l)
+			dp.CopyTo(stream(dp.Attributes()).metric(rm, sm, m, pos).Gauge().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dp := dps.At(l)
+			dp.CopyTo(stream(dp.Attributes()).metric(rm, sm, m, pos).Sum().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dp := dps.At(l)
+			dp.CopyTo(stream(dp.Attributes()).metric(rm, sm, m, pos).Histogram().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dp := dps.At(l)
+			dp.CopyTo(stream(dp.Attributes()).metric(rm, sm, m, pos).ExponentialHistogram().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dp := dps.At(l)
+			dp.CopyTo(stream(dp.Attributes()).metric(rm, sm, m, pos).Summary().DataPoints().AppendEmpty())
+		}
+	}
+}
+
+// metricsBatch holds the metrics to be sent to a single backend, keeping track of the resources, scopes and
+// metrics already copied from the original data, so that data points of the same metric are kept together.
+type metricsBatch struct {
+	md        pmetric.Metrics
+	resources map[int]pmetric.ResourceMetrics
+	scopes    map[[2]int]pmetric.ScopeMetrics
+	metrics   map[[3]int]pmetric.Metric
+}
+
+func newMetricsBatch() *metricsBatch {
+	return &metricsBatch{
+		md:        pmetric.NewMetrics(),
+		resources: make(map[int]pmetric.ResourceMetrics),
+		scopes:    make(map[[2]int]pmetric.ScopeMetrics),
+		metrics:   make(map[[3]int]pmetric.Metric),
+	}
+}
+
+// metric returns the metric of the batch for the metric at the given position of the original data,
+// creating it without data points, along with its resource and scope, if needed.
+func (b *metricsBatch) metric(rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, m pmetric.Metric, pos [3]int) pmetric.Metric {
+	if dest, ok := b.metrics[pos]; ok {
+		return dest
+	}
+
+	scopePos := [2]int{pos[0], pos[1]}
+	destSm, ok := b.scopes[scopePos]
+	if !ok {
+		destRm, ok := b.resources[pos[0]]
+		if !ok {
+			destRm = b.md.ResourceMetrics().AppendEmpty()
+			rm.Resource().CopyTo(destRm.Resource())
+			destRm.SetSchemaUrl(rm.SchemaUrl())
+			b.resources[pos[0]] = destRm
+		}
+		destSm = destRm.ScopeMetrics().AppendEmpty()
+		sm.Scope().CopyTo(destSm.Scope())
+		destSm.SetSchemaUrl(sm.SchemaUrl())
+		b.scopes[scopePos] = destSm
+	}
+
+	dest := destSm.Metrics().AppendEmpty()
+	dest.SetName(m.Name())
+	dest.SetDescription(m.Description())
+	dest.SetUnit(m.Unit())
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricDataTypeSum:
+		sum := dest.SetEmptySum()
+		sum.SetAggregationTemporality(m.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(m.Sum().IsMonotonic())
+	case pmetric.MetricDataTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(m.Histogram().AggregationTemporality())
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(m.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricDataTypeSummary:
+		dest.SetEmptySummary()
+	}
+	b.metrics[pos] = dest
+	return dest
+}
@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			nil,
+		},
+		{
+			"empty",
+			&Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+			},
+			errNoResolver,
+		},
+		{
+			"trace id routing",
+			func() *Config {
+				cfg := simpleConfig()
+				cfg.RoutingKey = "traceID"
+				return cfg
+			}(),
+			errors.New("unsupported routing_key for metrics: traceID"),
+		},
+		{
+			"attributes routing without attributes",
+			func() *Config {
+				cfg := simpleConfig()
+				cfg.RoutingKey = "attributes"
+				return cfg
+			}(),
+			errNoRoutingAttributes,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestMetricsExporterShutdown(t *testing.T) {
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res := p.Shutdown(context.Background())
+
+	// verify
+	assert.Nil(t, res)
+}
+
+func TestConsumeMetricsStreamID(t *testing.T) {
+	// prepare
+	p, sinks := newMetricsExporterWithSinks(t, simpleConfig())
+	md := metricsWithStreams()
+
+	// test
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	// verify
+	total := 0
+	streams := map[string]string{}
+	for endpoint, sink := range sinks {
+		for _, received := range sink.AllMetrics() {
+			total += received.DataPointCount()
+
+			rm := received.ResourceMetrics().At(0)
+			assert.Equal(t, "checkout", rm.Resource().Attributes().AsRaw()["service.name"])
+			m := rm.ScopeMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, "requests", m.Name())
+			assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+			assert.True(t, m.Sum().IsMonotonic())
+
+			dps := m.Sum().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				path := dps.At(i).Attributes().AsRaw()["path"].(string)
+				if previous, ok := streams[path]; ok {
+					assert.Equal(t, previous, endpoint, "all the points of a stream should be sent to the same backend")
+				}
+				streams[path] = endpoint
+			}
+		}
+	}
+	assert.Equal(t, 2*md.DataPointCount(), total)
+	assert.Len(t, streams, 20)
+}
+
+func TestConsumeMetricsAttributes(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.RoutingKey = "attributes"
+	cfg.RoutingAttributes = []string{"tenant"}
+	p, sinks := newMetricsExporterWithSinks(t, cfg)
+
+	md := pmetric.NewMetrics()
+	for i := 0; i < 10; i++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutString("tenant", fmt.Sprintf("tenant-%d", i))
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("requests")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntVal(int64(i))
+	}
+
+	// test
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	// verify
+	total := 0
+	for endpoint, sink := range sinks {
+		for _, received := range sink.AllMetrics() {
+			for i := 0; i < received.ResourceMetrics().Len(); i++ {
+				tenant, _ := received.ResourceMetrics().At(i).Resource().Attributes().Get("tenant")
+				assert.Equal(t, endpoint, endpointWithPort(p.loadBalancer.Endpoint([]byte(tenant.StringVal()))))
+				total++
+			}
+		}
+	}
+	assert.Equal(t, 10, total)
+}
+
+func TestConsumeMetricsServiceWithoutName(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.RoutingKey = "service"
+	p, sinks := newMetricsExporterWithSinks(t, cfg)
+
+	md := metricsWithStreams()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("no-service")
+
+	// test
+	err := p.ConsumeMetrics(context.Background(), md)
+
+	// verify
+	assert.Equal(t, errNoServiceName, err)
+	total := 0
+	for _, sink := range sinks {
+		total += sink.DataPointCount()
+	}
+	assert.Equal(t, 20, total)
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), metricsWithStreams())
+
+	// verify
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected *component.MetricsExporter but got %T", newNopMockExporter()))
+}
+
+// newMetricsExporterWithSinks returns a started exporter with four backends, along with the sinks receiving the data of each backend.
+func newMetricsExporterWithSinks(t *testing.T, cfg *Config) (*metricExporterImp, map[string]*consumertest.MetricsSink) {
+	sinks := map[string]*consumertest.MetricsSink{}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		sink := new(consumertest.MetricsSink)
+		sinks[endpoint] = sink
+		return newMockMetricsExporter(sink.ConsumeMetrics), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	})
+	return p, sinks
+}
+
+func metricsWithStreams() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutString("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("library")
+
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	for i := 0; i < 20; i++ {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutString("path", fmt.Sprintf("/path-%d", i))
+		dp.SetIntVal(int64(i))
+	}
+	return md
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		consumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.consumeMetricsFn == nil {
+		return nil
+	}
+	return e.consumeMetricsFn(ctx, md)
+}
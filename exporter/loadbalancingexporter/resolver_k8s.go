@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace = "default"
+	defaultSyncTimeout  = 30 * time.Second
+)
+
+var (
+	errNoSvc           = errors.New("no service specified to resolve the backends")
+	errInvalidSvc      = errors.New("invalid service, expected name, name.namespace or name.namespace.svc[.cluster-domain]")
+	errCacheSyncFailed = errors.New("failed to sync the endpoints of the service")
+
+	k8sResolverMutator = tag.Upsert(tag.MustNewKey("resolver"), "k8s")
+
+	k8sResolverSuccessTrueMutators = []tag.Mutator{k8sResolverMutator, successTrueMutator}
+)
+
+// k8sResolver watches the Endpoints object of a Kubernetes service, using the ready addresses of the
+// service as backends. Changes are propagated as soon as the API server notifies them.
+type k8sResolver struct {
+	logger *zap.Logger
+
+	svcName     string
+	svcNs       string
+	ports       []int32
+	syncTimeout time.Duration
+
+	epsListWatcher cache.ListerWatcher
+
+	addresses         []string
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+// newInClusterClient returns a client for the Kubernetes API server the collector is running on.
+func newInClusterClient() (kubernetes.Interface, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Kubernetes client config: %w", err)
+	}
+	return kubernetes.NewForConfig(cfg)
+}
+
+func newK8sResolver(clt kubernetes.Interface, logger *zap.Logger, service string, ports []int32) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+
+	name, namespace, err := parseK8sService(service)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	epsListWatcher := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return clt.CoreV1().Endpoints(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return clt.CoreV1().Endpoints(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	return &k8sResolver{
+		logger:         logger,
+		svcName:        name,
+		svcNs:          namespace,
+		ports:          ports,
+		syncTimeout:    defaultSyncTimeout,
+		epsListWatcher: epsListWatcher,
+		stopCh:         make(chan struct{}),
+	}, nil
+}
+
+// parseK8sService returns the name and the namespace of the service, specified as "name.namespace", the namespace
+// being optional, or by its fully qualified domain name "name.namespace.svc.cluster.local".
+func parseK8sService(service string) (string, string, error) {
+	labels := strings.Split(service, ".")
+	for _, label := range labels {
+		if label == "" {
+			return "", "", fmt.Errorf("%w: %s", errInvalidSvc, service)
+		}
+	}
+
+	switch {
+	case len(labels) == 1:
+		return labels[0], defaultK8sNamespace, nil
+	case len(labels) == 2 || labels[2] == "svc":
+		return labels[0], labels[1], nil
+	default:
+		return "", "", fmt.Errorf("%w: %s", errInvalidSvc, service)
+	}
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	informer := cache.NewSharedInformer(r.epsListWatcher, &corev1.Endpoints{}, 0)
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: r.onEndpointsChange,
+		UpdateFunc: func(_, newObj interface{}) {
+			r.onEndpointsChange(newObj)
+		},
+		DeleteFunc: r.onEndpointsDelete,
+	})
+
+	r.shutdownWg.Add(1)
+	go func() {
+		defer r.shutdownWg.Done()
+		informer.Run(r.stopCh)
+	}()
+
+	syncCtx, cancel := context.WithTimeout(ctx, r.syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+		return fmt.Errorf("%w: %s.%s", errCacheSyncFailed, r.svcName, r.svcNs)
+	}
+
+	// the event handlers are called asynchronously, so we make sure the backends are known when the exporter starts
+	if obj, exists, err := informer.GetStore().GetByKey(r.svcNs + "/" + r.svcName); err == nil && exists {
+		r.onEndpointsChange(obj)
+	}
+
+	r.logger.Debug("Kubernetes service resolver started",
+		zap.String("service", r.svcName), zap.String("namespace", r.svcNs), zap.Int32s("ports", r.ports))
+	return nil
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) onEndpointsChange(obj interface{}) {
+	eps, ok := obj.(*corev1.Endpoints)
+	if !ok || eps.Name != r.svcName {
+		return
+	}
+
+	// only the ready addresses are used, so that backends aren't used before they are able to receive data
+	var addresses []string
+	for _, subset := range eps.Subsets {
+		for _, addr := range subset.Addresses {
+			addresses = append(addresses, addr.IP)
+		}
+	}
+	r.setAddresses(addresses)
+}
+
+func (r *k8sResolver) onEndpointsDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	eps, ok := obj.(*corev1.Endpoints)
+	if !ok || eps.Name != r.svcName {
+		return
+	}
+	r.setAddresses(nil)
+}
+
+func (r *k8sResolver) setAddresses(addresses []string) {
+	r.updateLock.Lock()
+	r.addresses = addresses
+	r.updateLock.Unlock()
+
+	if _, err := r.resolve(context.Background()); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	r.shutdownWg.Add(1)
+	defer r.shutdownWg.Done()
+
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumResolutions.M(1))
+
+	r.updateLock.Lock()
+	var backends []string
+	for _, addr := range r.addresses {
+		if strings.Contains(addr, ":") {
+			// it's an IPv6 address
+			addr = fmt.Sprintf("[%s]", addr)
+		}
+
+		// without ports, the default port is used for each address
+		if len(r.ports) == 0 {
+			backends = append(backends, addr)
+			continue
+		}
+		for _, port := range r.ports {
+			backends = append(backends, fmt.Sprintf("%s:%d", addr, port))
+		}
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	if equalStringSlice(r.endpoints, backends) {
+		r.updateLock.Unlock()
+		return backends, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	r.updateLock.Unlock()
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestEndpoints(name string, ready []string, notReady []string) *corev1.Endpoints {
+	subset := corev1.EndpointSubset{}
+	for _, ip := range ready {
+		subset.Addresses = append(subset.Addresses, corev1.EndpointAddress{IP: ip})
+	}
+	for _, ip := range notReady {
+		subset.NotReadyAddresses = append(subset.NotReadyAddresses, corev1.EndpointAddress{IP: ip})
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "observability",
+		},
+		Subsets: []corev1.EndpointSubset{subset},
+	}
+}
+
+func TestK8sResolve(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(
+		newTestEndpoints("lb", []string{"10.0.0.2", "10.0.0.1"}, []string{"10.0.0.3"}),
+		newTestEndpoints("other", []string{"10.0.1.1"}, nil),
+	)
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb.observability", []int32{4317, 55690})
+	require.NoError(t, err)
+
+	var resolvedLock sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolvedLock.Lock()
+		defer resolvedLock.Unlock()
+		resolved = endpoints
+	})
+	lastResolved := func() []string {
+		resolvedLock.Lock()
+		defer resolvedLock.Unlock()
+		return resolved
+	}
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.1:55690", "10.0.0.2:4317", "10.0.0.2:55690"}, lastResolved())
+
+	// the not ready address becomes ready, and the first one is gone
+	_, err = clt.CoreV1().Endpoints("observability").Update(context.Background(),
+		newTestEndpoints("lb", []string{"10.0.0.2", "10.0.0.3"}, nil), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"10.0.0.2:4317", "10.0.0.2:55690", "10.0.0.3:4317", "10.0.0.3:55690"}, lastResolved())
+	}, time.Second, 10*time.Millisecond)
+
+	// the service is gone
+	err = clt.CoreV1().Endpoints("observability").Delete(context.Background(), "lb", metav1.DeleteOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(lastResolved()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sResolveWithoutPorts(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(&corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lb",
+			Namespace: defaultK8sNamespace,
+		},
+		Subsets: []corev1.EndpointSubset{
+			{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "fd00::1"}}},
+		},
+	})
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "[fd00::1]"}, resolved)
+}
+
+func TestK8sErrNoService(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "", nil)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSvc, err)
+}
+
+func TestK8sParseService(t *testing.T) {
+	for _, tt := range []struct {
+		service   string
+		name      string
+		namespace string
+		err       error
+	}{
+		{service: "lb", name: "lb", namespace: "default"},
+		{service: "lb.observability", name: "lb", namespace: "observability"},
+		{service: "lb.observability.svc", name: "lb", namespace: "observability"},
+		{service: "lb.observability.svc.cluster.local", name: "lb", namespace: "observability"},
+		{service: "lb.observability.cluster.local", err: errInvalidSvc},
+		{service: "lb..svc", err: errInvalidSvc},
+		{service: ".observability", err: errInvalidSvc},
+	} {
+		t.Run(tt.service, func(t *testing.T) {
+			// test
+			name, namespace, err := parseK8sService(tt.service)
+
+			// verify
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.namespace, namespace)
+		})
+	}
+}
+
+func TestK8sResolverShutdownWithLoadBalancer(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(newTestEndpoints("lb", []string{"10.0.0.1"}, nil))
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb.observability.svc.cluster.local", nil)
+	require.NoError(t, err)
+
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NoError(t, err)
+	lb.res = res
+	require.NoError(t, lb.Start(context.Background(), componenttest.NewNopHost()))
+
+	// test
+	require.NoError(t, lb.Shutdown(context.Background()))
+	require.NoError(t, lb.Shutdown(context.Background()))
+
+	// verify
+	select {
+	case <-res.stopCh:
+	default:
+		assert.Fail(t, "the resolver should have been shut down")
+	}
+}
+
+func TestK8sOnChangeIsNotCalledAfterShutdown(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(newTestEndpoints("lb", []string{"10.0.0.1"}, nil))
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb.observability", nil)
+	require.NoError(t, err)
+
+	counter := 0
+	res.onChange(func(endpoints []string) {
+		counter++
+	})
+	require.NoError(t, res.start(context.Background()))
+
+	// test
+	require.NoError(t, res.shutdown(context.Background()))
+	res.setAddresses([]string{"10.0.0.2"})
+
+	// verify
+	assert.Equal(t, 1, counter)
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var (
+	errNoServiceName       = errors.New("unable to get service name")
+	errNoRoutingAttributes = errors.New("no routing_attributes specified for the attributes routing key")
+)
+
+// resourceRoutingID returns the identifier used to find the backend for the data of the given resource,
+// either its service name or the values of the given attributes.
+func resourceRoutingID(resource pcommon.Resource, key routingKey, attrs []string) (string, error) {
+	if key == svcRouting {
+		svc, ok := resource.Attributes().Get("service.name")
+		if !ok {
+			return "", errNoServiceName
+		}
+		return svc.StringVal(), nil
+	}
+
+	// missing attributes are represented by an empty value, so that the data missing
+	// the same attributes is still routed consistently
+	values := make([]string, len(attrs))
+	for i, attr := range attrs {
+		if v, ok := resource.Attributes().Get(attr); ok {
+			values[i] = v.AsString()
+		}
+	}
+	return strings.Join(values, ";"), nil
+}
+
+// streamID returns the identity of the metric stream a data point with the given attributes belongs to.
+func streamID(resource pcommon.Resource, scope pcommon.InstrumentationScope, metricName string, attrs pcommon.Map) string {
+	var b strings.Builder
+	writeAttributes(&b, resource.Attributes())
+	b.WriteString(scope.Name())
+	b.WriteByte(';')
+	b.WriteString(scope.Version())
+	b.WriteByte(';')
+	b.WriteString(metricName)
+	b.WriteByte(';')
+	writeAttributes(&b, attrs)
+	return b.String()
+}
+
+// writeAttributes writes the attributes sorted by key, without changing the order of the original map.
+func writeAttributes(b *strings.Builder, attrs pcommon.Map) {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(v.AsString())
+		b.WriteByte(';')
+	}
+}
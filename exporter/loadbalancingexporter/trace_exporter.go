@@ -38,6 +38,7 @@ var _ component.TracesExporter = (*traceExporterImp)(nil)
 type traceExporterImp struct {
 	loadBalancer loadBalancer
 	routingKey   routingKey
+	routingAttrs []string
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -60,6 +61,12 @@ func newTracesExporter(params component.ExporterCreateSettings, cfg config.Expor
 	switch cfg.(*Config).RoutingKey {
 	case "service":
 		traceExporter.routingKey = svcRouting
+	case "attributes":
+		if len(cfg.(*Config).RoutingAttributes) == 0 {
+			return nil, errNoRoutingAttributes
+		}
+		traceExporter.routingKey = attrRouting
+		traceExporter.routingAttrs = cfg.(*Config).RoutingAttributes
 	case "traceID", "":
 	default:
 		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
@@ -82,10 +89,10 @@ func (e *traceExporterImp) Start(ctx context.Context, host component.Host) error
 	return e.loadBalancer.Start(ctx, host)
 }
 
-func (e *traceExporterImp) Shutdown(context.Context) error {
+func (e *traceExporterImp) Shutdown(ctx context.Context) error {
 	e.stopped = true
 	e.shutdownWg.Wait()
-	return nil
+	return e.loadBalancer.Shutdown(ctx)
 }
 
 func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
@@ -100,12 +107,11 @@ func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 
 func (e *traceExporterImp) consumeTrace(ctx context.Context, td ptrace.Traces) error {
 	var exp component.Exporter
-	routingIds, err := routingIdentifiersFromTraces(td, e.routingKey)
+	routingIds, err := routingIdentifiersFromTraces(td, e.routingKey, e.routingAttrs)
 	if err != nil {
 		return err
 	}
-	for rid := range routingIds {
-		endpoint := e.loadBalancer.Endpoint([]byte(rid))
+	for endpoint, batch := range e.batchesPerEndpoint(td, routingIds) {
 		exp, err = e.loadBalancer.Exporter(endpoint)
 		if err != nil {
 			return err
@@ -118,7 +124,7 @@ func (e *traceExporterImp) consumeTrace(ctx context.Context, td ptrace.Traces) e
 		}
 
 		start := time.Now()
-		err = te.ConsumeTraces(ctx, td)
+		err = te.ConsumeTraces(ctx, batch)
 		duration := time.Since(start)
 
 		if err == nil {
@@ -136,7 +142,34 @@ func (e *traceExporterImp) consumeTrace(ctx context.Context, td ptrace.Traces) e
 	return err
 }
 
-func routingIdentifiersFromTraces(td ptrace.Traces, key routingKey) (map[string]bool, error) {
+// batchesPerEndpoint returns the spans to send to each backend. When the resources of the trace are routed
+// to several backends, each backend only gets the spans of its resources.
+func (e *traceExporterImp) batchesPerEndpoint(td ptrace.Traces, routingIds map[string]bool) map[string]ptrace.Traces {
+	batches := make(map[string]ptrace.Traces)
+	if e.routingKey == traceIDRouting || len(routingIds) == 1 {
+		for rid := range routingIds {
+			batches[e.loadBalancer.Endpoint([]byte(rid))] = td
+		}
+		return batches
+	}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		// the routing IDs of all the resources have been found already
+		rid, _ := resourceRoutingID(rs.Resource(), e.routingKey, e.routingAttrs)
+		endpoint := e.loadBalancer.Endpoint([]byte(rid))
+		batch, ok := batches[endpoint]
+		if !ok {
+			batch = ptrace.NewTraces()
+			batches[endpoint] = batch
+		}
+		rs.CopyTo(batch.ResourceSpans().AppendEmpty())
+	}
+	return batches
+}
+
+func routingIdentifiersFromTraces(td ptrace.Traces, key routingKey, attrs []string) (map[string]bool, error) {
 	ids := make(map[string]bool)
 	rs := td.ResourceSpans()
 	if rs.Len() == 0 {
@@ -153,13 +186,13 @@ func routingIdentifiersFromTraces(td ptrace.Traces, key routingKey) (map[string]
 		return nil, errors.New("empty spans")
 	}
 
-	if key == svcRouting {
+	if key == svcRouting || key == attrRouting {
 		for i := 0; i < rs.Len(); i++ {
-			svc, ok := rs.At(i).Resource().Attributes().Get("service.name")
-			if !ok {
-				return nil, errors.New("unable to get service name")
+			id, err := resourceRoutingID(rs.At(i).Resource(), key, attrs)
+			if err != nil {
+				return nil, err
 			}
-			ids[svc.StringVal()] = true
+			ids[id] = true
 		}
 		return ids, nil
 	}
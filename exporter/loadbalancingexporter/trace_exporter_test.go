@@ -220,13 +220,109 @@ func TestServiceBasedRoutingForSameTraceId(t *testing.T) {
 		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
-			res, err := routingIdentifiersFromTraces(tt.batch, tt.routingKey)
+			res, err := routingIdentifiersFromTraces(tt.batch, tt.routingKey, nil)
 			assert.Equal(t, err, nil)
 			assert.Equal(t, res, tt.res)
 		})
 	}
 }
 
+func TestAttributesBasedRouting(t *testing.T) {
+	// prepare
+	batch := twoServicesWithSameTraceID()
+	batch.ResourceSpans().At(0).Resource().Attributes().PutString("tenant", "acme")
+
+	// test
+	res, err := routingIdentifiersFromTraces(batch, attrRouting, []string{"tenant", "service.name"})
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"acme;ad-service-1": true, ";get-recommendations-7": true}, res)
+}
+
+func TestConsumeTracesAttributesSplitsResources(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.RoutingKey = "attributes"
+	cfg.RoutingAttributes = []string{"tenant"}
+
+	sinks := map[string]*consumertest.TracesSink{}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		sink := new(consumertest.TracesSink)
+		sinks[endpoint] = sink
+		return newMockTracesExporter(sink.ConsumeTraces), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NoError(t, err)
+
+	p, err := newTracesExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1", "endpoint-2"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// find two tenants routed to different backends
+	tenants := []string{"tenant-0"}
+	for i := 1; len(tenants) < 2; i++ {
+		tenant := fmt.Sprintf("tenant-%d", i)
+		if lb.Endpoint([]byte(tenant)) != lb.Endpoint([]byte(tenants[0])) {
+			tenants = append(tenants, tenant)
+		}
+	}
+
+	// the same trace spans the resources of both tenants
+	td := twoServicesWithSameTraceID()
+	for i, tenant := range tenants {
+		td.ResourceSpans().At(i).Resource().Attributes().PutString("tenant", tenant)
+	}
+
+	// test
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+	// verify
+	for _, tenant := range tenants {
+		sink := sinks[endpointWithPort(lb.Endpoint([]byte(tenant)))]
+		require.NotNil(t, sink)
+		require.Len(t, sink.AllTraces(), 1)
+		received := sink.AllTraces()[0].ResourceSpans()
+		require.Equal(t, 1, received.Len())
+		value, _ := received.At(0).Resource().Attributes().Get("tenant")
+		assert.Equal(t, tenant, value.StringVal())
+	}
+}
+
+func TestNewTracesExporterAttributesRouting(t *testing.T) {
+	cfg := simpleConfig()
+	cfg.RoutingKey = "attributes"
+
+	// test
+	_, err := newTracesExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+
+	// verify
+	assert.Equal(t, errNoRoutingAttributes, err)
+
+	// prepare
+	cfg.RoutingAttributes = []string{"k8s.pod.uid"}
+
+	// test
+	p, err := newTracesExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, attrRouting, p.routingKey)
+	assert.Equal(t, []string{"k8s.pod.uid"}, p.routingAttrs)
+}
+
 func TestConsumeTracesExporterNoEndpoint(t *testing.T) {
 	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
 		return newNopMockTracesExporter(), nil
@@ -394,7 +490,7 @@ func TestNoTracesInBatch(t *testing.T) {
 		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
-			res, err := routingIdentifiersFromTraces(tt.batch, tt.routingKey)
+			res, err := routingIdentifiersFromTraces(tt.batch, tt.routingKey, nil)
 			assert.Equal(t, err, tt.err)
 			assert.Equal(t, res, map[string]bool(nil))
 		})
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+	compressionLz4    = "lz4"
+)
+
+var compressionCodecs = map[string]parquet.CompressionCodec{
+	compressionNone:   parquet.CompressionCodec_UNCOMPRESSED,
+	compressionSnappy: parquet.CompressionCodec_SNAPPY,
+	compressionGzip:   parquet.CompressionCodec_GZIP,
+	compressionZstd:   parquet.CompressionCodec_ZSTD,
+	compressionLz4:    parquet.CompressionCodec_LZ4,
+}
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the directory the Parquet files are written to. One set of files
+	// is written per signal (and per metric type), see the README for the layout.
+	Path string `mapstructure:"path"`
+
+	// Compression is the codec used to compress column chunks.
+	// Options: none, snappy[default], gzip, zstd, lz4.
+	Compression string `mapstructure:"compression"`
+
+	// RowGroupMegabytes is the approximate uncompressed size in megabytes of a
+	// row group before it is flushed to the file. It defaults to 16 megabytes.
+	RowGroupMegabytes int `mapstructure:"row_group_megabytes"`
+
+	// Rotation defines when the current file is closed and a new one started.
+	Rotation Rotation `mapstructure:"rotation"`
+}
+
+// Rotation defines the options for rolling over Parquet files.
+type Rotation struct {
+	// MaxMegabytes is the maximum size in megabytes of a file before it gets
+	// rotated. It defaults to 128 megabytes, 0 disables size based rotation.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// MaxAge is the maximum amount of time a file is kept open for writing
+	// before it gets rotated. It defaults to 5 minutes, 0 disables time based
+	// rotation. Files only become readable once they are rotated or the
+	// exporter is shut down.
+	MaxAge time.Duration `mapstructure:"max_age"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if _, ok := compressionCodecs[cfg.Compression]; !ok {
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	if cfg.RowGroupMegabytes <= 0 {
+		return errors.New("row_group_megabytes must be positive")
+	}
+	if cfg.Rotation.MaxMegabytes < 0 {
+		return errors.New("rotation max_megabytes must not be negative")
+	}
+	if cfg.Rotation.MaxAge < 0 {
+		return errors.New("rotation max_age must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters[config.NewComponentID(typeStr)]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Path = "/var/output"
+	assert.Equal(t, defaultCfg, e0)
+
+	e1 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "2")]
+	assert.Equal(t,
+		&Config{
+			ExporterSettings:  config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
+			Path:              "/var/output/custom",
+			Compression:       compressionZstd,
+			RowGroupMegabytes: 8,
+			Rotation: Rotation{
+				MaxMegabytes: 64,
+				MaxAge:       time.Hour,
+			},
+		}, e1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "empty path",
+			modify: func(cfg *Config) { cfg.Path = "" },
+			err:    "path must be non-empty",
+		},
+		{
+			name:   "unknown compression",
+			modify: func(cfg *Config) { cfg.Compression = "brotli" },
+			err:    `compression "brotli" is not supported`,
+		},
+		{
+			name:   "zero row group size",
+			modify: func(cfg *Config) { cfg.RowGroupMegabytes = 0 },
+			err:    "row_group_megabytes must be positive",
+		},
+		{
+			name:   "negative max size",
+			modify: func(cfg *Config) { cfg.Rotation.MaxMegabytes = -1 },
+			err:    "rotation max_megabytes must not be negative",
+		},
+		{
+			name:   "negative max age",
+			modify: func(cfg *Config) { cfg.Rotation.MaxAge = -time.Second },
+			err:    "rotation max_age must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Path = t.TempDir()
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -18,31 +18,345 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// File name prefixes, one per Parquet schema.
+const (
+	spansPrefix                = "spans"
+	logsPrefix                 = "logs"
+	gaugePrefix                = "metrics_gauge"
+	sumPrefix                  = "metrics_sum"
+	histogramPrefix            = "metrics_histogram"
+	exponentialHistogramPrefix = "metrics_exponential_histogram"
+	summaryPrefix              = "metrics_summary"
 )
 
 type parquetExporter struct {
-	path string
+	// writers holds one writer per file prefix handled by this exporter.
+	writers map[string]*fileWriter
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func newTracesExporter(cfg *Config, logger *zap.Logger) *parquetExporter {
+	return &parquetExporter{writers: map[string]*fileWriter{
+		spansPrefix: newFileWriter(cfg, spansPrefix, new(spanRow), logger),
+	}}
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
-	return nil
+func newMetricsExporter(cfg *Config, logger *zap.Logger) *parquetExporter {
+	return &parquetExporter{writers: map[string]*fileWriter{
+		gaugePrefix:                newFileWriter(cfg, gaugePrefix, new(gaugeRow), logger),
+		sumPrefix:                  newFileWriter(cfg, sumPrefix, new(sumRow), logger),
+		histogramPrefix:            newFileWriter(cfg, histogramPrefix, new(histogramRow), logger),
+		exponentialHistogramPrefix: newFileWriter(cfg, exponentialHistogramPrefix, new(exponentialHistogramRow), logger),
+		summaryPrefix:              newFileWriter(cfg, summaryPrefix, new(summaryRow), logger),
+	}}
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+func newLogsExporter(cfg *Config, logger *zap.Logger) *parquetExporter {
+	return &parquetExporter{writers: map[string]*fileWriter{
+		logsPrefix: newFileWriter(cfg, logsPrefix, new(logRow), logger),
+	}}
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
+func (e *parquetExporter) start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) shutdown(ctx context.Context) error {
+	var errs error
+	for _, w := range e.writers {
+		errs = multierr.Append(errs, w.close())
+	}
+	return errs
+}
+
+func (e *parquetExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
+	var rows []interface{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceAttrs := attributesToMap(rs.Resource().Attributes())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				rows = append(rows, newSpanRow(resourceAttrs, ss.Scope(), ss.Spans().At(k)))
+			}
+		}
+	}
+	return e.writers[spansPrefix].write(rows)
+}
+
+func (e *parquetExporter) consumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	rows := make(map[string][]interface{})
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceAttrs := attributesToMap(rm.Resource().Attributes())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				appendMetricRows(rows, resourceAttrs, sm.Scope(), sm.Metrics().At(k))
+			}
+		}
+	}
+
+	var errs error
+	for prefix, r := range rows {
+		errs = multierr.Append(errs, e.writers[prefix].write(r))
+	}
+	return errs
+}
+
+func (e *parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
+	var rows []interface{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceAttrs := attributesToMap(rl.Resource().Attributes())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				rows = append(rows, newLogRow(resourceAttrs, sl.Scope(), sl.LogRecords().At(k)))
+			}
+		}
+	}
+	return e.writers[logsPrefix].write(rows)
+}
+
+func newSpanRow(resourceAttrs map[string]string, scope pcommon.InstrumentationScope, span ptrace.Span) spanRow {
+	row := spanRow{
+		ResourceAttributes:     resourceAttrs,
+		ScopeName:              scope.Name(),
+		ScopeVersion:           scope.Version(),
+		TraceID:                span.TraceID().HexString(),
+		SpanID:                 span.SpanID().HexString(),
+		ParentSpanID:           span.ParentSpanID().HexString(),
+		TraceState:             span.TraceStateStruct().AsRaw(),
+		Name:                   span.Name(),
+		Kind:                   span.Kind().String(),
+		StartTime:              int64(span.StartTimestamp()),
+		EndTime:                int64(span.EndTimestamp()),
+		DurationNanos:          int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+		Attributes:             attributesToMap(span.Attributes()),
+		DroppedAttributesCount: int64(span.DroppedAttributesCount()),
+		Events:                 make([]spanEventRow, 0, span.Events().Len()),
+		DroppedEventsCount:     int64(span.DroppedEventsCount()),
+		Links:                  make([]spanLinkRow, 0, span.Links().Len()),
+		DroppedLinksCount:      int64(span.DroppedLinksCount()),
+		StatusCode:             span.Status().Code().String(),
+		StatusMessage:          span.Status().Message(),
+	}
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		row.Events = append(row.Events, spanEventRow{
+			Time:                   int64(event.Timestamp()),
+			Name:                   event.Name(),
+			Attributes:             attributesToMap(event.Attributes()),
+			DroppedAttributesCount: int64(event.DroppedAttributesCount()),
+		})
+	}
+	for i := 0; i < span.Links().Len(); i++ {
+		link := span.Links().At(i)
+		row.Links = append(row.Links, spanLinkRow{
+			TraceID:                link.TraceID().HexString(),
+			SpanID:                 link.SpanID().HexString(),
+			TraceState:             link.TraceStateStruct().AsRaw(),
+			Attributes:             attributesToMap(link.Attributes()),
+			DroppedAttributesCount: int64(link.DroppedAttributesCount()),
+		})
+	}
+	return row
+}
+
+func newLogRow(resourceAttrs map[string]string, scope pcommon.InstrumentationScope, lr plog.LogRecord) logRow {
+	return logRow{
+		ResourceAttributes:     resourceAttrs,
+		ScopeName:              scope.Name(),
+		ScopeVersion:           scope.Version(),
+		Time:                   int64(lr.Timestamp()),
+		ObservedTime:           int64(lr.ObservedTimestamp()),
+		TraceID:                lr.TraceID().HexString(),
+		SpanID:                 lr.SpanID().HexString(),
+		Flags:                  int64(lr.Flags()),
+		SeverityText:           lr.SeverityText(),
+		SeverityNumber:         int32(lr.SeverityNumber()),
+		Body:                   lr.Body().AsString(),
+		Attributes:             attributesToMap(lr.Attributes()),
+		DroppedAttributesCount: int64(lr.DroppedAttributesCount()),
+	}
+}
+
+// appendMetricRows converts every data point of the metric to a row of the
+// schema matching its type and appends it to rows under the schema prefix.
+func appendMetricRows(rows map[string][]interface{}, resourceAttrs map[string]string, scope pcommon.InstrumentationScope, metric pmetric.Metric) {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			doubleValue, intValue := numberValue(dp)
+			rows[gaugePrefix] = append(rows[gaugePrefix], gaugeRow{
+				ResourceAttributes: resourceAttrs,
+				ScopeName:          scope.Name(),
+				ScopeVersion:       scope.Version(),
+				MetricName:         metric.Name(),
+				MetricDescription:  metric.Description(),
+				MetricUnit:         metric.Unit(),
+				Attributes:         attributesToMap(dp.Attributes()),
+				StartTime:          int64(dp.StartTimestamp()),
+				Time:               int64(dp.Timestamp()),
+				Flags:              int64(dp.Flags()),
+				DoubleValue:        doubleValue,
+				IntValue:           intValue,
+			})
+		}
+	case pmetric.MetricDataTypeSum:
+		sum := metric.Sum()
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			doubleValue, intValue := numberValue(dp)
+			rows[sumPrefix] = append(rows[sumPrefix], sumRow{
+				ResourceAttributes:     resourceAttrs,
+				ScopeName:              scope.Name(),
+				ScopeVersion:           scope.Version(),
+				MetricName:             metric.Name(),
+				MetricDescription:      metric.Description(),
+				MetricUnit:             metric.Unit(),
+				AggregationTemporality: sum.AggregationTemporality().String(),
+				IsMonotonic:            sum.IsMonotonic(),
+				Attributes:             attributesToMap(dp.Attributes()),
+				StartTime:              int64(dp.StartTimestamp()),
+				Time:                   int64(dp.Timestamp()),
+				Flags:                  int64(dp.Flags()),
+				DoubleValue:            doubleValue,
+				IntValue:               intValue,
+			})
+		}
+	case pmetric.MetricDataTypeHistogram:
+		histogram := metric.Histogram()
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := histogramRow{
+				ResourceAttributes:     resourceAttrs,
+				ScopeName:              scope.Name(),
+				ScopeVersion:           scope.Version(),
+				MetricName:             metric.Name(),
+				MetricDescription:      metric.Description(),
+				MetricUnit:             metric.Unit(),
+				AggregationTemporality: histogram.AggregationTemporality().String(),
+				Attributes:             attributesToMap(dp.Attributes()),
+				StartTime:              int64(dp.StartTimestamp()),
+				Time:                   int64(dp.Timestamp()),
+				Flags:                  int64(dp.Flags()),
+				Count:                  int64(dp.Count()),
+				BucketCounts:           uint64SliceToInt64(dp.BucketCounts()),
+				ExplicitBounds:         dp.ExplicitBounds().AsRaw(),
+			}
+			if dp.HasSum() {
+				row.Sum = float64Ptr(dp.Sum())
+			}
+			if dp.HasMin() {
+				row.Min = float64Ptr(dp.Min())
+			}
+			if dp.HasMax() {
+				row.Max = float64Ptr(dp.Max())
+			}
+			rows[histogramPrefix] = append(rows[histogramPrefix], row)
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		histogram := metric.ExponentialHistogram()
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := exponentialHistogramRow{
+				ResourceAttributes:     resourceAttrs,
+				ScopeName:              scope.Name(),
+				ScopeVersion:           scope.Version(),
+				MetricName:             metric.Name(),
+				MetricDescription:      metric.Description(),
+				MetricUnit:             metric.Unit(),
+				AggregationTemporality: histogram.AggregationTemporality().String(),
+				Attributes:             attributesToMap(dp.Attributes()),
+				StartTime:              int64(dp.StartTimestamp()),
+				Time:                   int64(dp.Timestamp()),
+				Flags:                  int64(dp.Flags()),
+				Count:                  int64(dp.Count()),
+				Scale:                  dp.Scale(),
+				ZeroCount:              int64(dp.ZeroCount()),
+				PositiveOffset:         dp.Positive().Offset(),
+				PositiveBucketCounts:   uint64SliceToInt64(dp.Positive().BucketCounts()),
+				NegativeOffset:         dp.Negative().Offset(),
+				NegativeBucketCounts:   uint64SliceToInt64(dp.Negative().BucketCounts()),
+			}
+			if dp.HasSum() {
+				row.Sum = float64Ptr(dp.Sum())
+			}
+			rows[exponentialHistogramPrefix] = append(rows[exponentialHistogramPrefix], row)
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := summaryRow{
+				ResourceAttributes: resourceAttrs,
+				ScopeName:          scope.Name(),
+				ScopeVersion:       scope.Version(),
+				MetricName:         metric.Name(),
+				MetricDescription:  metric.Description(),
+				MetricUnit:         metric.Unit(),
+				Attributes:         attributesToMap(dp.Attributes()),
+				StartTime:          int64(dp.StartTimestamp()),
+				Time:               int64(dp.Timestamp()),
+				Flags:              int64(dp.Flags()),
+				Count:              int64(dp.Count()),
+				Sum:                dp.Sum(),
+				QuantileValues:     make([]summaryQuantileRow, 0, dp.QuantileValues().Len()),
+			}
+			for q := 0; q < dp.QuantileValues().Len(); q++ {
+				qv := dp.QuantileValues().At(q)
+				row.QuantileValues = append(row.QuantileValues, summaryQuantileRow{Quantile: qv.Quantile(), Value: qv.Value()})
+			}
+			rows[summaryPrefix] = append(rows[summaryPrefix], row)
+		}
+	}
+}
+
+func numberValue(dp pmetric.NumberDataPoint) (*float64, *int64) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		return float64Ptr(dp.DoubleVal()), nil
+	case pmetric.NumberDataPointValueTypeInt:
+		v := dp.IntVal()
+		return nil, &v
+	}
+	return nil, nil
+}
+
+func attributesToMap(attrs pcommon.Map) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+func uint64SliceToInt64(s pcommon.UInt64Slice) []int64 {
+	res := make([]int64, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		res[i] = int64(s.At(i))
+	}
+	return res
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
 }
@@ -0,0 +1,329 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func testConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	return cfg
+}
+
+func readRows[T any](t *testing.T, file string) []T {
+	fr, err := local.NewLocalFileReader(file)
+	require.NoError(t, err)
+	defer fr.Close()
+
+	pr, err := reader.NewParquetReader(fr, new(T), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	rows := make([]T, pr.GetNumRows())
+	require.NoError(t, pr.Read(&rows))
+	return rows
+}
+
+func globFiles(t *testing.T, dir, prefix string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, prefix+"-*"+fileExtension))
+	require.NoError(t, err)
+	return files
+}
+
+func TestConsumeTraces(t *testing.T) {
+	cfg := testConfig(t)
+	exp := newTracesExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutString("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("lib")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(1000)
+	span.SetEndTimestamp(3500)
+	span.Attributes().PutInt("http.status_code", 200)
+	span.Status().SetCode(ptrace.StatusCodeOk)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(2000)
+	event.Attributes().PutString("exception.type", "io")
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.NewTraceID([16]byte{16}))
+
+	require.NoError(t, exp.consumeTraces(context.Background(), td))
+	assert.Empty(t, globFiles(t, cfg.Path, spansPrefix), "files must only be visible once finished")
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := globFiles(t, cfg.Path, spansPrefix)
+	require.Len(t, files, 1)
+
+	rows := readRows[spanRow](t, files[0])
+	require.Len(t, rows, 1)
+	row := rows[0]
+	assert.Equal(t, map[string]string{"service.name": "checkout"}, row.ResourceAttributes)
+	assert.Equal(t, "lib", row.ScopeName)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", row.TraceID)
+	assert.Equal(t, "0102030405060708", row.SpanID)
+	assert.Equal(t, "GET /cart", row.Name)
+	assert.Equal(t, "SPAN_KIND_SERVER", row.Kind)
+	assert.Equal(t, int64(2500), row.DurationNanos)
+	assert.Equal(t, map[string]string{"http.status_code": "200"}, row.Attributes)
+	assert.Equal(t, "STATUS_CODE_OK", row.StatusCode)
+	require.Len(t, row.Events, 1)
+	assert.Equal(t, "exception", row.Events[0].Name)
+	assert.Equal(t, int64(2000), row.Events[0].Time)
+	assert.Equal(t, map[string]string{"exception.type": "io"}, row.Events[0].Attributes)
+	require.Len(t, row.Links, 1)
+	assert.Equal(t, "10000000000000000000000000000000", row.Links[0].TraceID)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	cfg := testConfig(t)
+	exp := newMetricsExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetEmptyGauge()
+	gauge.Gauge().DataPoints().AppendEmpty().SetDoubleVal(1.5)
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("sum")
+	sum.SetEmptySum()
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sumDp := sum.Sum().DataPoints().AppendEmpty()
+	sumDp.SetIntVal(42)
+	sumDp.Attributes().PutString("host", "a")
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetEmptyHistogram()
+	histogramDp := histogram.Histogram().DataPoints().AppendEmpty()
+	histogramDp.SetCount(3)
+	histogramDp.SetSum(6)
+	histogramDp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2}))
+	histogramDp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{2.5}))
+
+	expHistogram := sm.Metrics().AppendEmpty()
+	expHistogram.SetName("exponential_histogram")
+	expHistogram.SetEmptyExponentialHistogram()
+	expHistogramDp := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	expHistogramDp.SetScale(2)
+	expHistogramDp.Positive().SetOffset(1)
+	expHistogramDp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{4, 5}))
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("summary")
+	summary.SetEmptySummary()
+	summaryDp := summary.Summary().DataPoints().AppendEmpty()
+	summaryDp.SetCount(10)
+	qv := summaryDp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.99)
+	qv.SetValue(12)
+
+	require.NoError(t, exp.consumeMetrics(context.Background(), md))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := globFiles(t, cfg.Path, gaugePrefix)
+	require.Len(t, files, 1)
+	gauges := readRows[gaugeRow](t, files[0])
+	require.Len(t, gauges, 1)
+	assert.Equal(t, "gauge", gauges[0].MetricName)
+	require.NotNil(t, gauges[0].DoubleValue)
+	assert.Equal(t, 1.5, *gauges[0].DoubleValue)
+	assert.Nil(t, gauges[0].IntValue)
+
+	files = globFiles(t, cfg.Path, sumPrefix)
+	require.Len(t, files, 1)
+	sums := readRows[sumRow](t, files[0])
+	require.Len(t, sums, 1)
+	assert.True(t, sums[0].IsMonotonic)
+	assert.Equal(t, "AGGREGATION_TEMPORALITY_CUMULATIVE", sums[0].AggregationTemporality)
+	require.NotNil(t, sums[0].IntValue)
+	assert.Equal(t, int64(42), *sums[0].IntValue)
+	assert.Equal(t, map[string]string{"host": "a"}, sums[0].Attributes)
+
+	files = globFiles(t, cfg.Path, histogramPrefix)
+	require.Len(t, files, 1)
+	histograms := readRows[histogramRow](t, files[0])
+	require.Len(t, histograms, 1)
+	assert.Equal(t, int64(3), histograms[0].Count)
+	require.NotNil(t, histograms[0].Sum)
+	assert.Equal(t, 6.0, *histograms[0].Sum)
+	assert.Nil(t, histograms[0].Min)
+	assert.Equal(t, []int64{1, 2}, histograms[0].BucketCounts)
+	assert.Equal(t, []float64{2.5}, histograms[0].ExplicitBounds)
+
+	files = globFiles(t, cfg.Path, exponentialHistogramPrefix)
+	require.Len(t, files, 1)
+	expHistograms := readRows[exponentialHistogramRow](t, files[0])
+	require.Len(t, expHistograms, 1)
+	assert.Equal(t, int32(2), expHistograms[0].Scale)
+	assert.Equal(t, int32(1), expHistograms[0].PositiveOffset)
+	assert.Equal(t, []int64{4, 5}, expHistograms[0].PositiveBucketCounts)
+
+	files = globFiles(t, cfg.Path, summaryPrefix)
+	require.Len(t, files, 1)
+	summaries := readRows[summaryRow](t, files[0])
+	require.Len(t, summaries, 1)
+	assert.Equal(t, int64(10), summaries[0].Count)
+	assert.Equal(t, []summaryQuantileRow{{Quantile: 0.99, Value: 12}}, summaries[0].QuantileValues)
+}
+
+func TestConsumeLogs(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Compression = compressionZstd
+	exp := newLogsExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(10, 0)))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText("WARN")
+	lr.Body().SetStringVal("disk almost full")
+	lr.Attributes().PutBool("retry", true)
+
+	require.NoError(t, exp.consumeLogs(context.Background(), ld))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := globFiles(t, cfg.Path, logsPrefix)
+	require.Len(t, files, 1)
+	rows := readRows[logRow](t, files[0])
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(10*time.Second), rows[0].Time)
+	assert.Equal(t, int32(plog.SeverityNumberWarn), rows[0].SeverityNumber)
+	assert.Equal(t, "WARN", rows[0].SeverityText)
+	assert.Equal(t, "disk almost full", rows[0].Body)
+	assert.Equal(t, map[string]string{"retry": "true"}, rows[0].Attributes)
+}
+
+func TestRotateBySize(t *testing.T) {
+	cfg := testConfig(t)
+	w := newFileWriter(cfg, logsPrefix, new(logRow), zap.NewNop())
+	// Any file is larger than one byte, so every write finishes the current file.
+	w.maxSize = 1
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, w.write([]interface{}{logRow{Body: "line"}}))
+	}
+	assert.Len(t, globFiles(t, cfg.Path, logsPrefix), 3)
+	require.NoError(t, w.close())
+	assert.Len(t, globFiles(t, cfg.Path, logsPrefix), 3)
+	assert.Error(t, w.write([]interface{}{logRow{Body: "line"}}))
+}
+
+func TestRotateByAge(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Rotation.MaxAge = 10 * time.Millisecond
+	w := newFileWriter(cfg, logsPrefix, new(logRow), zap.NewNop())
+
+	require.NoError(t, w.write([]interface{}{logRow{Body: "line"}}))
+	assert.Eventually(t, func() bool {
+		return len(globFiles(t, cfg.Path, logsPrefix)) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, w.write([]interface{}{logRow{Body: "line"}}))
+	require.NoError(t, w.close())
+	assert.Len(t, globFiles(t, cfg.Path, logsPrefix), 2)
+}
+
+func TestUniqueFileNames(t *testing.T) {
+	cfg := testConfig(t)
+	now := time.Date(2022, 9, 16, 16, 33, 48, 0, time.UTC)
+	newWriter := func() *fileWriter {
+		w := newFileWriter(cfg, logsPrefix, new(logRow), zap.NewNop())
+		w.now = func() time.Time { return now }
+		return w
+	}
+
+	// a restarted collector gets a different name, even within the same second
+	first := newWriter()
+	require.NoError(t, first.write([]interface{}{logRow{Body: "first"}}))
+	require.NoError(t, first.close())
+	now = now.Add(time.Millisecond)
+	second := newWriter()
+	require.NoError(t, second.write([]interface{}{logRow{Body: "second"}}))
+	require.NoError(t, second.close())
+	assert.Len(t, globFiles(t, cfg.Path, logsPrefix), 2)
+
+	// an existing file is never overwritten
+	third := newWriter()
+	assert.ErrorIs(t, third.write([]interface{}{logRow{Body: "third"}}), os.ErrExist)
+	files := globFiles(t, cfg.Path, logsPrefix)
+	require.Len(t, files, 2)
+	assert.Equal(t, "second", readRows[logRow](t, files[1])[0].Body)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteFailureDropsPartialFile(t *testing.T) {
+	cfg := testConfig(t)
+	w := newFileWriter(cfg, logsPrefix, new(logRow), zap.NewNop())
+	require.NoError(t, w.write([]interface{}{logRow{Body: "line"}}))
+
+	// the pages are only written once enough rows are buffered, fail in the middle of the batch
+	w.cw.w = failingWriter{}
+	w.pw.RowGroupSize = 0
+	rows := make([]interface{}, 10000)
+	for i := range rows {
+		rows[i] = logRow{Body: strings.Repeat("x", 100)}
+	}
+	assert.Error(t, w.write(rows))
+	inProgress, err := filepath.Glob(filepath.Join(cfg.Path, "*"))
+	require.NoError(t, err)
+	assert.Empty(t, inProgress)
+
+	// the retried batch goes to a new file
+	require.NoError(t, w.write([]interface{}{logRow{Body: "retried"}}))
+	require.NoError(t, w.close())
+	files := globFiles(t, cfg.Path, logsPrefix)
+	require.Len(t, files, 1)
+	retried := readRows[logRow](t, files[0])
+	require.Len(t, retried, 1)
+	assert.Equal(t, "retried", retried[0].Body)
+}
@@ -16,6 +16,7 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -27,12 +28,12 @@ const (
 	typeStr = "parquet"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelInDevelopment
-)
 
-type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
-	Path                    string `mapstructure:"path"`
-}
+	defaultCompression       = compressionSnappy
+	defaultRowGroupMegabytes = 16
+	defaultMaxMegabytes      = 128
+	defaultMaxAge            = 5 * time.Minute
+)
 
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() component.ExporterFactory {
@@ -46,7 +47,13 @@ func NewFactory() component.ExporterFactory {
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		ExporterSettings:  config.NewExporterSettings(config.NewComponentID(typeStr)),
+		Compression:       defaultCompression,
+		RowGroupMegabytes: defaultRowGroupMegabytes,
+		Rotation: Rotation{
+			MaxMegabytes: defaultMaxMegabytes,
+			MaxAge:       defaultMaxAge,
+		},
 	}
 }
 
@@ -55,7 +62,7 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newTracesExporter(cfg.(*Config), set.Logger)
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -71,7 +78,7 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newMetricsExporter(cfg.(*Config), set.Logger)
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -87,7 +94,7 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newLogsExporter(cfg.(*Config), set.Logger)
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+// The structs in this file define the Parquet schema of every file kind
+// written by the exporter. Attribute maps are flattened to MAP<STRING, STRING>
+// columns, non string attribute values are stored in their string (JSON for
+// maps and slices) representation. Timestamps are stored as nanosecond
+// precision UTC timestamps.
+
+// spanRow is a single span, including its events and links.
+type spanRow struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ParentSpanID           string            `parquet:"name=parent_span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState             string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Name                   string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Kind                   string            `parquet:"name=kind, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StartTime              int64             `parquet:"name=start_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	EndTime                int64             `parquet:"name=end_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	DurationNanos          int64             `parquet:"name=duration_nanos, type=INT64"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+	Events                 []spanEventRow    `parquet:"name=events, type=LIST"`
+	DroppedEventsCount     int64             `parquet:"name=dropped_events_count, type=INT64"`
+	Links                  []spanLinkRow     `parquet:"name=links, type=LIST"`
+	DroppedLinksCount      int64             `parquet:"name=dropped_links_count, type=INT64"`
+	StatusCode             string            `parquet:"name=status_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StatusMessage          string            `parquet:"name=status_message, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// spanEventRow is an element of spanRow.Events.
+type spanEventRow struct {
+	Time                   int64             `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Name                   string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+}
+
+// spanLinkRow is an element of spanRow.Links.
+type spanLinkRow struct {
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState             string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+}
+
+// logRow is a single log record.
+type logRow struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Time                   int64             `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	ObservedTime           int64             `parquet:"name=observed_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+	SeverityText           string            `parquet:"name=severity_text, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SeverityNumber         int32             `parquet:"name=severity_number, type=INT32"`
+	Body                   string            `parquet:"name=body, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+}
+
+// gaugeRow is a single data point of a gauge metric.
+type gaugeRow struct {
+	ResourceAttributes map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion       string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName         string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription  string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit         string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes         map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTime          int64             `parquet:"name=start_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Time               int64             `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags              int64             `parquet:"name=flags, type=INT64"`
+	DoubleValue        *float64          `parquet:"name=double_value, type=DOUBLE, repetitiontype=OPTIONAL"`
+	IntValue           *int64            `parquet:"name=int_value, type=INT64, repetitiontype=OPTIONAL"`
+}
+
+// sumRow is a single data point of a sum metric.
+type sumRow struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AggregationTemporality string            `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8"`
+	IsMonotonic            bool              `parquet:"name=is_monotonic, type=BOOLEAN"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTime              int64             `parquet:"name=start_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Time                   int64             `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+	DoubleValue            *float64          `parquet:"name=double_value, type=DOUBLE, repetitiontype=OPTIONAL"`
+	IntValue               *int64            `parquet:"name=int_value, type=INT64, repetitiontype=OPTIONAL"`
+}
+
+// histogramRow is a single data point of an explicit bucket histogram metric.
+type histogramRow struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AggregationTemporality string            `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTime              int64             `parquet:"name=start_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Time                   int64             `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+	Count                  int64             `parquet:"name=count, type=INT64"`
+	Sum                    *float64          `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Min                    *float64          `parquet:"name=min, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Max                    *float64          `parquet:"name=max, type=DOUBLE, repetitiontype=OPTIONAL"`
+	BucketCounts           []int64           `parquet:"name=bucket_counts, type=LIST, valuetype=INT64"`
+	ExplicitBounds         []float64         `parquet:"name=explicit_bounds, type=LIST, valuetype=DOUBLE"`
+}
+
+// exponentialHistogramRow is a single data point of an exponential histogram metric.
+type exponentialHistogramRow struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AggregationTemporality string            `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTime              int64             `parquet:"name=start_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Time                   int64             `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+	Count                  int64             `parquet:"name=count, type=INT64"`
+	Sum                    *float64          `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Scale                  int32             `parquet:"name=scale, type=INT32"`
+	ZeroCount              int64             `parquet:"name=zero_count, type=INT64"`
+	PositiveOffset         int32             `parquet:"name=positive_offset, type=INT32"`
+	PositiveBucketCounts   []int64           `parquet:"name=positive_bucket_counts, type=LIST, valuetype=INT64"`
+	NegativeOffset         int32             `parquet:"name=negative_offset, type=INT32"`
+	NegativeBucketCounts   []int64           `parquet:"name=negative_bucket_counts, type=LIST, valuetype=INT64"`
+}
+
+// summaryRow is a single data point of a summary metric.
+type summaryRow struct {
+	ResourceAttributes map[string]string    `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string               `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion       string               `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName         string               `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription  string               `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit         string               `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes         map[string]string    `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTime          int64                `parquet:"name=start_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Time               int64                `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags              int64                `parquet:"name=flags, type=INT64"`
+	Count              int64                `parquet:"name=count, type=INT64"`
+	Sum                float64              `parquet:"name=sum, type=DOUBLE"`
+	QuantileValues     []summaryQuantileRow `parquet:"name=quantile_values, type=LIST"`
+}
+
+// summaryQuantileRow is an element of summaryRow.QuantileValues.
+type summaryQuantileRow struct {
+	Quantile float64 `parquet:"name=quantile, type=DOUBLE"`
+	Value    float64 `parquet:"name=value, type=DOUBLE"`
+}
@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	megabyte = 1024 * 1024
+	// fileExtension is the extension of finished files.
+	fileExtension = ".parquet"
+	// inProgressExtension is appended to files that are still being written,
+	// so that readers globbing for *.parquet never pick up a file without footer.
+	inProgressExtension = ".tmp"
+	// timeFormat is the format of the creation time in the file names. It has
+	// nanosecond precision so that a restarted collector doesn't reuse the
+	// name of a file written before the restart.
+	timeFormat = "20060102T150405.000000000Z"
+)
+
+// fileWriter writes rows of a single schema to a sequence of Parquet files in
+// a directory, rolling over to a new file once the current one grows beyond
+// the configured size or age.
+type fileWriter struct {
+	dir    string
+	prefix string
+	// schema is a pointer to the row struct describing the file schema.
+	schema      interface{}
+	compression parquet.CompressionCodec
+	rowGroup    int64
+	maxSize     int64
+	maxAge      time.Duration
+	logger      *zap.Logger
+	now         func() time.Time
+
+	mu     sync.Mutex
+	file   *os.File
+	name   string
+	cw     *countingWriter
+	pw     *writer.ParquetWriter
+	timer  *time.Timer
+	seq    int
+	rows   int // rows written to the current file
+	closed bool
+}
+
+func newFileWriter(cfg *Config, prefix string, schema interface{}, logger *zap.Logger) *fileWriter {
+	return &fileWriter{
+		dir:         cfg.Path,
+		prefix:      prefix,
+		schema:      schema,
+		compression: compressionCodecs[cfg.Compression],
+		rowGroup:    int64(cfg.RowGroupMegabytes) * megabyte,
+		maxSize:     int64(cfg.Rotation.MaxMegabytes) * megabyte,
+		maxAge:      cfg.Rotation.MaxAge,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// write appends the rows to the current file, opening a new one if needed.
+func (w *fileWriter) write(rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("writer for %q is closed", w.prefix)
+	}
+	if w.pw == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := w.pw.Write(row); err != nil {
+			// The rows written before the failure can't be taken back from the
+			// file, drop it so that they aren't duplicated when the batch is retried.
+			return multierr.Append(fmt.Errorf("failed to write %s row: %w", w.prefix, err), w.abort())
+		}
+		w.rows++
+	}
+	if w.maxSize > 0 && w.size() >= w.maxSize {
+		return w.finish()
+	}
+	return nil
+}
+
+// size estimates the size of the current file, including buffered rows.
+func (w *fileWriter) size() int64 {
+	return w.cw.n + w.pw.Size + w.pw.ObjsSize
+}
+
+func (w *fileWriter) open() error {
+	if err := os.MkdirAll(w.dir, 0750); err != nil {
+		return err
+	}
+
+	w.seq++
+	w.name = filepath.Join(w.dir, fmt.Sprintf("%s-%s-%d%s", w.prefix, w.now().UTC().Format(timeFormat), w.seq, fileExtension))
+	if _, err := os.Lstat(w.name); err == nil {
+		return fmt.Errorf("failed to create %s: %w", w.name, os.ErrExist)
+	}
+	file, err := os.OpenFile(w.name+inProgressExtension, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	cw := &countingWriter{w: file}
+	pw, err := writer.NewParquetWriterFromWriter(cw, w.schema, 1)
+	if err != nil {
+		return multierr.Append(err, file.Close())
+	}
+	pw.CompressionType = w.compression
+	pw.RowGroupSize = w.rowGroup
+
+	w.file, w.cw, w.pw, w.rows = file, cw, pw, 0
+	if w.maxAge > 0 {
+		seq := w.seq
+		w.timer = time.AfterFunc(w.maxAge, func() { w.rotateIfCurrent(seq) })
+	}
+	return nil
+}
+
+// rotateIfCurrent finishes the current file if it is still the one identified by seq.
+func (w *fileWriter) rotateIfCurrent(seq int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pw == nil || w.seq != seq {
+		return
+	}
+	if err := w.finish(); err != nil {
+		w.logger.Error("Failed to rotate Parquet file", zap.String("file", w.name), zap.Error(err))
+	}
+}
+
+// finish writes the footer of the current file and moves it to its final name.
+// It fails rather than replace an existing file with the same name.
+func (w *fileWriter) finish() error {
+	pw, file := w.release()
+
+	err := pw.WriteStop()
+	if closeErr := file.Close(); closeErr != nil {
+		err = multierr.Append(err, closeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to finish %s: %w", w.name, err)
+	}
+	if _, err = os.Lstat(w.name); err == nil {
+		return fmt.Errorf("failed to finish %s: %w", w.name, os.ErrExist)
+	}
+	return os.Rename(w.name+inProgressExtension, w.name)
+}
+
+// abort closes the current file without writing its footer and removes it,
+// dropping the rows it holds.
+func (w *fileWriter) abort() error {
+	rows := w.rows
+	_, file := w.release()
+
+	w.logger.Error("Dropping Parquet file after a failed write", zap.String("file", w.name), zap.Int("rows", rows))
+	err := file.Close()
+	return multierr.Append(err, os.Remove(w.name+inProgressExtension))
+}
+
+// release stops the age based rotation of the current file and detaches it
+// from the writer, so that the next write opens a new one.
+func (w *fileWriter) release() (*writer.ParquetWriter, *os.File) {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	pw, file := w.pw, w.file
+	w.pw, w.file, w.cw, w.rows = nil, nil, nil, 0
+	return pw, file
+}
+
+// close finishes the current file, if any. Later writes fail.
+func (w *fileWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	if w.pw == nil {
+		return nil
+	}
+	return w.finish()
+}
+
+// countingWriter keeps track of the number of bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
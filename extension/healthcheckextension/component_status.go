@@ -0,0 +1,303 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/config"
+)
+
+// status is the health status of a component, of a pipeline or of the whole collector.
+type status string
+
+const (
+	statusStarting         status = "starting"
+	statusOK               status = "ok"
+	statusRecoverableError status = "recoverable_error"
+	statusPermanentError   status = "permanent_error"
+)
+
+// severity orders the statuses, the status of a group of components being the most severe of theirs.
+var severity = map[status]int{
+	statusOK:               0,
+	statusStarting:         1,
+	statusRecoverableError: 2,
+	statusPermanentError:   3,
+}
+
+func worst(a, b status) status {
+	if severity[b] > severity[a] {
+		return b
+	}
+	return a
+}
+
+const (
+	receiverKind  = "receiver"
+	processorKind = "processor"
+	exporterKind  = "exporter"
+)
+
+// observedView tells what a view of the collector's own metrics reports about a component.
+type observedView struct {
+	kind     string
+	pipeline config.DataType
+	failure  bool
+}
+
+// observedViews are the views of the collector's own metrics used to determine the status of the components,
+// keyed by view name. The success and failure counters of each component kind are tagged by the component ID.
+var observedViews = func() map[string]observedView {
+	views := make(map[string]observedView)
+	counters := map[string][2]string{
+		receiverKind:  {"accepted_", "refused_"},
+		processorKind: {"accepted_", "refused_"},
+		exporterKind:  {"sent_", "send_failed_"},
+	}
+	items := map[config.DataType]string{
+		config.TracesDataType:  "spans",
+		config.MetricsDataType: "metric_points",
+		config.LogsDataType:    "log_records",
+	}
+	for kind, prefixes := range counters {
+		for pipeline, item := range items {
+			views[kind+"/"+prefixes[0]+item] = observedView{kind: kind, pipeline: pipeline}
+			views[kind+"/"+prefixes[1]+item] = observedView{kind: kind, pipeline: pipeline, failure: true}
+		}
+	}
+	return views
+}()
+
+type componentKey struct {
+	pipeline config.DataType
+	kind     string
+	id       string
+}
+
+// counterKey identifies a row of a counter view. A component may have several rows,
+// e.g. the rows of a receiver are also tagged by transport.
+type counterKey struct {
+	view string
+	tags string
+}
+
+// componentState keeps the reports of a component within the interval.
+type componentState struct {
+	failures    []time.Time
+	successes   []time.Time
+	lastFailure time.Time
+	lastSuccess time.Time
+}
+
+// componentStatusResponse is the status of a component served by the health check.
+type componentStatusResponse struct {
+	Status      status     `json:"status"`
+	Failures    int        `json:"failures"`
+	LastFailure *time.Time `json:"last_failure,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+}
+
+// pipelineStatusResponse is the status of the components of a pipeline type served by the health check.
+type pipelineStatusResponse struct {
+	Status     status                              `json:"status"`
+	Receivers  map[string]*componentStatusResponse `json:"receivers,omitempty"`
+	Processors map[string]*componentStatusResponse `json:"processors,omitempty"`
+	Exporters  map[string]*componentStatusResponse `json:"exporters,omitempty"`
+}
+
+// statusResponse is the status of the collector served by the health check.
+type statusResponse struct {
+	Status    status                             `json:"status"`
+	Pipelines map[string]*pipelineStatusResponse `json:"pipelines,omitempty"`
+}
+
+// componentStatusTracker is an open census exporter deriving the status of each component, per pipeline type,
+// from the successes and failures reported by the collector's own metrics.
+type componentStatusTracker struct {
+	interval         time.Duration
+	failureThreshold int
+	now              func() time.Time
+
+	mu         sync.Mutex
+	ready      bool
+	components map[componentKey]*componentState
+	// counters keeps the last value of the cumulative counters, to compute what was reported since.
+	counters map[counterKey]float64
+}
+
+var _ view.Exporter = (*componentStatusTracker)(nil)
+
+func newComponentStatusTracker(settings componentStatusSettings) *componentStatusTracker {
+	return &componentStatusTracker{
+		interval:         settings.Interval,
+		failureThreshold: settings.FailureThreshold,
+		now:              time.Now,
+		components:       make(map[componentKey]*componentState),
+		counters:         make(map[counterKey]float64),
+	}
+}
+
+// ExportView records the successes and failures reported for each component since the previous export.
+func (t *componentStatusTracker) ExportView(vd *view.Data) {
+	observed, ok := observedViews[vd.View.Name]
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	// a component having several rows is reported once per export, if any of its rows increased
+	reported := make(map[string]struct{})
+	for _, row := range vd.Rows {
+		var id string
+		tags := make([]string, 0, len(row.Tags))
+		for _, tag := range row.Tags {
+			if tag.Key.Name() == observed.kind {
+				id = tag.Value
+			}
+			tags = append(tags, tag.Key.Name()+"="+tag.Value)
+		}
+		sum, ok := row.Data.(*view.SumData)
+		if id == "" || !ok {
+			continue
+		}
+
+		key := counterKey{view: vd.View.Name, tags: strings.Join(tags, ",")}
+		previous := t.counters[key]
+		t.counters[key] = sum.Value
+		if sum.Value > previous {
+			reported[id] = struct{}{}
+		}
+	}
+
+	for id := range reported {
+		state := t.component(componentKey{pipeline: observed.pipeline, kind: observed.kind, id: id})
+		if observed.failure {
+			state.failures = append(expire(state.failures, now.Add(-t.interval)), now)
+			state.lastFailure = now
+		} else {
+			state.successes = append(expire(state.successes, now.Add(-t.interval)), now)
+			state.lastSuccess = now
+		}
+	}
+}
+
+// addComponent registers a component that hasn't reported anything yet.
+func (t *componentStatusTracker) addComponent(pipeline config.DataType, kind string, id config.ComponentID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.component(componentKey{pipeline: pipeline, kind: kind, id: id.String()})
+}
+
+// component must be called with the lock held.
+func (t *componentStatusTracker) component(key componentKey) *componentState {
+	state, ok := t.components[key]
+	if !ok {
+		state = &componentState{}
+		t.components[key] = state
+	}
+	return state
+}
+
+func (t *componentStatusTracker) setReady(ready bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ready = ready
+}
+
+// status returns the status of each component, of each pipeline type and of the whole collector.
+func (t *componentStatusTracker) status() *statusResponse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	resp := &statusResponse{Status: statusOK, Pipelines: make(map[string]*pipelineStatusResponse)}
+	if !t.ready {
+		resp.Status = statusStarting
+	}
+
+	expiration := t.now().Add(-t.interval)
+	for key, state := range t.components {
+		state.failures = expire(state.failures, expiration)
+		state.successes = expire(state.successes, expiration)
+
+		component := &componentStatusResponse{Status: t.componentStatus(state), Failures: len(state.failures)}
+		if !state.lastFailure.IsZero() {
+			lastFailure := state.lastFailure
+			component.LastFailure = &lastFailure
+		}
+		if !state.lastSuccess.IsZero() {
+			lastSuccess := state.lastSuccess
+			component.LastSuccess = &lastSuccess
+		}
+
+		pipeline, ok := resp.Pipelines[string(key.pipeline)]
+		if !ok {
+			pipeline = &pipelineStatusResponse{Status: statusOK}
+			if !t.ready {
+				pipeline.Status = statusStarting
+			}
+			resp.Pipelines[string(key.pipeline)] = pipeline
+		}
+		pipeline.Status = worst(pipeline.Status, component.Status)
+		resp.Status = worst(resp.Status, component.Status)
+
+		switch key.kind {
+		case receiverKind:
+			pipeline.Receivers = addComponentStatus(pipeline.Receivers, key.id, component)
+		case processorKind:
+			pipeline.Processors = addComponentStatus(pipeline.Processors, key.id, component)
+		case exporterKind:
+			pipeline.Exporters = addComponentStatus(pipeline.Exporters, key.id, component)
+		}
+	}
+	return resp
+}
+
+// componentStatus must be called with the lock held, once the expired reports are removed.
+func (t *componentStatusTracker) componentStatus(state *componentState) status {
+	switch {
+	case len(state.failures) == 0 && !t.ready:
+		return statusStarting
+	case len(state.failures) == 0:
+		return statusOK
+	case len(state.successes) == 0 && len(state.failures) >= t.failureThreshold:
+		return statusPermanentError
+	default:
+		return statusRecoverableError
+	}
+}
+
+func addComponentStatus(components map[string]*componentStatusResponse, id string, component *componentStatusResponse) map[string]*componentStatusResponse {
+	if components == nil {
+		components = make(map[string]*componentStatusResponse)
+	}
+	components[id] = component
+	return components
+}
+
+// expire removes the times before the expiration, the times being sorted.
+func expire(times []time.Time, expiration time.Time) []time.Time {
+	i := 0
+	for i < len(times) && !times[i].After(expiration) {
+		i++
+	}
+	return times[i:]
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/config"
+)
+
+func counterData(t *testing.T, name string, kind string, values map[string]float64) *view.Data {
+	key, err := tag.NewKey(kind)
+	require.NoError(t, err)
+
+	vd := &view.Data{View: &view.View{Name: name}}
+	for id, value := range values {
+		vd.Rows = append(vd.Rows, &view.Row{
+			Tags: []tag.Tag{{Key: key, Value: id}},
+			Data: &view.SumData{Value: value},
+		})
+	}
+	return vd
+}
+
+func newTestTracker(now *time.Time) *componentStatusTracker {
+	tracker := newComponentStatusTracker(componentStatusSettings{
+		Interval:         5 * time.Minute,
+		FailureThreshold: 2,
+	})
+	tracker.now = func() time.Time { return *now }
+	return tracker
+}
+
+func TestComponentStatusTrackerStarting(t *testing.T) {
+	now := time.Now()
+	tracker := newTestTracker(&now)
+	tracker.addComponent(config.TracesDataType, exporterKind, config.NewComponentIDWithName("otlp", "1"))
+
+	resp := tracker.status()
+	assert.Equal(t, statusStarting, resp.Status)
+	require.Contains(t, resp.Pipelines, "traces")
+	assert.Equal(t, statusStarting, resp.Pipelines["traces"].Status)
+	assert.Equal(t, &componentStatusResponse{Status: statusStarting}, resp.Pipelines["traces"].Exporters["otlp/1"])
+
+	tracker.setReady(true)
+	resp = tracker.status()
+	assert.Equal(t, statusOK, resp.Status)
+	assert.Equal(t, statusOK, resp.Pipelines["traces"].Status)
+	assert.Equal(t, &componentStatusResponse{Status: statusOK}, resp.Pipelines["traces"].Exporters["otlp/1"])
+}
+
+func TestComponentStatusTrackerErrors(t *testing.T) {
+	now := time.Now()
+	tracker := newTestTracker(&now)
+	tracker.setReady(true)
+
+	// the exporter sends some logs, and fails to send others
+	tracker.ExportView(counterData(t, "exporter/sent_log_records", exporterKind, map[string]float64{"otlp": 10}))
+	tracker.ExportView(counterData(t, "exporter/send_failed_log_records", exporterKind, map[string]float64{"otlp": 2}))
+	// the receiver refuses everything
+	tracker.ExportView(counterData(t, "receiver/accepted_spans", receiverKind, map[string]float64{"jaeger": 0}))
+	tracker.ExportView(counterData(t, "receiver/refused_spans", receiverKind, map[string]float64{"jaeger": 5}))
+
+	resp := tracker.status()
+	assert.Equal(t, statusRecoverableError, resp.Status)
+	assert.Equal(t, statusRecoverableError, resp.Pipelines["logs"].Status)
+	assert.Equal(t, statusRecoverableError, resp.Pipelines["logs"].Exporters["otlp"].Status)
+	assert.Equal(t, 1, resp.Pipelines["logs"].Exporters["otlp"].Failures)
+	assert.Equal(t, statusRecoverableError, resp.Pipelines["traces"].Receivers["jaeger"].Status)
+
+	// the receiver keeps refusing everything, while nothing new is reported by the exporter
+	now = now.Add(time.Minute)
+	tracker.ExportView(counterData(t, "exporter/send_failed_log_records", exporterKind, map[string]float64{"otlp": 2}))
+	tracker.ExportView(counterData(t, "receiver/refused_spans", receiverKind, map[string]float64{"jaeger": 7}))
+
+	resp = tracker.status()
+	assert.Equal(t, statusPermanentError, resp.Status)
+	assert.Equal(t, statusRecoverableError, resp.Pipelines["logs"].Status)
+	assert.Equal(t, 1, resp.Pipelines["logs"].Exporters["otlp"].Failures)
+	assert.Equal(t, statusPermanentError, resp.Pipelines["traces"].Status)
+	assert.Equal(t, statusPermanentError, resp.Pipelines["traces"].Receivers["jaeger"].Status)
+	assert.Equal(t, 2, resp.Pipelines["traces"].Receivers["jaeger"].Failures)
+	require.NotNil(t, resp.Pipelines["traces"].Receivers["jaeger"].LastFailure)
+	assert.Equal(t, now, *resp.Pipelines["traces"].Receivers["jaeger"].LastFailure)
+
+	// the failures expire
+	now = now.Add(5 * time.Minute)
+	resp = tracker.status()
+	assert.Equal(t, statusOK, resp.Status)
+	assert.Equal(t, statusOK, resp.Pipelines["traces"].Receivers["jaeger"].Status)
+	assert.Equal(t, 0, resp.Pipelines["traces"].Receivers["jaeger"].Failures)
+	assert.Equal(t, statusOK, resp.Pipelines["logs"].Exporters["otlp"].Status)
+}
+
+func TestComponentStatusTrackerIgnoresOtherViews(t *testing.T) {
+	now := time.Now()
+	tracker := newTestTracker(&now)
+	tracker.setReady(true)
+
+	tracker.ExportView(counterData(t, "processor/dropped_spans", processorKind, map[string]float64{"filter": 3}))
+	tracker.ExportView(counterData(t, exporterFailureView, exporterKind, map[string]float64{"otlp": 3}))
+	tracker.ExportView(counterData(t, "exporter/send_failed_spans", "other", map[string]float64{"otlp": 3}))
+
+	resp := tracker.status()
+	assert.Equal(t, statusOK, resp.Status)
+	assert.Empty(t, resp.Pipelines)
+}
+
+func TestComponentStatusTrackerTransports(t *testing.T) {
+	now := time.Now()
+	tracker := newTestTracker(&now)
+	tracker.setReady(true)
+
+	receiverKey, err := tag.NewKey(receiverKind)
+	require.NoError(t, err)
+	transportKey, err := tag.NewKey("transport")
+	require.NoError(t, err)
+	transportData := func(name string, grpc, http float64) *view.Data {
+		return &view.Data{View: &view.View{Name: name}, Rows: []*view.Row{
+			{
+				Tags: []tag.Tag{{Key: receiverKey, Value: "otlp"}, {Key: transportKey, Value: "grpc"}},
+				Data: &view.SumData{Value: grpc},
+			},
+			{
+				Tags: []tag.Tag{{Key: receiverKey, Value: "otlp"}, {Key: transportKey, Value: "http"}},
+				Data: &view.SumData{Value: http},
+			},
+		}}
+	}
+
+	// only the grpc transport receives spans, the http transport refused some a while ago
+	tracker.ExportView(transportData("receiver/accepted_spans", 10, 0))
+	tracker.ExportView(transportData("receiver/refused_spans", 0, 3))
+	now = now.Add(5 * time.Minute)
+	for i := 1; i <= 3; i++ {
+		now = now.Add(time.Minute)
+		tracker.ExportView(transportData("receiver/accepted_spans", float64(10+i), 0))
+		tracker.ExportView(transportData("receiver/refused_spans", 0, 3))
+	}
+
+	resp := tracker.status()
+	require.Contains(t, resp.Pipelines, "traces")
+	receiver := resp.Pipelines["traces"].Receivers["otlp"]
+	assert.Equal(t, statusOK, receiver.Status, "Each transport must be compared against its own previous value")
+	assert.Equal(t, 0, receiver.Failures)
+	require.NotNil(t, receiver.LastSuccess)
+	assert.Equal(t, now, *receiver.LastSuccess)
+}
+
+func TestComponentStatusTrackerRegisteredComponents(t *testing.T) {
+	now := time.Now()
+	tracker := newTestTracker(&now)
+	tracker.setReady(true)
+	tracker.addComponent(config.MetricsDataType, receiverKind, config.NewComponentID("prometheus"))
+	tracker.addComponent(config.MetricsDataType, processorKind, config.NewComponentID("batch"))
+
+	resp := tracker.status()
+	require.Contains(t, resp.Pipelines, "metrics")
+	assert.Equal(t, &componentStatusResponse{Status: statusOK}, resp.Pipelines["metrics"].Receivers["prometheus"],
+		"A registered component that reported nothing has no last success")
+	assert.Equal(t, &componentStatusResponse{Status: statusOK}, resp.Pipelines["metrics"].Processors["batch"])
+}
@@ -35,6 +35,9 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// ComponentStatus contains the settings of the per pipeline type, per component status model
+	ComponentStatus componentStatusSettings `mapstructure:"component_status"`
 }
 
 var _ config.Extension = (*Config)(nil)
@@ -42,6 +45,11 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errInvalidFailureThresholdProvided         = errors.New("bad config: failure_threshold expects a positive number")
+	errInvalidIntervalProvided                 = errors.New("bad config: interval expects a positive duration")
+	errComponentStatusAndCollectorPipeline     = errors.New("bad config: component_status and check_collector_pipeline can't be both enabled")
+	errDuplicatePath                           = errors.New("bad config: path, liveness_path and readiness_path must be different")
+	errInvalidPipelineType                     = errors.New("bad config: pipelines must be of type traces, metrics or logs")
 )
 
 // Validate checks if the extension configuration is valid
@@ -59,6 +67,36 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	if cfg.ComponentStatus.Enabled {
+		return cfg.validateComponentStatus()
+	}
+	return nil
+}
+
+func (cfg *Config) validateComponentStatus() error {
+	if cfg.CheckCollectorPipeline.Enabled {
+		return errComponentStatusAndCollectorPipeline
+	}
+	if cfg.ComponentStatus.Interval <= 0 {
+		return errInvalidIntervalProvided
+	}
+	if cfg.ComponentStatus.FailureThreshold <= 0 {
+		return errInvalidFailureThresholdProvided
+	}
+	if !strings.HasPrefix(cfg.ComponentStatus.LivenessPath, "/") || !strings.HasPrefix(cfg.ComponentStatus.ReadinessPath, "/") {
+		return errInvalidPath
+	}
+	if cfg.Path == cfg.ComponentStatus.LivenessPath || cfg.Path == cfg.ComponentStatus.ReadinessPath ||
+		cfg.ComponentStatus.LivenessPath == cfg.ComponentStatus.ReadinessPath {
+		return errDuplicatePath
+	}
+	for id := range cfg.ComponentStatus.Pipelines {
+		switch config.DataType(id.Type()) {
+		case config.TracesDataType, config.MetricsDataType, config.LogsDataType:
+		default:
+			return errInvalidPipelineType
+		}
+	}
 	return nil
 }
 
@@ -70,3 +108,29 @@ type checkCollectorPipelineSettings struct {
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
 }
+
+type componentStatusSettings struct {
+	// Enabled indicates whether to enable the per component status model.
+	// When enabled, the health check path serves the status of each component as JSON.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval the time range during which the failures of a component are taken into account
+	Interval time.Duration `mapstructure:"interval"`
+	// FailureThreshold is the number of failures reported during the Interval, without any success,
+	// after which a component is considered in permanent error
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// LivenessPath represents the path serving the liveness status, failing when a component
+	// is in permanent error.
+	LivenessPath string `mapstructure:"liveness_path"`
+	// ReadinessPath represents the path serving the readiness status, failing while the collector
+	// is starting or when a component is in permanent error.
+	ReadinessPath string `mapstructure:"readiness_path"`
+	// Pipelines lists the receivers and processors of the collector pipelines, as in the service configuration,
+	// so that they are tracked from the start. Exporters are always tracked from the start.
+	Pipelines map[config.ComponentID]componentStatusPipeline `mapstructure:"pipelines"`
+}
+
+// componentStatusPipeline are the receivers and processors of a pipeline tracked by the component status model.
+type componentStatusPipeline struct {
+	Receivers  []config.ComponentID `mapstructure:"receivers"`
+	Processors []config.ComponentID `mapstructure:"processors"`
+}
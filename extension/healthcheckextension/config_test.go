@@ -17,6 +17,7 @@ package healthcheckextension
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,6 +54,7 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentStatus:        defaultComponentStatusSettings(),
 				Path:                   "/",
 			},
 		},
@@ -68,6 +70,49 @@ func TestLoadConfig(t *testing.T) {
 			id:          config.NewComponentIDWithName(typeStr, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "componentstatus"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:13",
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentStatus: componentStatusSettings{
+					Enabled:          true,
+					Interval:         2 * time.Minute,
+					FailureThreshold: 3,
+					LivenessPath:     "/livez",
+					ReadinessPath:    "/readyz",
+					Pipelines: map[config.ComponentID]componentStatusPipeline{
+						config.NewComponentID("traces"): {
+							Receivers:  []config.ComponentID{config.NewComponentID("otlp")},
+							Processors: []config.ComponentID{config.NewComponentID("batch")},
+						},
+						config.NewComponentIDWithName("metrics", "prom"): {
+							Receivers: []config.ComponentID{config.NewComponentIDWithName("prometheus", "1")},
+						},
+					},
+				},
+				Path: "/health",
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "duplicatepath"),
+			expectedErr: errDuplicatePath,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidfailurethreshold"),
+			expectedErr: errInvalidFailureThresholdProvided,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "componentstatuswithcollectorpipeline"),
+			expectedErr: errComponentStatusAndCollectorPipeline,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidpipeline"),
+			expectedErr: errInvalidPipelineType,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -16,6 +16,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -48,6 +49,7 @@ func createDefaultConfig() config.Extension {
 			Endpoint: defaultEndpoint,
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentStatus:        defaultComponentStatusSettings(),
 		Path:                   "/",
 	}
 }
@@ -66,3 +68,14 @@ func defaultCheckCollectorPipelineSettings() checkCollectorPipelineSettings {
 		ExporterFailureThreshold: 5,
 	}
 }
+
+// defaultComponentStatusSettings returns the default settings for ComponentStatus.
+func defaultComponentStatusSettings() componentStatusSettings {
+	return componentStatusSettings{
+		Enabled:          false,
+		Interval:         5 * time.Minute,
+		FailureThreshold: 5,
+		LivenessPath:     "/liveness",
+		ReadinessPath:    "/readiness",
+	}
+}
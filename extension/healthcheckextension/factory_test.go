@@ -36,6 +36,7 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 			Endpoint: defaultEndpoint,
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentStatus:        defaultComponentStatusSettings(),
 		Path:                   "/",
 	}, cfg)
 
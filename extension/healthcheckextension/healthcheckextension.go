@@ -16,6 +16,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -24,6 +25,7 @@ import (
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap"
 )
 
@@ -34,6 +36,7 @@ type healthCheckExtension struct {
 	server   *http.Server
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
+	tracker  *componentStatusTracker
 	settings component.TelemetrySettings
 }
 
@@ -52,7 +55,39 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		return err
 	}
 
-	if !hc.config.CheckCollectorPipeline.Enabled {
+	if hc.config.ComponentStatus.Enabled {
+		// per component status model
+		for dataType, exporters := range host.GetExporters() {
+			for id := range exporters {
+				hc.tracker.addComponent(dataType, exporterKind, id)
+			}
+		}
+		for pipelineID, pipeline := range hc.config.ComponentStatus.Pipelines {
+			dataType := config.DataType(pipelineID.Type())
+			for _, id := range pipeline.Receivers {
+				hc.tracker.addComponent(dataType, receiverKind, id)
+			}
+			for _, id := range pipeline.Processors {
+				hc.tracker.addComponent(dataType, processorKind, id)
+			}
+		}
+		view.RegisterExporter(hc.tracker)
+
+		mux := http.NewServeMux()
+		mux.Handle(hc.config.Path, hc.statusHandler(isReady))
+		mux.Handle(hc.config.ComponentStatus.LivenessPath, hc.statusHandler(isAlive))
+		mux.Handle(hc.config.ComponentStatus.ReadinessPath, hc.statusHandler(isReady))
+		hc.server.Handler = mux
+		hc.stopCh = make(chan struct{})
+		go func() {
+			defer close(hc.stopCh)
+			defer view.UnregisterExporter(hc.tracker)
+
+			if errHTTP := hc.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+				host.ReportFatalError(errHTTP)
+			}
+		}()
+	} else if !hc.config.CheckCollectorPipeline.Enabled {
 		// Mount HC handler
 		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.state.Handler())
@@ -119,6 +154,32 @@ func (hc *healthCheckExtension) handler() http.Handler {
 	})
 }
 
+// statusHandler serves the status of the components as JSON, failing if the status isn't healthy
+func (hc *healthCheckExtension) statusHandler(healthy func(*statusResponse) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		resp := hc.tracker.status()
+		w.Header().Set("Content-Type", "application/json")
+		if healthy(resp) {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			hc.logger.Warn("Failed to write the health check status", zap.Error(err))
+		}
+	})
+}
+
+// isAlive tells whether the collector is alive, i.e. no component is in permanent error
+func isAlive(resp *statusResponse) bool {
+	return resp.Status != statusPermanentError
+}
+
+// isReady tells whether the collector is ready, i.e. it's started and no component is in permanent error
+func isReady(resp *statusResponse) bool {
+	return resp.Status == statusOK || resp.Status == statusRecoverableError
+}
+
 func (hc *healthCheckExtension) check() bool {
 	return hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
 }
@@ -136,11 +197,17 @@ func (hc *healthCheckExtension) Shutdown(context.Context) error {
 
 func (hc *healthCheckExtension) Ready() error {
 	hc.state.Set(healthcheck.Ready)
+	if hc.tracker != nil {
+		hc.tracker.setReady(true)
+	}
 	return nil
 }
 
 func (hc *healthCheckExtension) NotReady() error {
 	hc.state.Set(healthcheck.Unavailable)
+	if hc.tracker != nil {
+		hc.tracker.setReady(false)
+	}
 	return nil
 }
 
@@ -153,6 +220,9 @@ func newServer(config Config, settings component.TelemetrySettings) *healthCheck
 	}
 
 	hc.state.SetLogger(settings.Logger)
+	if config.ComponentStatus.Enabled {
+		hc.tracker = newComponentStatusTracker(config.ComponentStatus)
+	}
 
 	return hc
 }
@@ -16,6 +16,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"runtime"
@@ -27,6 +28,7 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
@@ -246,6 +248,77 @@ func TestHealthCheckExtensionUsageWithCustomPathWithCheckCollectorPipeline(t *te
 	require.NoError(t, resp3.Body.Close(), "Must be able to close the response")
 }
 
+func TestHealthCheckExtensionUsageWithComponentStatus(t *testing.T) {
+	settings := defaultComponentStatusSettings()
+	settings.Enabled = true
+	settings.FailureThreshold = 1
+	settings.Pipelines = map[config.ComponentID]componentStatusPipeline{
+		config.NewComponentID(config.TracesDataType): {
+			Receivers:  []config.ComponentID{config.NewComponentID("otlp")},
+			Processors: []config.ComponentID{config.NewComponentID("batch")},
+		},
+	}
+	cfg := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentStatus:        settings,
+		Path:                   "/",
+	}
+
+	hcExt := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, hcExt)
+
+	host := &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.TracesDataType: {config.NewComponentID("otlp"): nil},
+		},
+	}
+	require.NoError(t, hcExt.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.Eventuallyf(t, ensureServerRunning(cfg.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+
+	get := func(path string) (int, *statusResponse) {
+		resp, err := http.Get("http://" + cfg.Endpoint + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		status := &statusResponse{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(status))
+		return resp.StatusCode, status
+	}
+
+	code, status := get(settings.LivenessPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, statusStarting, status.Status)
+	assert.Equal(t, statusStarting, status.Pipelines["traces"].Exporters["otlp"].Status)
+	assert.Equal(t, statusStarting, status.Pipelines["traces"].Receivers["otlp"].Status)
+	assert.Equal(t, statusStarting, status.Pipelines["traces"].Processors["batch"].Status)
+	code, _ = get(settings.ReadinessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, _ = get(cfg.Path)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	require.NoError(t, hcExt.Ready())
+	code, status = get(settings.ReadinessPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, statusOK, status.Status)
+	code, _ = get(cfg.Path)
+	assert.Equal(t, http.StatusOK, code)
+
+	hcExt.tracker.ExportView(counterData(t, "exporter/send_failed_spans", exporterKind, map[string]float64{"otlp": 1}))
+	code, status = get(settings.LivenessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, statusPermanentError, status.Status)
+	assert.Equal(t, statusPermanentError, status.Pipelines["traces"].Status)
+	assert.Equal(t, 1, status.Pipelines["traces"].Exporters["otlp"].Failures)
+	code, _ = get(settings.ReadinessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
 func TestHealthCheckExtensionPortAlreadyInUse(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 
@@ -336,3 +409,13 @@ func newAssertNoErrorHost(t *testing.T) component.Host {
 func (aneh *assertNoErrorHost) ReportFatalError(err error) {
 	assert.NoError(aneh, err)
 }
+
+// exportersHost implements a component.Host returning the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (eh *exportersHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return eh.exporters
+}
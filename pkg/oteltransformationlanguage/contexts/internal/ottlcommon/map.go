@@ -16,25 +16,103 @@ package ottlcommon // import "github.com/open-telemetry/opentelemetry-collector-
 
 import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
 )
 
-func GetMapValue(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
+// GetMapValue returns the value found by following the keys from the given map. The first key
+// must be a string; the following ones can be strings, to index nested maps, or ints, to index slices.
+// nil is returned when any of the keys can't be followed.
+func GetMapValue(attrs pcommon.Map, keys []ottl.Key) interface{} {
+	if len(keys) == 0 || keys[0].String == nil {
+		return nil
+	}
+	val, ok := attrs.Get(*keys[0].String)
 	if !ok {
 		return nil
 	}
+	return GetIndexableValue(val, keys[1:])
+}
+
+// GetIndexableValue returns the value found by following the keys from the given value.
+// nil is returned when any of the keys can't be followed.
+func GetIndexableValue(val pcommon.Value, keys []ottl.Key) interface{} {
+	for _, key := range keys {
+		next, ok := index(val, key)
+		if !ok {
+			return nil
+		}
+		val = next
+	}
 	return GetValue(val)
 }
 
-func SetMapValue(attrs pcommon.Map, mapKey string, val interface{}) {
-	var value pcommon.Value
+// SetMapValue sets val at the location found by following the keys from the given map.
+// Missing map entries are created along the way, but slices are never extended: if an index
+// is out of range or a key doesn't match the type of the value it indexes, nothing is set.
+func SetMapValue(attrs pcommon.Map, keys []ottl.Key, val interface{}) {
+	if len(keys) == 0 || keys[0].String == nil {
+		return
+	}
+	value, ok := attrs.Get(*keys[0].String)
+	if !ok {
+		if len(keys) > 1 && keys[1].String == nil {
+			return
+		}
+		value = attrs.PutEmpty(*keys[0].String)
+	}
+	SetIndexableValue(value, keys[1:], val)
+}
+
+// SetIndexableValue sets val at the location found by following the keys from the given value,
+// following the same rules as SetMapValue. An empty value indexed by a string key is turned into a map.
+func SetIndexableValue(value pcommon.Value, keys []ottl.Key, val interface{}) {
+	for i, key := range keys {
+		if key.String == nil || value.Type() == pcommon.ValueTypeSlice {
+			next, ok := index(value, key)
+			if !ok {
+				return
+			}
+			value = next
+			continue
+		}
+
+		switch value.Type() {
+		case pcommon.ValueTypeEmpty:
+			value.SetEmptyMapVal()
+		case pcommon.ValueTypeMap:
+		default:
+			return
+		}
+		next, ok := value.MapVal().Get(*key.String)
+		if !ok {
+			if i+1 < len(keys) && keys[i+1].String == nil {
+				return
+			}
+			next = value.MapVal().PutEmpty(*key.String)
+		}
+		value = next
+	}
+
 	switch val.(type) {
+	case nil:
+		pcommon.NewValueEmpty().CopyTo(value)
 	case []string, []bool, []int64, []float64, [][]byte:
-		value = pcommon.NewValueSlice()
-	default:
-		value = pcommon.NewValueEmpty()
+		value.SetEmptySliceVal()
 	}
-
 	SetValue(value, val)
-	value.CopyTo(attrs.PutEmpty(mapKey))
+}
+
+func index(val pcommon.Value, key ottl.Key) (pcommon.Value, bool) {
+	switch {
+	case key.String != nil && val.Type() == pcommon.ValueTypeMap:
+		return val.MapVal().Get(*key.String)
+	case key.Int != nil && val.Type() == pcommon.ValueTypeSlice:
+		i := *key.Int
+		if i < 0 || i >= int64(val.SliceVal().Len()) {
+			return pcommon.Value{}, false
+		}
+		return val.SliceVal().At(int(i)), true
+	}
+	return pcommon.Value{}, false
 }
@@ -0,0 +1,180 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl/ottltest"
+)
+
+func strKey(s string) ottl.Key {
+	return ottl.Key{String: ottltest.Strp(s)}
+}
+
+func intKey(i int64) ottl.Key {
+	return ottl.Key{Int: ottltest.Intp(i)}
+}
+
+func createNestedMap() pcommon.Map {
+	m := pcommon.NewMap()
+	m.PutString("str", "val")
+	request := m.PutEmptyMap("http").PutEmptyMap("request")
+	headers := request.PutEmptySlice("headers")
+	headers.AppendEmpty().SetStringVal("accept")
+	headers.AppendEmpty().SetEmptyMapVal().PutString("name", "content-type")
+	return m
+}
+
+func TestGetMapValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []ottl.Key
+		expected interface{}
+	}{
+		{
+			name:     "single key",
+			keys:     []ottl.Key{strKey("str")},
+			expected: "val",
+		},
+		{
+			name:     "nested maps",
+			keys:     []ottl.Key{strKey("http"), strKey("request"), strKey("headers"), intKey(0)},
+			expected: "accept",
+		},
+		{
+			name:     "map inside slice",
+			keys:     []ottl.Key{strKey("http"), strKey("request"), strKey("headers"), intKey(1), strKey("name")},
+			expected: "content-type",
+		},
+		{
+			name:     "missing key",
+			keys:     []ottl.Key{strKey("http"), strKey("response")},
+			expected: nil,
+		},
+		{
+			name:     "index out of range",
+			keys:     []ottl.Key{strKey("http"), strKey("request"), strKey("headers"), intKey(2)},
+			expected: nil,
+		},
+		{
+			name:     "negative index",
+			keys:     []ottl.Key{strKey("http"), strKey("request"), strKey("headers"), intKey(-1)},
+			expected: nil,
+		},
+		{
+			name:     "index into map",
+			keys:     []ottl.Key{strKey("http"), intKey(0)},
+			expected: nil,
+		},
+		{
+			name:     "key into string",
+			keys:     []ottl.Key{strKey("str"), strKey("nested")},
+			expected: nil,
+		},
+		{
+			name:     "first key is an index",
+			keys:     []ottl.Key{intKey(0)},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetMapValue(createNestedMap(), tt.keys))
+		})
+	}
+}
+
+func TestSetMapValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []ottl.Key
+		val      interface{}
+		expected func(m pcommon.Map)
+	}{
+		{
+			name: "nested slice element",
+			keys: []ottl.Key{strKey("http"), strKey("request"), strKey("headers"), intKey(0)},
+			val:  "accept-encoding",
+			expected: func(m pcommon.Map) {
+				http, _ := m.Get("http")
+				request, _ := http.MapVal().Get("request")
+				headers, _ := request.MapVal().Get("headers")
+				headers.SliceVal().At(0).SetStringVal("accept-encoding")
+			},
+		},
+		{
+			name: "missing maps are created",
+			keys: []ottl.Key{strKey("http"), strKey("response"), strKey("status")},
+			val:  int64(200),
+			expected: func(m pcommon.Map) {
+				http, _ := m.Get("http")
+				http.MapVal().PutEmptyMap("response").PutInt("status", 200)
+			},
+		},
+		{
+			name: "string value is replaced by a slice",
+			keys: []ottl.Key{strKey("str")},
+			val:  []string{"a", "b"},
+			expected: func(m pcommon.Map) {
+				s := m.PutEmptySlice("str")
+				s.AppendEmpty().SetStringVal("a")
+				s.AppendEmpty().SetStringVal("b")
+			},
+		},
+		{
+			name: "nil empties the value",
+			keys: []ottl.Key{strKey("http"), strKey("request")},
+			val:  nil,
+			expected: func(m pcommon.Map) {
+				http, _ := m.Get("http")
+				http.MapVal().PutEmpty("request")
+			},
+		},
+		{
+			name:     "slices are not extended",
+			keys:     []ottl.Key{strKey("http"), strKey("request"), strKey("headers"), intKey(2)},
+			val:      "accept",
+			expected: func(m pcommon.Map) {},
+		},
+		{
+			name:     "missing slices are not created",
+			keys:     []ottl.Key{strKey("new"), intKey(0)},
+			val:      "accept",
+			expected: func(m pcommon.Map) {},
+		},
+		{
+			name:     "key into string",
+			keys:     []ottl.Key{strKey("str"), strKey("nested")},
+			val:      "val",
+			expected: func(m pcommon.Map) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := createNestedMap()
+			SetMapValue(m, tt.keys, tt.val)
+
+			expected := createNestedMap()
+			tt.expected(expected)
+
+			assert.Equal(t, expected.AsRaw(), m.AsRaw())
+		})
+	}
+}
@@ -28,11 +28,11 @@ func ResourcePathGetSetter(path []ottl.Field) (ottl.GetSetter, error) {
 	}
 	switch path[0].Name {
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessResourceAttributes(), nil
 		}
-		return accessResourceAttributesKey(mapKey), nil
+		return accessResourceAttributesKey(keys), nil
 	case "dropped_attributes_count":
 		return accessDroppedAttributesCount(), nil
 	}
@@ -66,13 +66,13 @@ func accessResourceAttributes() ottl.StandardGetSetter {
 	}
 }
 
-func accessResourceAttributesKey(mapKey *string) ottl.StandardGetSetter {
+func accessResourceAttributesKey(keys []ottl.Key) ottl.StandardGetSetter {
 	return ottl.StandardGetSetter{
 		Getter: func(ctx ottl.TransformContext) interface{} {
-			return GetMapValue(ctx.GetResource().Attributes(), *mapKey)
+			return GetMapValue(ctx.GetResource().Attributes(), keys)
 		},
 		Setter: func(ctx ottl.TransformContext, val interface{}) {
-			SetMapValue(ctx.GetResource().Attributes(), *mapKey, val)
+			SetMapValue(ctx.GetResource().Attributes(), keys, val)
 		},
 	}
 }
@@ -33,11 +33,11 @@ func ScopePathGetSetter(path []ottl.Field) (ottl.GetSetter, error) {
 	case "version":
 		return accessInstrumentationScopeVersion(), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessInstrumentationScopeAttributes(), nil
 		}
-		return accessInstrumentationScopeAttributesKey(mapKey), nil
+		return accessInstrumentationScopeAttributesKey(keys), nil
 	}
 
 	return nil, fmt.Errorf("invalid scope path expression %v", path)
@@ -69,13 +69,13 @@ func accessInstrumentationScopeAttributes() ottl.StandardGetSetter {
 	}
 }
 
-func accessInstrumentationScopeAttributesKey(mapKey *string) ottl.StandardGetSetter {
+func accessInstrumentationScopeAttributesKey(keys []ottl.Key) ottl.StandardGetSetter {
 	return ottl.StandardGetSetter{
 		Getter: func(ctx ottl.TransformContext) interface{} {
-			return GetMapValue(ctx.GetInstrumentationScope().Attributes(), *mapKey)
+			return GetMapValue(ctx.GetInstrumentationScope().Attributes(), keys)
 		},
 		Setter: func(ctx ottl.TransformContext, val interface{}) {
-			SetMapValue(ctx.GetInstrumentationScope().Attributes(), *mapKey, val)
+			SetMapValue(ctx.GetInstrumentationScope().Attributes(), keys, val)
 		},
 	}
 }
@@ -85,7 +85,11 @@ func SetValue(value pcommon.Value, val interface{}) {
 		for _, b := range v {
 			value.SliceVal().AppendEmpty().SetEmptyBytesVal().FromRaw(b)
 		}
-	default:
-		// TODO(anuraaga): Support set of map type.
+	case []any:
+		value.SetEmptySliceVal().FromRaw(v)
+	case pcommon.Map:
+		v.CopyTo(value.SetEmptyMapVal())
+	case pcommon.Slice:
+		v.CopyTo(value.SetEmptySliceVal())
 	}
 }
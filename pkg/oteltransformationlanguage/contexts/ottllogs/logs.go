@@ -113,13 +113,17 @@ func newPathGetSetter(path []ottl.Field) (ottl.GetSetter, error) {
 	case "severity_text":
 		return accessSeverityText(), nil
 	case "body":
-		return accessBody(), nil
+		keys := path[0].Keys
+		if len(keys) == 0 {
+			return accessBody(), nil
+		}
+		return accessBodyKey(keys), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(keys), nil
 	case "dropped_attributes_count":
 		return accessDroppedAttributesCount(), nil
 	case "flags":
@@ -206,6 +210,17 @@ func accessBody() ottl.StandardGetSetter {
 	}
 }
 
+func accessBodyKey(keys []ottl.Key) ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ottlcommon.GetIndexableValue(ctx.GetItem().(plog.LogRecord).Body(), keys)
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			ottlcommon.SetIndexableValue(ctx.GetItem().(plog.LogRecord).Body(), keys, val)
+		},
+	}
+}
+
 func accessAttributes() ottl.StandardGetSetter {
 	return ottl.StandardGetSetter{
 		Getter: func(ctx ottl.TransformContext) interface{} {
@@ -219,13 +234,13 @@ func accessAttributes() ottl.StandardGetSetter {
 	}
 }
 
-func accessAttributesKey(mapKey *string) ottl.StandardGetSetter {
+func accessAttributesKey(keys []ottl.Key) ottl.StandardGetSetter {
 	return ottl.StandardGetSetter{
 		Getter: func(ctx ottl.TransformContext) interface{} {
-			return ottlcommon.GetMapValue(ctx.GetItem().(plog.LogRecord).Attributes(), *mapKey)
+			return ottlcommon.GetMapValue(ctx.GetItem().(plog.LogRecord).Attributes(), keys)
 		},
 		Setter: func(ctx ottl.TransformContext, val interface{}) {
-			ottlcommon.SetMapValue(ctx.GetItem().(plog.LogRecord).Attributes(), *mapKey, val)
+			ottlcommon.SetMapValue(ctx.GetItem().(plog.LogRecord).Attributes(), keys, val)
 		},
 	}
 }
@@ -201,8 +201,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("str"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig:   "val",
@@ -215,8 +219,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig:   true,
@@ -229,8 +237,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("int"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig:   int64(10),
@@ -243,8 +255,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("double"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig:   float64(1.2),
@@ -257,8 +273,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -271,8 +291,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -288,8 +312,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -305,8 +333,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -322,8 +354,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -339,8 +375,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -378,6 +418,84 @@ func Test_newPathGetSetter(t *testing.T) {
 				pcommon.NewInstrumentationScope().CopyTo(il)
 			},
 		},
+		{
+			name: "attributes nested map",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("http"),
+						},
+						{
+							String: ottltest.Strp("request"),
+						},
+						{
+							String: ottltest.Strp("method"),
+						},
+					},
+				},
+			},
+			orig:   "GET",
+			newVal: "POST",
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				http, _ := log.Attributes().Get("http")
+				request, _ := http.MapVal().Get("request")
+				request.MapVal().PutString("method", "POST")
+			},
+		},
+		{
+			name: "attributes nested slice",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("http"),
+						},
+						{
+							String: ottltest.Strp("request"),
+						},
+						{
+							String: ottltest.Strp("headers"),
+						},
+						{
+							Int: ottltest.Intp(0),
+						},
+					},
+				},
+			},
+			orig:   "accept",
+			newVal: "content-type",
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				http, _ := log.Attributes().Get("http")
+				request, _ := http.MapVal().Get("request")
+				headers, _ := request.MapVal().Get("headers")
+				headers.SliceVal().At(0).SetStringVal("content-type")
+			},
+		},
+		{
+			name: "attributes slice index",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{
+							String: ottltest.Strp("arr_int"),
+						},
+						{
+							Int: ottltest.Intp(1),
+						},
+					},
+				},
+			},
+			orig:   int64(3),
+			newVal: int64(4),
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				arr, _ := log.Attributes().Get("arr_int")
+				arr.SliceVal().At(1).SetIntVal(4)
+			},
+		},
 		{
 			name: "resource",
 			path: []ottl.Field{
@@ -414,6 +532,59 @@ func Test_newPathGetSetter(t *testing.T) {
 	}
 }
 
+func Test_newPathGetSetter_BodyKeys(t *testing.T) {
+	log := plog.NewLogRecord()
+	body := log.Body().SetEmptyMapVal()
+	items := body.PutEmptySlice("items")
+	items.AppendEmpty().SetEmptyMapVal().PutString("name", "first")
+	items.AppendEmpty().SetEmptyMapVal().PutString("name", "second")
+	ctx := NewTransformContext(log, pcommon.NewInstrumentationScope(), pcommon.NewResource())
+
+	accessor, err := newPathGetSetter([]ottl.Field{
+		{
+			Name: "body",
+			Keys: []ottl.Key{
+				{
+					String: ottltest.Strp("items"),
+				},
+				{
+					Int: ottltest.Intp(1),
+				},
+				{
+					String: ottltest.Strp("name"),
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "second", accessor.Get(ctx))
+
+	accessor.Set(ctx, "last")
+	item, _ := items.At(1).MapVal().Get("name")
+	assert.Equal(t, "last", item.StringVal())
+
+	// an empty body is turned into a map when it's indexed by a string key
+	log.Body().SetStringVal("")
+	pcommon.NewValueEmpty().CopyTo(log.Body())
+	accessor, err = newPathGetSetter([]ottl.Field{
+		{
+			Name: "body",
+			Keys: []ottl.Key{
+				{
+					String: ottltest.Strp("parsed"),
+				},
+				{
+					String: ottltest.Strp("level"),
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, accessor.Get(ctx))
+	accessor.Set(ctx, "info")
+	assert.Equal(t, map[string]interface{}{"parsed": map[string]interface{}{"level": "info"}}, log.Body().MapVal().AsRaw())
+}
+
 func createTelemetry() (plog.LogRecord, pcommon.InstrumentationScope, pcommon.Resource) {
 	log := plog.NewLogRecord()
 	log.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
@@ -447,6 +618,10 @@ func createTelemetry() (plog.LogRecord, pcommon.InstrumentationScope, pcommon.Re
 	arrBytes.AppendEmpty().SetEmptyBytesVal().FromRaw([]byte{1, 2, 3})
 	arrBytes.AppendEmpty().SetEmptyBytesVal().FromRaw([]byte{2, 3, 4})
 
+	request := log.Attributes().PutEmptyMap("http").PutEmptyMap("request")
+	request.PutString("method", "GET")
+	request.PutEmptySlice("headers").AppendEmpty().SetStringVal("accept")
+
 	log.SetDroppedAttributesCount(10)
 
 	log.SetFlags(plog.LogRecordFlags(4))
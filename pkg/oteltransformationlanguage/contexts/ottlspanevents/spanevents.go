@@ -0,0 +1,299 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlspanevents // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottlspanevents"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/internal/ottlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
+)
+
+type TransformContext struct {
+	spanEvent            ptrace.SpanEvent
+	span                 ptrace.Span
+	instrumentationScope pcommon.InstrumentationScope
+	resource             pcommon.Resource
+}
+
+func NewTransformContext(spanEvent ptrace.SpanEvent, span ptrace.Span, instrumentationScope pcommon.InstrumentationScope, resource pcommon.Resource) TransformContext {
+	return TransformContext{
+		spanEvent:            spanEvent,
+		span:                 span,
+		instrumentationScope: instrumentationScope,
+		resource:             resource,
+	}
+}
+
+func (ctx TransformContext) GetItem() interface{} {
+	return ctx.spanEvent
+}
+
+func (ctx TransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.instrumentationScope
+}
+
+func (ctx TransformContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+func (ctx TransformContext) GetSpan() ptrace.Span {
+	return ctx.span
+}
+
+var symbolTable = map[ottl.EnumSymbol]ottl.Enum{
+	"SPAN_KIND_UNSPECIFIED": ottl.Enum(ptrace.SpanKindUnspecified),
+	"SPAN_KIND_INTERNAL":    ottl.Enum(ptrace.SpanKindInternal),
+	"SPAN_KIND_SERVER":      ottl.Enum(ptrace.SpanKindServer),
+	"SPAN_KIND_CLIENT":      ottl.Enum(ptrace.SpanKindClient),
+	"SPAN_KIND_PRODUCER":    ottl.Enum(ptrace.SpanKindProducer),
+	"SPAN_KIND_CONSUMER":    ottl.Enum(ptrace.SpanKindConsumer),
+	"STATUS_CODE_UNSET":     ottl.Enum(ptrace.StatusCodeUnset),
+	"STATUS_CODE_OK":        ottl.Enum(ptrace.StatusCodeOk),
+	"STATUS_CODE_ERROR":     ottl.Enum(ptrace.StatusCodeError),
+}
+
+func ParseEnum(val *ottl.EnumSymbol) (*ottl.Enum, error) {
+	if val != nil {
+		if enum, ok := symbolTable[*val]; ok {
+			return &enum, nil
+		}
+		return nil, fmt.Errorf("enum symbol, %s, not found", *val)
+	}
+	return nil, fmt.Errorf("enum symbol not provided")
+}
+
+func ParsePath(val *ottl.Path) (ottl.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newPathGetSetter(path []ottl.Field) (ottl.GetSetter, error) {
+	switch path[0].Name {
+	case "resource":
+		return ottlcommon.ResourcePathGetSetter(path[1:])
+	case "instrumentation_scope":
+		return ottlcommon.ScopePathGetSetter(path[1:])
+	case "span":
+		if len(path) == 1 {
+			return accessSpan(), nil
+		}
+		switch path[1].Name {
+		case "name":
+			return accessSpanName(), nil
+		case "kind":
+			return accessSpanKind(), nil
+		case "trace_id":
+			if len(path) == 3 && path[2].Name == "string" {
+				return accessSpanStringTraceID(), nil
+			}
+		case "span_id":
+			if len(path) == 3 && path[2].Name == "string" {
+				return accessSpanStringSpanID(), nil
+			}
+		case "attributes":
+			keys := path[1].Keys
+			if len(keys) == 0 {
+				return accessSpanAttributes(), nil
+			}
+			return accessSpanAttributesKey(keys), nil
+		case "status":
+			if len(path) == 3 && path[2].Name == "code" {
+				return accessSpanStatusCode(), nil
+			}
+		}
+	case "time_unix_nano":
+		return accessTimeUnixNano(), nil
+	case "name":
+		return accessName(), nil
+	case "attributes":
+		keys := path[0].Keys
+		if len(keys) == 0 {
+			return accessAttributes(), nil
+		}
+		return accessAttributesKey(keys), nil
+	case "dropped_attributes_count":
+		return accessDroppedAttributesCount(), nil
+	default:
+		return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+	}
+
+	return nil, fmt.Errorf("invalid path expression %v", path)
+}
+
+func accessSpan() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ctx.(TransformContext).GetSpan()
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			// the span holds the event being processed, so it can't be replaced from here
+		},
+	}
+}
+
+func accessSpanName() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ctx.(TransformContext).GetSpan().Name()
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.(TransformContext).GetSpan().SetName(str)
+			}
+		},
+	}
+}
+
+func accessSpanKind() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return int64(ctx.(TransformContext).GetSpan().Kind())
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.(TransformContext).GetSpan().SetKind(ptrace.SpanKind(i))
+			}
+		},
+	}
+}
+
+func accessSpanStringTraceID() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ctx.(TransformContext).GetSpan().TraceID().HexString()
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			// identifiers are read-only in this context
+		},
+	}
+}
+
+func accessSpanStringSpanID() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ctx.(TransformContext).GetSpan().SpanID().HexString()
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			// identifiers are read-only in this context
+		},
+	}
+}
+
+func accessSpanAttributes() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ctx.(TransformContext).GetSpan().Attributes()
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			if attrs, ok := val.(pcommon.Map); ok {
+				attrs.CopyTo(ctx.(TransformContext).GetSpan().Attributes())
+			}
+		},
+	}
+}
+
+func accessSpanAttributesKey(keys []ottl.Key) ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ottlcommon.GetMapValue(ctx.(TransformContext).GetSpan().Attributes(), keys)
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			ottlcommon.SetMapValue(ctx.(TransformContext).GetSpan().Attributes(), keys, val)
+		},
+	}
+}
+
+func accessSpanStatusCode() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return int64(ctx.(TransformContext).GetSpan().Status().Code())
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.(TransformContext).GetSpan().Status().SetCode(ptrace.StatusCode(i))
+			}
+		},
+	}
+}
+
+func accessTimeUnixNano() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanEvent).Timestamp().AsTime().UnixNano()
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
+			}
+		},
+	}
+}
+
+func accessName() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanEvent).Name()
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetName(str)
+			}
+		},
+	}
+}
+
+func accessAttributes() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanEvent).Attributes()
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			if attrs, ok := val.(pcommon.Map); ok {
+				attrs.CopyTo(ctx.GetItem().(ptrace.SpanEvent).Attributes())
+			}
+		},
+	}
+}
+
+func accessAttributesKey(keys []ottl.Key) ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return ottlcommon.GetMapValue(ctx.GetItem().(ptrace.SpanEvent).Attributes(), keys)
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			ottlcommon.SetMapValue(ctx.GetItem().(ptrace.SpanEvent).Attributes(), keys, val)
+		},
+	}
+}
+
+func accessDroppedAttributesCount() ottl.StandardGetSetter {
+	return ottl.StandardGetSetter{
+		Getter: func(ctx ottl.TransformContext) interface{} {
+			return int64(ctx.GetItem().(ptrace.SpanEvent).DroppedAttributesCount())
+		},
+		Setter: func(ctx ottl.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetDroppedAttributesCount(uint32(i))
+			}
+		},
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlspanevents
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl/ottltest"
+)
+
+var (
+	traceID = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanID  = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refEvent, _, _, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutString("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     []ottl.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "time_unix_nano",
+			path: []ottl.Field{
+				{
+					Name: "time_unix_nano",
+				},
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "name",
+			path: []ottl.Field{
+				{
+					Name: "name",
+				},
+			},
+			orig:   "exception",
+			newVal: "retry",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.SetName("retry")
+			},
+		},
+		{
+			name: "attributes",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refEvent.Attributes(),
+			newVal: newAttrs,
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.Attributes().Clear()
+				newAttrs.CopyTo(event.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("exception.type")}},
+				},
+			},
+			orig:   "java.lang.NullPointerException",
+			newVal: "java.io.IOException",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.Attributes().PutString("exception.type", "java.io.IOException")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []ottl.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "span name",
+			path: []ottl.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "GET /users",
+			newVal: "GET /users/{id}",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.SetName("GET /users/{id}")
+			},
+		},
+		{
+			name: "span kind",
+			path: []ottl.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "kind",
+				},
+			},
+			orig:   int64(ptrace.SpanKindServer),
+			newVal: int64(ptrace.SpanKindClient),
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.SetKind(ptrace.SpanKindClient)
+			},
+		},
+		{
+			name: "span attributes string",
+			path: []ottl.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("http.method")}},
+				},
+			},
+			orig:   "GET",
+			newVal: "POST",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.Attributes().PutString("http.method", "POST")
+			},
+		},
+		{
+			name: "span status code",
+			path: []ottl.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "status",
+				},
+				{
+					Name: "code",
+				},
+			},
+			orig:   int64(ptrace.StatusCodeError),
+			newVal: int64(ptrace.StatusCodeOk),
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.Status().SetCode(ptrace.StatusCodeOk)
+			},
+		},
+		{
+			name: "span trace_id string",
+			path: []ottl.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "trace_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   hex.EncodeToString(traceID[:]),
+			newVal: "ignored",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+			},
+		},
+		{
+			name: "span span_id string",
+			path: []ottl.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "span_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   hex.EncodeToString(spanID[:]),
+			newVal: "ignored",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+			},
+		},
+		{
+			name: "resource attributes",
+			path: []ottl.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("service.name")}},
+				},
+			},
+			orig:   "users",
+			newVal: "accounts",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().PutString("service.name", "accounts")
+			},
+		},
+		{
+			name: "instrumentation_scope name",
+			path: []ottl.Field{
+				{
+					Name: "instrumentation_scope",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "other",
+			modified: func(event ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("other")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			event, span, il, resource := createTelemetry()
+
+			got := accessor.Get(NewTransformContext(event, span, il, resource))
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(NewTransformContext(event, span, il, resource), tt.newVal)
+
+			exEvent, exSpan, exIl, exRes := createTelemetry()
+			tt.modified(exEvent, exSpan, exIl, exRes)
+
+			assert.Equal(t, exEvent, event)
+			assert.Equal(t, exSpan, span)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []ottl.Field
+	}{
+		{
+			name: "unknown field",
+			path: []ottl.Field{{Name: "links"}},
+		},
+		{
+			name: "unknown span field",
+			path: []ottl.Field{{Name: "span"}, {Name: "events"}},
+		},
+		{
+			name: "span trace_id without string",
+			path: []ottl.Field{{Name: "span"}, {Name: "trace_id"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func createTelemetry() (ptrace.SpanEvent, ptrace.Span, pcommon.InstrumentationScope, pcommon.Resource) {
+	span := ptrace.NewSpan()
+	span.SetName("GET /users")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID(traceID)
+	span.SetSpanID(spanID)
+	span.Attributes().PutString("http.method", "GET")
+	span.Status().SetCode(ptrace.StatusCodeError)
+
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	event.Attributes().PutString("exception.type", "java.lang.NullPointerException")
+	event.SetDroppedAttributesCount(10)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutString("service.name", "users")
+
+	return event, span, il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		want ottl.Enum
+	}{
+		{
+			name: "SPAN_KIND_SERVER",
+			want: ottl.Enum(ptrace.SpanKindServer),
+		},
+		{
+			name: "STATUS_CODE_ERROR",
+			want: ottl.Enum(ptrace.StatusCodeError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseEnum((*ottl.EnumSymbol)(ottltest.Strp(tt.name)))
+			assert.NoError(t, err)
+			assert.Equal(t, *actual, tt.want)
+		})
+	}
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	actual, err := ParseEnum((*ottl.EnumSymbol)(ottltest.Strp("not an enum")))
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+
+	actual, err = ParseEnum(nil)
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
@@ -104,11 +104,14 @@ func newPathGetSetter(path []ottl.Field) (ottl.GetSetter, error) {
 			return accessStringSpanID(), nil
 		}
 	case "trace_state":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessTraceState(), nil
 		}
-		return accessTraceStateKey(mapKey), nil
+		if len(keys) != 1 || keys[0].String == nil {
+			return nil, fmt.Errorf("invalid path expression, trace_state must be indexed by a single string key")
+		}
+		return accessTraceStateKey(keys[0].String), nil
 	case "parent_span_id":
 		return accessParentSpanID(), nil
 	case "name":
@@ -120,11 +123,11 @@ func newPathGetSetter(path []ottl.Field) (ottl.GetSetter, error) {
 	case "end_time_unix_nano":
 		return accessEndTimeUnixNano(), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(keys), nil
 	case "dropped_attributes_count":
 		return accessDroppedAttributesCount(), nil
 	case "events":
@@ -319,13 +322,13 @@ func accessAttributes() ottl.StandardGetSetter {
 	}
 }
 
-func accessAttributesKey(mapKey *string) ottl.StandardGetSetter {
+func accessAttributesKey(keys []ottl.Key) ottl.StandardGetSetter {
 	return ottl.StandardGetSetter{
 		Getter: func(ctx ottl.TransformContext) interface{} {
-			return ottlcommon.GetMapValue(ctx.GetItem().(ptrace.Span).Attributes(), *mapKey)
+			return ottlcommon.GetMapValue(ctx.GetItem().(ptrace.Span).Attributes(), keys)
 		},
 		Setter: func(ctx ottl.TransformContext, val interface{}) {
-			ottlcommon.SetMapValue(ctx.GetItem().(ptrace.Span).Attributes(), *mapKey, val)
+			ottlcommon.SetMapValue(ctx.GetItem().(ptrace.Span).Attributes(), keys, val)
 		},
 	}
 }
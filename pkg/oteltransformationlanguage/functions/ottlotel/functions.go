@@ -0,0 +1,32 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/functions/ottlotel"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/functions/ottlcommon"
+)
+
+// ReadOnlyFunctions returns the functions that don't modify the telemetry, for the components
+// evaluating OTTL conditions that must not have side effects. A new map is returned on each call,
+// so that callers may extend it without affecting each other.
+func ReadOnlyFunctions() map[string]interface{} {
+	return map[string]interface{}{
+		"TraceID": TraceID,
+		"SpanID":  SpanID,
+		"IsMatch": ottlcommon.IsMatch,
+		"Concat":  ottlcommon.Concat,
+		"Int":     ottlcommon.Int,
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/maps"
+)
+
+func Test_ReadOnlyFunctions(t *testing.T) {
+	functions := ReadOnlyFunctions()
+	assert.ElementsMatch(t, []string{"TraceID", "SpanID", "IsMatch", "Concat", "Int"}, maps.Keys(functions))
+
+	// callers own the returned map
+	delete(functions, "TraceID")
+	assert.Contains(t, ReadOnlyFunctions(), "TraceID")
+}
@@ -117,10 +117,28 @@ func (p *Parser) newBooleanTermEvaluator(term *Term) (boolExpressionEvaluator, e
 	return andFuncs(funcs), nil
 }
 
+// builds a function that returns the negated result of a boolExpressionEvaluator func
+func notFunc(f boolExpressionEvaluator) boolExpressionEvaluator {
+	return func(ctx TransformContext) bool {
+		return !f(ctx)
+	}
+}
+
 func (p *Parser) newBooleanValueEvaluator(value *BooleanValue) (boolExpressionEvaluator, error) {
 	if value == nil {
 		return alwaysTrue, nil
 	}
+	f, err := p.newNonNegatedBooleanValueEvaluator(value)
+	if err != nil {
+		return nil, err
+	}
+	if value.Negation != nil {
+		return notFunc(f), nil
+	}
+	return f, nil
+}
+
+func (p *Parser) newNonNegatedBooleanValueEvaluator(value *BooleanValue) (boolExpressionEvaluator, error) {
 	switch {
 	case value.Comparison != nil:
 		comparison, err := p.newComparisonEvaluator(value.Comparison)
@@ -133,6 +151,16 @@ func (p *Parser) newBooleanValueEvaluator(value *BooleanValue) (boolExpressionEv
 			return alwaysTrue, nil
 		}
 		return alwaysFalse, nil
+	case value.Invocation != nil:
+		call, err := p.newFunctionCall(*value.Invocation)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx TransformContext) bool {
+			// anything other than a boolean is considered false
+			result, ok := call(ctx).(bool)
+			return ok && result
+		}, nil
 	case value.SubExpr != nil:
 		return p.newBooleanExpressionEvaluator(value.SubExpr)
 	}
@@ -346,7 +346,137 @@ func Test_newBooleanExpressionEvaluator(t *testing.T) {
 				},
 			},
 		},
+		{"i", false,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation:  ottltest.Strp("not"),
+						ConstExpr: Booleanp(true),
+					},
+				},
+			},
+		},
+		{"j", true,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: ottltest.Strp("not"),
+						SubExpr: &BooleanExpression{
+							Left: &Term{
+								Left: &BooleanValue{
+									ConstExpr: Booleanp(true),
+								},
+								Right: []*OpAndBooleanValue{
+									{
+										Operator: "and",
+										Value: &BooleanValue{
+											ConstExpr: Booleanp(false),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{"k", true,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Invocation: &Invocation{
+							Function: "testing_bool_result",
+							Arguments: []Value{
+								{
+									Bool: Booleanp(true),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{"l", true,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: ottltest.Strp("not"),
+						Invocation: &Invocation{
+							Function: "testing_bool_result",
+							Arguments: []Value{
+								{
+									Bool: Booleanp(false),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{"m", false,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Invocation: &Invocation{
+							Function: "testing_string",
+							Arguments: []Value{
+								{
+									String: ottltest.Strp("not a boolean"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{"n", true,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Comparison: &Comparison{
+							Left: valueFor("b"),
+							Op:   IN,
+							Right: Value{
+								List: &List{
+									Values: []Value{valueFor("a"), valueFor("b")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{"o", true,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: ottltest.Strp("not"),
+						Comparison: &Comparison{
+							Left: valueFor(1),
+							Op:   IN,
+							Right: Value{
+								List: &List{
+									Values: []Value{valueFor(2.5), valueFor("1")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	functions := defaultFunctionsForTests()
+	functions["testing_bool_result"] = func(b bool) (ExprFunc, error) {
+		return func(TransformContext) interface{} {
+			return b
+		}, nil
 	}
+	p = NewParser(
+		functions,
+		testParsePath,
+		testParseEnum,
+		NoOpLogger{},
+	)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			evaluate, err := p.newBooleanExpressionEvaluator(tt.expr)
@@ -18,12 +18,14 @@ import (
 	"bytes"
 	"fmt"
 
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"golang.org/x/exp/constraints"
 )
 
 // The functions in this file implement a general-purpose comparison of two
 // values of type any, which for the purposes of OTTL mean values that are one of
-// int, float, string, bool, or pointers to those, or []byte, or nil.
+// int, float, string, bool, or pointers to those, or []byte, or nil, as well as lists
+// of those values for the IN operator.
 
 // invalidComparison returns false for everything except NE (where it returns true to indicate that the
 // objects were definitely not equivalent).
@@ -146,9 +148,49 @@ func compareFloat64(a float64, b any, op CompareOp) bool {
 	}
 }
 
+func compareSlices(a []any, b any, op CompareOp) bool {
+	v, ok := b.([]any)
+	if !ok {
+		return invalidComparison("list to non-list", op)
+	}
+	switch op {
+	case EQ, NE:
+		equal := len(a) == len(v)
+		for i := 0; equal && i < len(a); i++ {
+			equal = compare(a[i], v[i], EQ)
+		}
+		return equal == (op == EQ)
+	default:
+		return invalidComparison("unsupported inequality on lists", op)
+	}
+}
+
+// contains reports whether a is equal to any of the values in the list b.
+// pcommon.Slice values, such as slice attributes, are compared using their raw values.
+func contains(a any, b any) bool {
+	var list []any
+	switch v := b.(type) {
+	case []any:
+		list = v
+	case pcommon.Slice:
+		list = v.AsRaw()
+	default:
+		return invalidComparison("value in non-list", IN)
+	}
+	for _, item := range list {
+		if compare(a, item, EQ) {
+			return true
+		}
+	}
+	return false
+}
+
 // a and b are the return values from a Getter; we try to compare them
 // according to the given operator.
 func compare(a any, b any, op CompareOp) bool {
+	if op == IN {
+		return contains(a, b)
+	}
 	// nils are equal to each other and never equal to anything else,
 	// so if they're both nil, report equality.
 	if a == nil && b == nil {
@@ -173,6 +215,8 @@ func compare(a any, b any, op CompareOp) bool {
 			return compare(b, nil, op)
 		}
 		return compareByte(v, b, op)
+	case []any:
+		return compareSlices(v, b, op)
 	default:
 		// If we don't know what type it is, we can't do inequalities yet. So we can fall back to the old behavior where we just
 		// use Go's standard equality.
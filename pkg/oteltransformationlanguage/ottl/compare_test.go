@@ -17,6 +17,9 @@ package ottl
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Our types are bool, int, float, string, Bytes, nil, so we compare all types in both directions.
@@ -126,6 +129,45 @@ func Test_compare(t *testing.T) {
 // It's not attempting to be exhaustive, but again, it hits most of the major types and combinations.
 // The summary is that they're pretty fast; all the calls to compare are 12 ns/op or less on a 2019 intel
 // mac pro laptop, and none of them have any allocations.
+func Test_compare_lists(t *testing.T) {
+	tests := []struct {
+		name string
+		a    any
+		b    any
+		op   CompareOp
+		want bool
+	}{
+		{"string in list", sa, []any{sb, sa}, IN, true},
+		{"string not in list", sa, []any{sb, i64a}, IN, false},
+		{"int64 in list of floats", i64a, []any{f64b, f64a}, IN, true},
+		{"nil in list", nil, []any{sa, nil}, IN, true},
+		{"bytes in list", ba, []any{bb, ba}, IN, true},
+		{"in empty list", sa, []any{}, IN, false},
+		{"in non-list", sa, sa, IN, false},
+		{"in nil", sa, nil, IN, false},
+		{"in pcommon slice", sa, pcommonSlice(sb, sa), IN, true},
+		{"not in pcommon slice", sa, pcommonSlice(sb), IN, false},
+		{"equal lists", []any{sa, i64a}, []any{sa, f64a}, EQ, true},
+		{"different lists", []any{sa, i64a}, []any{sa, i64b}, EQ, false},
+		{"different lengths", []any{sa}, []any{sa, sa}, NE, true},
+		{"list and non-list", []any{sa}, sa, EQ, false},
+		{"list inequality", []any{sa}, []any{sb}, LT, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, compare(tt.a, tt.b, tt.op))
+		})
+	}
+}
+
+func pcommonSlice(values ...any) pcommon.Slice {
+	s := pcommon.NewSlice()
+	for _, v := range values {
+		s.AppendEmpty().SetStringVal(v.(string))
+	}
+	return s
+}
+
 func BenchmarkCompareEQInt64(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		compare(i64a, i64b, EQ)
@@ -70,6 +70,30 @@ func (g exprGetter) Get(ctx TransformContext) interface{} {
 	return g.expr(ctx)
 }
 
+type listGetter struct {
+	values []Getter
+}
+
+func (l *listGetter) Get(ctx TransformContext) interface{} {
+	evaluated := make([]any, len(l.values))
+	for i, v := range l.values {
+		evaluated[i] = v.Get(ctx)
+	}
+	return evaluated
+}
+
+func (p *Parser) newListGetter(list *List) (Getter, error) {
+	values := make([]Getter, 0, len(list.Values))
+	for _, v := range list.Values {
+		getter, err := p.newGetter(v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, getter)
+	}
+	return &listGetter{values: values}, nil
+}
+
 func (p *Parser) newGetter(val Value) (Getter, error) {
 	if val.IsNil != nil && *val.IsNil {
 		return &literal{value: nil}, nil
@@ -103,6 +127,14 @@ func (p *Parser) newGetter(val Value) (Getter, error) {
 		return p.pathParser(val.Path)
 	}
 
+	if val.List != nil {
+		return p.newListGetter(val.List)
+	}
+
+	if val.MathExpression != nil {
+		return p.evaluateMathExpression(val.MathExpression)
+	}
+
 	if val.Invocation == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the OpenTelemetry Transformation Language")
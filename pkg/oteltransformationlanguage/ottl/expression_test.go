@@ -98,6 +98,27 @@ func Test_newGetter(t *testing.T) {
 			},
 			want: "world",
 		},
+		{
+			name: "list",
+			val: Value{
+				List: &List{
+					Values: []Value{
+						{
+							String: ottltest.Strp("a"),
+						},
+						{
+							Int: ottltest.Intp(1),
+						},
+						{
+							Invocation: &Invocation{
+								Function: "hello",
+							},
+						},
+					},
+				},
+			},
+			want: []any{"a", int64(1), "world"},
+		},
 		{
 			name: "enum",
 			val: Value{
@@ -97,6 +97,47 @@ func Test_lexer(t *testing.T) {
 			{"Bytes", "0x0102030405060708"},
 			{"RParen", ")"},
 		}},
+		{"math operators", `(a + 1.5) * b - c / 2`, false, []result{
+			{"LParen", "("},
+			{"Lowercase", "a"},
+			{"OpAddSub", "+"},
+			{"Float", "1.5"},
+			{"RParen", ")"},
+			{"OpMultDiv", "*"},
+			{"Lowercase", "b"},
+			{"OpAddSub", "-"},
+			{"Lowercase", "c"},
+			{"OpMultDiv", "/"},
+			{"Int", "2"},
+		}},
+		{"subtraction without spaces", `a-1 10-2.5`, false, []result{
+			{"Lowercase", "a"},
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+			{"Int", "10"},
+			{"OpAddSub", "-"},
+			{"Float", "2.5"},
+		}},
+		{"negative number", `a - -1`, false, []result{
+			{"Lowercase", "a"},
+			{"OpAddSub", "-"},
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+		}},
+		{"not and in", `not name in ["a", 1]`, false, []result{
+			{"OpNot", "not"},
+			{"Lowercase", "name"},
+			{"Lowercase", "in"},
+			{"Punct", "["},
+			{"String", `"a"`},
+			{"Punct", ","},
+			{"Int", "1"},
+			{"Punct", "]"},
+		}},
+		{"names containing not and in", `nothing index`, false, []result{
+			{"Lowercase", "nothing"},
+			{"Lowercase", "index"},
+		}},
 		{"Mixing case", `aBCd`, false, []result{
 			{"Lowercase", "a"},
 			{"Uppercase", "BC"},
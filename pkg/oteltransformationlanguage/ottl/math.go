@@ -0,0 +1,140 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
+
+import (
+	"fmt"
+)
+
+func (p *Parser) evaluateMathExpression(expr *MathExpression) (Getter, error) {
+	mainGetter, err := p.evaluateAddSubTerm(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range expr.Right {
+		getter, err := p.evaluateAddSubTerm(rhs.Term)
+		if err != nil {
+			return nil, err
+		}
+		mainGetter, err = attemptMathOperation(mainGetter, rhs.Operator, getter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mainGetter, nil
+}
+
+func (p *Parser) evaluateAddSubTerm(term *AddSubTerm) (Getter, error) {
+	mainGetter, err := p.evaluateMathValue(term.Left)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range term.Right {
+		getter, err := p.evaluateMathValue(rhs.Value)
+		if err != nil {
+			return nil, err
+		}
+		mainGetter, err = attemptMathOperation(mainGetter, rhs.Operator, getter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mainGetter, nil
+}
+
+func (p *Parser) evaluateMathValue(val *MathValue) (Getter, error) {
+	switch {
+	case val.Literal != nil:
+		return p.newGetter(Value{
+			Invocation: val.Literal.Invocation,
+			String:     val.Literal.String,
+			Float:      val.Literal.Float,
+			Int:        val.Literal.Int,
+			Path:       val.Literal.Path,
+		})
+	case val.SubExpression != nil:
+		return p.evaluateMathExpression(val.SubExpression)
+	}
+
+	return nil, fmt.Errorf("unsupported math value %v", val)
+}
+
+// attemptMathOperation returns a Getter applying the operator to the values of both Getters.
+// When both sides are literals the operation is performed right away, so that invalid
+// operations are reported while parsing.
+func attemptMathOperation(lhs Getter, op MathOp, rhs Getter) (Getter, error) {
+	l, lok := lhs.(*literal)
+	r, rok := rhs.(*literal)
+	if lok && rok {
+		result, err := performMathOperation(l.value, op, r.value)
+		if err != nil {
+			return nil, err
+		}
+		return &literal{value: result}, nil
+	}
+
+	return &exprGetter{
+		expr: func(ctx TransformContext) interface{} {
+			// invalid operations at runtime result in nil, like accessing a missing value
+			result, _ := performMathOperation(lhs.Get(ctx), op, rhs.Get(ctx))
+			return result
+		},
+	}, nil
+}
+
+// performMathOperation applies the operator to two values. Ints and floats can be mixed, in which case
+// the result is a float. Strings can only be added to other strings, resulting in their concatenation.
+func performMathOperation(x interface{}, op MathOp, y interface{}) (interface{}, error) {
+	switch x := x.(type) {
+	case int64:
+		switch y := y.(type) {
+		case int64:
+			if op == DIV && y == 0 {
+				return nil, fmt.Errorf("attempted to divide by 0")
+			}
+			return performOp(x, op, y), nil
+		case float64:
+			return performOp(float64(x), op, y), nil
+		}
+	case float64:
+		switch y := y.(type) {
+		case int64:
+			return performOp(x, op, float64(y)), nil
+		case float64:
+			return performOp(x, op, y), nil
+		}
+	case string:
+		if y, ok := y.(string); ok && op == ADD {
+			return x + y, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported math operation: %T %v %T", x, op, y)
+}
+
+func performOp[N int64 | float64](x N, op MathOp, y N) N {
+	switch op {
+	case ADD:
+		return x + y
+	case SUB:
+		return x - y
+	case MULT:
+		return x * y
+	case DIV:
+		return x / y
+	}
+	return x
+}
@@ -0,0 +1,219 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl/ottltest"
+)
+
+func Test_evaluateMathExpression(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		item  interface{}
+		want  interface{}
+	}{
+		{
+			name:  "int addition",
+			input: "1 + 2",
+			want:  int64(3),
+		},
+		{
+			name:  "precedence",
+			input: "1 + 2 * 3 - 4 / 2",
+			want:  int64(5),
+		},
+		{
+			name:  "parentheses",
+			input: "(1 + 2) * (3 - 1)",
+			want:  int64(6),
+		},
+		{
+			name:  "left associativity",
+			input: "10 - 4 - 3",
+			want:  int64(3),
+		},
+		{
+			name:  "subtraction without spaces",
+			input: "10-1",
+			want:  int64(9),
+		},
+		{
+			name:  "negative operands",
+			input: "-2.5 * -2 - -1",
+			want:  float64(6),
+		},
+		{
+			name:  "path subtraction without spaces",
+			input: "name-1",
+			item:  int64(5),
+			want:  int64(4),
+		},
+		{
+			name:  "int division truncates",
+			input: "7 / 2",
+			want:  int64(3),
+		},
+		{
+			name:  "int and float are coerced to float",
+			input: "7 / 2.0",
+			want:  3.5,
+		},
+		{
+			name:  "float and int are coerced to float",
+			input: "1.5 * 2",
+			want:  float64(3),
+		},
+		{
+			name:  "path as operand",
+			input: "(name - 1000) / 1000",
+			item:  int64(5000),
+			want:  int64(4),
+		},
+		{
+			name:  "float path as operand",
+			input: "name * 2",
+			item:  1.25,
+			want:  2.5,
+		},
+		{
+			name:  "invocation as operand",
+			input: `"hello " + hello()`,
+			want:  "hello world",
+		},
+		{
+			name:  "string path concatenation",
+			input: `name + "-suffix"`,
+			item:  "prefix",
+			want:  "prefix-suffix",
+		},
+		{
+			name:  "path of unsupported type",
+			input: "name + 1",
+			item:  true,
+			want:  nil,
+		},
+		{
+			name:  "missing path",
+			input: "name + 1",
+			item:  nil,
+			want:  nil,
+		},
+		{
+			name:  "string minus path",
+			input: `"a" - name`,
+			item:  "b",
+			want:  nil,
+		},
+		{
+			name:  "int division by zero path",
+			input: "10 / name",
+			item:  int64(0),
+			want:  nil,
+		},
+	}
+
+	functions := map[string]interface{}{"hello": hello}
+
+	p := NewParser(
+		functions,
+		testParsePath,
+		testParseEnum,
+		NoOpLogger{},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery("set(name, " + tt.input + ")")
+			require.NoError(t, err)
+
+			getter, err := p.newGetter(parsed.Invocation.Arguments[1])
+			require.NoError(t, err)
+
+			result := getter.Get(ottltest.TestTransformContext{
+				Item: tt.item,
+			})
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_evaluateMathExpression_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "string and int",
+			input: `"a" + 1`,
+		},
+		{
+			name:  "strings subtraction",
+			input: `"a" - "b"`,
+		},
+		{
+			name:  "int division by zero",
+			input: "1 / 0",
+		},
+		{
+			name:  "unknown path",
+			input: "1 + unknown",
+		},
+		{
+			name:  "unknown function",
+			input: "1 + unknown()",
+		},
+	}
+
+	p := NewParser(
+		map[string]interface{}{},
+		testParsePath,
+		testParseEnum,
+		NoOpLogger{},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery("set(name, " + tt.input + ")")
+			require.NoError(t, err)
+
+			_, err = p.newGetter(parsed.Invocation.Arguments[1])
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_mathExpressionInComparison(t *testing.T) {
+	p := NewParser(
+		map[string]interface{}{"hello": hello},
+		testParsePath,
+		testParseEnum,
+		NoOpLogger{},
+	)
+
+	parsed, err := parseQuery(`set(name, 1) where (name - 500) / 1000 >= 1 and name * 2 == 3000`)
+	require.NoError(t, err)
+
+	evaluate, err := p.newBooleanExpressionEvaluator(parsed.WhereClause)
+	require.NoError(t, err)
+
+	assert.True(t, evaluate(ottltest.TestTransformContext{Item: int64(1500)}))
+	assert.False(t, evaluate(ottltest.TestTransformContext{Item: int64(1000)}))
+}
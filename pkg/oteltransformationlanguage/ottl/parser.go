@@ -29,12 +29,21 @@ type ParsedQuery struct {
 	WhereClause *BooleanExpression `parser:"( 'where' @@ )?"`
 }
 
+// ParsedCondition represents a parsed standalone condition, a boolean expression that isn't
+// attached to an invocation.
+type ParsedCondition struct {
+	Expression *BooleanExpression `parser:"@@"`
+}
+
 // BooleanValue represents something that evaluates to a boolean --
-// either an equality or inequality, explicit true or false, or
-// a parenthesized subexpression.
+// either an equality or inequality, explicit true or false, an invocation
+// returning a boolean, or a parenthesized subexpression. Any of them
+// can be negated with a leading `not`.
 type BooleanValue struct {
+	Negation   *string            `parser:"@OpNot?"`
 	Comparison *Comparison        `parser:"( @@"`
 	ConstExpr  *Boolean           `parser:"| @Boolean"`
+	Invocation *Invocation        `parser:"| @@"`
 	SubExpr    *BooleanExpression `parser:"| '(' @@ ')' )"`
 }
 
@@ -74,6 +83,7 @@ const (
 	LTE
 	GTE
 	GT
+	IN
 )
 
 // a fast way to get from a string to a compareOp
@@ -84,6 +94,7 @@ var compareOpTable = map[string]CompareOp{
 	"<=": LTE,
 	">":  GT,
 	">=": GTE,
+	"in": IN,
 }
 
 // Capture is how the parser converts an operator string to a CompareOp.
@@ -111,6 +122,8 @@ func (c CompareOp) String() string {
 		return "GTE"
 	case GT:
 		return "GT"
+	case IN:
+		return "IN"
 	default:
 		return "UNKNOWN OP!"
 	}
@@ -119,7 +132,7 @@ func (c CompareOp) String() string {
 // Comparison represents an optional boolean condition.
 type Comparison struct {
 	Left  Value     `parser:"@@"`
-	Op    CompareOp `parser:"@OpComparison"`
+	Op    CompareOp `parser:"@(OpComparison | 'in')"`
 	Right Value     `parser:"@@"`
 }
 
@@ -130,28 +143,127 @@ type Invocation struct {
 }
 
 // Value represents a part of a parsed query which is resolved to a value of some sort. This can be a telemetry path
-// expression, function call, or literal.
+// expression, function call, literal, or a math expression combining them. Values that can be part of a math expression
+// are only captured on their own if they aren't followed by a math operator.
 type Value struct {
+	Invocation     *Invocation     `parser:"( @@ (?! OpAddSub | OpMultDiv)"`
+	Bytes          *Bytes          `parser:"| @Bytes"`
+	String         *string         `parser:"| @String (?! OpAddSub | OpMultDiv)"`
+	Float          *float64        `parser:"| @(OpAddSub? Float) (?! OpAddSub | OpMultDiv)"`
+	Int            *int64          `parser:"| @(OpAddSub? Int) (?! OpAddSub | OpMultDiv)"`
+	Bool           *Boolean        `parser:"| @Boolean"`
+	IsNil          *IsNil          `parser:"| @'nil'"`
+	Enum           *EnumSymbol     `parser:"| @Uppercase"`
+	List           *List           `parser:"| @@"`
+	Path           *Path           `parser:"| @@ (?! OpAddSub | OpMultDiv)"`
+	MathExpression *MathExpression `parser:"| @@ )"`
+}
+
+// List represents a list of Values surrounded by square brackets.
+type List struct {
+	Values []Value `parser:"'[' ( @@ ( ',' @@ )* )? ']'"`
+}
+
+// MathExprLiteral represents a Value that can be an operand of a math expression.
+type MathExprLiteral struct {
 	Invocation *Invocation `parser:"( @@"`
-	Bytes      *Bytes      `parser:"| @Bytes"`
 	String     *string     `parser:"| @String"`
-	Float      *float64    `parser:"| @Float"`
-	Int        *int64      `parser:"| @Int"`
-	Bool       *Boolean    `parser:"| @Boolean"`
-	IsNil      *IsNil      `parser:"| @'nil'"`
-	Enum       *EnumSymbol `parser:"| @Uppercase"`
+	Float      *float64    `parser:"| @(OpAddSub? Float)"`
+	Int        *int64      `parser:"| @(OpAddSub? Int)"`
 	Path       *Path       `parser:"| @@ )"`
 }
 
+// MathValue represents an operand of a math expression, either a literal or a parenthesized subexpression.
+type MathValue struct {
+	Literal       *MathExprLiteral `parser:"( @@"`
+	SubExpression *MathExpression  `parser:"| '(' @@ ')' )"`
+}
+
+// OpMultDivValue represents the right side of a multiplication or division.
+type OpMultDivValue struct {
+	Operator MathOp     `parser:"@OpMultDiv"`
+	Value    *MathValue `parser:"@@"`
+}
+
+// AddSubTerm represents an arbitrary number of math values joined by multiplications or divisions.
+type AddSubTerm struct {
+	Left  *MathValue        `parser:"@@"`
+	Right []*OpMultDivValue `parser:"@@*"`
+}
+
+// OpAddSubTerm represents the right side of an addition or subtraction.
+type OpAddSubTerm struct {
+	Operator MathOp      `parser:"@OpAddSub"`
+	Term     *AddSubTerm `parser:"@@"`
+}
+
+// MathExpression represents an arbitrary number of terms joined by additions or subtractions.
+// Multiplications and divisions have higher precedence than additions and subtractions.
+type MathExpression struct {
+	Left  *AddSubTerm     `parser:"@@"`
+	Right []*OpAddSubTerm `parser:"@@*"`
+}
+
+// MathOp is the type of a math operator.
+type MathOp int
+
+// These are the allowed values of a MathOp
+const (
+	ADD MathOp = iota
+	SUB
+	MULT
+	DIV
+)
+
+// a fast way to get from a string to a MathOp
+var mathOpTable = map[string]MathOp{
+	"+": ADD,
+	"-": SUB,
+	"*": MULT,
+	"/": DIV,
+}
+
+// Capture is how the parser converts an operator string to a MathOp.
+func (m *MathOp) Capture(values []string) error {
+	op, ok := mathOpTable[values[0]]
+	if !ok {
+		return fmt.Errorf("'%s' is not a valid operator", values[0])
+	}
+	*m = op
+	return nil
+}
+
+// String() for MathOp gives us more legible test results and error messages.
+func (m MathOp) String() string {
+	switch m {
+	case ADD:
+		return "+"
+	case SUB:
+		return "-"
+	case MULT:
+		return "*"
+	case DIV:
+		return "/"
+	default:
+		return "UNKNOWN OP!"
+	}
+}
+
 // Path represents a telemetry path expression.
 type Path struct {
 	Fields []Field `parser:"@@ ( '.' @@ )*"`
 }
 
-// Field is an item within a Path.
+// Field is an item within a Path. It can be followed by any number of Keys.
 type Field struct {
-	Name   string  `parser:"@Lowercase"`
-	MapKey *string `parser:"( '[' @String ']' )?"`
+	Name string `parser:"@Lowercase"`
+	Keys []Key  `parser:"( '[' @@ ']' )*"`
+}
+
+// Key represents an index into a Field, either a string key for maps or an int index for slices.
+type Key struct {
+	String *string `parser:"( @String"`
+	Int    *int64  `parser:"| @(OpAddSub? Int) )"`
 }
 
 // Query holds a top level Query for processing telemetry data. A Query is a combination of a function
@@ -161,6 +273,9 @@ type Query struct {
 	Condition boolExpressionEvaluator
 }
 
+// Condition evaluates a standalone boolean expression against a TransformContext.
+type Condition = boolExpressionEvaluator
+
 // Bytes type for capturing byte arrays
 type Bytes []byte
 
@@ -240,7 +355,35 @@ func (p *Parser) ParseQueries(statements []string) ([]Query, error) {
 	return queries, nil
 }
 
-var parser = newParser()
+// ParseConditions parses each of the given statements as a standalone boolean expression, for components
+// that only need to decide whether a piece of telemetry matches, without invoking a function on it.
+func (p *Parser) ParseConditions(statements []string) ([]Condition, error) {
+	var conditions []Condition
+	var errors error
+
+	for _, statement := range statements {
+		parsed, err := parseCondition(statement)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		expression, err := p.newBooleanExpressionEvaluator(parsed.Expression)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		conditions = append(conditions, expression)
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+	return conditions, nil
+}
+
+var parser = newParser[ParsedQuery]()
+
+var conditionParser = newParser[ParsedCondition]()
 
 func parseQuery(raw string) (*ParsedQuery, error) {
 	parsed, err := parser.ParseString("", raw)
@@ -250,18 +393,29 @@ func parseQuery(raw string) (*ParsedQuery, error) {
 	return parsed, nil
 }
 
+func parseCondition(raw string) (*ParsedCondition, error) {
+	parsed, err := conditionParser.ParseString("", raw)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
 // buildLexer constructs a SimpleLexer definition.
 // Note that the ordering of these rules matters.
 // It's in a separate function so it can be easily tested alone (see lexer_test.go).
 func buildLexer() *lexer.StatefulDefinition {
 	return lexer.MustSimple([]lexer.SimpleRule{
 		{Name: `Bytes`, Pattern: `0x[a-fA-F0-9]+`},
-		{Name: `Float`, Pattern: `[-+]?\d*\.\d+([eE][-+]?\d+)?`},
-		{Name: `Int`, Pattern: `[-+]?\d+`},
+		{Name: `Float`, Pattern: `\d*\.\d+([eE][-+]?\d+)?`},
+		{Name: `Int`, Pattern: `\d+`},
 		{Name: `String`, Pattern: `"(\\"|[^"])*"`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
+		{Name: `OpNot`, Pattern: `\b(not)\b`},
 		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
+		{Name: `OpAddSub`, Pattern: `\+|\-`},
+		{Name: `OpMultDiv`, Pattern: `\/|\*`},
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
@@ -272,14 +426,17 @@ func buildLexer() *lexer.StatefulDefinition {
 	})
 }
 
-// newParser returns a parser that can be used to read a string into a ParsedQuery. An error will be returned if the string
-// is not formatted for the DSL.
-func newParser() *participle.Parser[ParsedQuery] {
+// newParser returns a parser that can be used to read a string into a ParsedQuery or a ParsedCondition. An error will be
+// returned if the string is not formatted for the DSL.
+func newParser[G any]() *participle.Parser[G] {
 	lex := buildLexer()
-	parser, err := participle.Build[ParsedQuery](
+	parser, err := participle.Build[G](
 		participle.Lexer(lex),
 		participle.Unquote("String"),
 		participle.Elide("whitespace"),
+		// math expressions and comparisons can both start with a parenthesis, so the parser
+		// needs to be able to backtrack when a branch doesn't match
+		participle.UseLookahead(participle.MaxLookahead),
 	)
 	if err != nil {
 		panic("Unable to initialize parser; this is a programming error in the transformprocessor:" + err.Error())
@@ -128,8 +128,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: ottltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: ottltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -145,6 +149,55 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:  "complex path with nested keys and indexes",
+			query: `set(attributes["http"]["headers"][0], body["items"][-1])`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: ottltest.Strp("http"),
+											},
+											{
+												String: ottltest.Strp("headers"),
+											},
+											{
+												Int: ottltest.Intp(0),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "body",
+										Keys: []Key{
+											{
+												String: ottltest.Strp("items"),
+											},
+											{
+												Int: ottltest.Intp(-1),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 		{
 			name:  "where == clause",
 			query: `set(foo.attributes["bar"].cat, "dog") where name == "fido"`,
@@ -159,8 +212,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: ottltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: ottltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -210,8 +267,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: ottltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: ottltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -261,8 +322,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: ottltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: ottltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -360,8 +425,12 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: ottltest.Strp("bytes"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: ottltest.Strp("bytes"),
+											},
+										},
 									},
 								},
 							},
@@ -385,8 +454,12 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: ottltest.Strp("test"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: ottltest.Strp("test"),
+											},
+										},
 									},
 								},
 							},
@@ -410,8 +483,12 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: ottltest.Strp("test"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: ottltest.Strp("test"),
+											},
+										},
 									},
 								},
 							},
@@ -435,6 +512,201 @@ func Test_parse(t *testing.T) {
 	}
 }
 
+func Test_parse_math(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected *MathExpression
+	}{
+		{
+			name:  "multiplication has precedence",
+			query: `set(name, 1 + 2 * 3.5)`,
+			expected: &MathExpression{
+				Left: &AddSubTerm{
+					Left: &MathValue{
+						Literal: &MathExprLiteral{
+							Int: ottltest.Intp(1),
+						},
+					},
+				},
+				Right: []*OpAddSubTerm{
+					{
+						Operator: ADD,
+						Term: &AddSubTerm{
+							Left: &MathValue{
+								Literal: &MathExprLiteral{
+									Int: ottltest.Intp(2),
+								},
+							},
+							Right: []*OpMultDivValue{
+								{
+									Operator: MULT,
+									Value: &MathValue{
+										Literal: &MathExprLiteral{
+											Float: ottltest.Floatp(3.5),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "parentheses and paths",
+			query: `set(name, (end - start) / 1000)`,
+			expected: &MathExpression{
+				Left: &AddSubTerm{
+					Left: &MathValue{
+						SubExpression: &MathExpression{
+							Left: &AddSubTerm{
+								Left: &MathValue{
+									Literal: &MathExprLiteral{
+										Path: &Path{
+											Fields: []Field{
+												{
+													Name: "end",
+												},
+											},
+										},
+									},
+								},
+							},
+							Right: []*OpAddSubTerm{
+								{
+									Operator: SUB,
+									Term: &AddSubTerm{
+										Left: &MathValue{
+											Literal: &MathExprLiteral{
+												Path: &Path{
+													Fields: []Field{
+														{
+															Name: "start",
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Right: []*OpMultDivValue{
+						{
+							Operator: DIV,
+							Value: &MathValue{
+								Literal: &MathExprLiteral{
+									Int: ottltest.Intp(1000),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "subtraction without spaces",
+			query: `set(name, a-1)`,
+			expected: &MathExpression{
+				Left: &AddSubTerm{
+					Left: &MathValue{
+						Literal: &MathExprLiteral{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "a",
+									},
+								},
+							},
+						},
+					},
+				},
+				Right: []*OpAddSubTerm{
+					{
+						Operator: SUB,
+						Term: &AddSubTerm{
+							Left: &MathValue{
+								Literal: &MathExprLiteral{
+									Int: ottltest.Intp(1),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "subtraction of a negative number",
+			query: `set(name, a - -1)`,
+			expected: &MathExpression{
+				Left: &AddSubTerm{
+					Left: &MathValue{
+						Literal: &MathExprLiteral{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "a",
+									},
+								},
+							},
+						},
+					},
+				},
+				Right: []*OpAddSubTerm{
+					{
+						Operator: SUB,
+						Term: &AddSubTerm{
+							Left: &MathValue{
+								Literal: &MathExprLiteral{
+									Int: ottltest.Intp(-1),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "string concatenation with invocation",
+			query: `set(name, "prefix-" + hello())`,
+			expected: &MathExpression{
+				Left: &AddSubTerm{
+					Left: &MathValue{
+						Literal: &MathExprLiteral{
+							String: ottltest.Strp("prefix-"),
+						},
+					},
+				},
+				Right: []*OpAddSubTerm{
+					{
+						Operator: ADD,
+						Term: &AddSubTerm{
+							Left: &MathValue{
+								Literal: &MathExprLiteral{
+									Invocation: &Invocation{
+										Function: "hello",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery(tt.query)
+			assert.NoError(t, err)
+			assert.Len(t, parsed.Invocation.Arguments, 2)
+			assert.EqualValues(t, tt.expected, parsed.Invocation.Arguments[1].MathExpression)
+		})
+	}
+}
+
 func Test_parse_failure(t *testing.T) {
 	tests := []string{
 		`set(`,
@@ -457,6 +729,8 @@ func Test_parse_failure(t *testing.T) {
 		`set("foo") where )`,
 		`set("foo") where (name == "fido"))`,
 		`set("foo") where ((name == "fido")`,
+		`set(name, 1 + )`,
+		`set(name, 1 ** 2)`,
 	}
 	for _, tt := range tests {
 		t.Run(tt, func(t *testing.T) {
@@ -772,6 +1046,116 @@ func Test_parseWhere(t *testing.T) {
 				},
 			}),
 		},
+		{
+			query: `not IsMatch(name, "foo")`,
+			expected: setNameTest(&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: ottltest.Strp("not"),
+						Invocation: &Invocation{
+							Function: "IsMatch",
+							Arguments: []Value{
+								{
+									Path: &Path{
+										Fields: []Field{
+											{
+												Name: "name",
+											},
+										},
+									},
+								},
+								{
+									String: ottltest.Strp("foo"),
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
+		{
+			query: `name in ["foo", 1]`,
+			expected: setNameTest(&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Comparison: &Comparison{
+							Left: Value{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
+									},
+								},
+							},
+							Op: IN,
+							Right: Value{
+								List: &List{
+									Values: []Value{
+										{
+											String: ottltest.Strp("foo"),
+										},
+										{
+											Int: ottltest.Intp(1),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
+		{
+			query: `in.name in ["foo"]`,
+			expected: setNameTest(&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Comparison: &Comparison{
+							Left: Value{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "in",
+										},
+										{
+											Name: "name",
+										},
+									},
+								},
+							},
+							Op: IN,
+							Right: Value{
+								List: &List{
+									Values: []Value{
+										{
+											String: ottltest.Strp("foo"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
+		{
+			query: `not (true)`,
+			expected: setNameTest(&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: ottltest.Strp("not"),
+						SubExpr: &BooleanExpression{
+							Left: &Term{
+								Left: &BooleanValue{
+									ConstExpr: Booleanp(true),
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
 	}
 
 	// create a test name that doesn't confuse vscode so we can rerun tests with one click
@@ -827,6 +1211,25 @@ func Test_parseQuery(t *testing.T) {
 		{`drop() where ==`, true},
 		{`drop() where == animal`, true},
 		{`drop() where attributes["path"] == "/healthcheck"`, false},
+		{`set(attributes["duration_ms"], (end_time_unix_nano - start_time_unix_nano) / 1000000)`, false},
+		{`set(attributes["total"], 1 + 2 * 3 - size(name) / 4.5)`, false},
+		{`set(attributes["greeting"], "hello " + name)`, false},
+		{`drop() where (end_time_unix_nano - start_time_unix_nano) / 1000000 > 500`, false},
+		{`drop() where (name == "fido") and (count + 1 == 2)`, false},
+		{`drop() where not IsMatch(name, "pinger")`, false},
+		{`drop() where attributes["env"] in ["prod", "staging"]`, false},
+		{`drop() where not (attributes["env"] in ["prod", "staging"] or name == "x") and not false`, false},
+		{`set(attributes["list"], ["a", 1, 2.5, nil, ["nested"], []])`, false},
+		{`set(in, attributes["in"]) where in.in in [in]`, false},
+		{`drop() where name in in`, false},
+		{`drop() where not`, true},
+		{`drop() where name in`, true},
+		{`drop() where name in ["a",]`, true},
+		{`drop() where not not true`, true},
+		{`set(attributes["total"], 1 +)`, true},
+		{`set(attributes["total"], * 2)`, true},
+		{`set(attributes["total"], (1 + 2)`, true},
+		{`set(attributes["total"], 1 + ())`, true},
 	}
 	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
 	for _, tt := range tests {
@@ -840,3 +1243,81 @@ func Test_parseQuery(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		wantErr   bool
+	}{
+		{`name == "pinger"`, false},
+		{`attributes["path"] == "/healthcheck" and resource.attributes["service.name"] == "api"`, false},
+		{`IsMatch(name, "ping.*") or not (attributes["env"] in ["prod", "staging"])`, false},
+		{`(end_time_unix_nano - start_time_unix_nano) / 1000000 > 500`, false},
+		{`true`, false},
+		{`drop() where name == "pinger"`, true},
+		{`name ==`, true},
+		{`where name == "pinger"`, true},
+		{``, true},
+	}
+	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
+	for _, tt := range tests {
+		name := pat.ReplaceAllString(tt.condition, "_")
+		t.Run(name, func(t *testing.T) {
+			_, err := parseCondition(tt.condition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCondition(%s) error = %v, wantErr %v", tt.condition, err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func Test_ParseConditions(t *testing.T) {
+	p := NewParser(
+		defaultFunctionsForTests(),
+		testParsePath,
+		testParseEnum,
+		NoOpLogger{},
+	)
+
+	conditions, err := p.ParseConditions([]string{
+		`name == "bear"`,
+		`name != "bear" and name != "cat"`,
+		`name in ["fish", "bird"] or TEST_ENUM_ONE == 1`,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, conditions, 3)
+
+	tests := []struct {
+		item interface{}
+		want []bool
+	}{
+		{"bear", []bool{true, false, true}},
+		{"cat", []bool{false, false, true}},
+		{"dog", []bool{false, true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.item.(string), func(t *testing.T) {
+			ctx := ottltest.TestTransformContext{Item: tt.item}
+			for i, condition := range conditions {
+				assert.Equal(t, tt.want[i], condition(ctx))
+			}
+		})
+	}
+}
+
+func Test_ParseConditions_Error(t *testing.T) {
+	p := NewParser(
+		defaultFunctionsForTests(),
+		testParsePath,
+		testParseEnum,
+		NoOpLogger{},
+	)
+
+	_, err := p.ParseConditions([]string{
+		`name == "bear"`,
+		`unknown == "bear"`,
+		`name ==`,
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionAuto = "auto"
+	compressionNone = "none"
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
+// decompressor returns a reader of the decompressed content of a compressed stream.
+type decompressor func(io.Reader) (io.ReadCloser, error)
+
+type compressionFormat struct {
+	magic        []byte
+	extensions   []string
+	decompressor decompressor
+}
+
+var compressionFormats = map[string]compressionFormat{
+	compressionGzip: {
+		magic:        []byte{0x1f, 0x8b},
+		extensions:   []string{".gz", ".gzip"},
+		decompressor: gunzip,
+	},
+	compressionZstd: {
+		magic:        []byte{0x28, 0xb5, 0x2f, 0xfd},
+		extensions:   []string{".zst", ".zstd"},
+		decompressor: unzstd,
+	},
+}
+
+func gunzip(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+func unzstd(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
+
+func validateCompression(compression string) error {
+	switch compression {
+	case compressionAuto, compressionNone, compressionGzip, compressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression '%s'", compression)
+	}
+}
+
+// detectDecompressor returns the decompressor of the file, or nil if the file isn't compressed.
+// In auto mode, a file is compressed if it starts with the magic bytes of a compression format,
+// or if it is too short to tell and its extension is the one of a compression format.
+func detectDecompressor(file *os.File, compression string) (decompressor, error) {
+	switch compression {
+	case compressionNone:
+		return nil, nil
+	case compressionGzip, compressionZstd:
+		return compressionFormats[compression].decompressor, nil
+	}
+
+	head := make([]byte, 4)
+	n, err := file.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("reading magic bytes: %w", err)
+	}
+	head = head[:n]
+
+	ext := strings.ToLower(filepath.Ext(file.Name()))
+	for _, format := range compressionFormats {
+		if bytes.HasPrefix(head, format.magic) {
+			return format.decompressor, nil
+		}
+		if len(head) < len(format.magic) && bytes.HasPrefix(format.magic, head) {
+			for _, formatExt := range format.extensions {
+				if ext == formatExt {
+					return format.decompressor, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// newDecompressedFingerprint creates a fingerprint from the first bytes of the decompressed content of the file.
+// A compressed file which is still being written has a shorter fingerprint, as for uncompressed files.
+func newDecompressedFingerprint(file *os.File, decompress decompressor, size int) (*Fingerprint, error) {
+	buf := make([]byte, size)
+
+	n, err := readDecompressed(file, decompress, buf)
+	if err != nil {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+
+	return &Fingerprint{FirstBytes: buf[:n]}, nil
+}
+
+// readDecompressed reads the first decompressed bytes of the file into buf, without moving the file offset.
+func readDecompressed(file *os.File, decompress decompressor, buf []byte) (int, error) {
+	dr, err := decompress(io.NewSectionReader(file, 0, math.MaxInt64))
+	if err != nil {
+		if isIncomplete(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer dr.Close()
+
+	n, err := io.ReadFull(dr, buf)
+	if err != nil && !isIncomplete(err) {
+		return n, err
+	}
+	return n, nil
+}
+
+// isIncomplete tells whether the error is caused by the end of a compressed stream which is still being written.
+func isIncomplete(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// countingReader counts the bytes read from a decompressed stream, and whether its end was reached.
+type countingReader struct {
+	io.Reader
+	count int64
+	eof   bool
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.count += int64(n)
+	if errors.Is(err, io.EOF) {
+		c.eof = true
+	}
+	return n, err
+}
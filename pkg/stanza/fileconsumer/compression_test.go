@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func gzipString(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdString(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func writeFile(t *testing.T, path string, content []byte) {
+	require.NoError(t, os.WriteFile(path, content, 0600))
+}
+
+func TestDetectDecompressor(t *testing.T) {
+	t.Parallel()
+
+	gz := gzipString(t, "testlog\n")
+	zst := zstdString(t, "testlog\n")
+
+	cases := []struct {
+		name        string
+		fileName    string
+		content     []byte
+		compression string
+		expected    string
+	}{
+		{"plain", "test.log", []byte("testlog\n"), compressionAuto, ""},
+		{"gzip_magic", "test.log", gz, compressionAuto, compressionGzip},
+		{"gzip_extension", "test.log.gz", gz, compressionAuto, compressionGzip},
+		{"zstd_magic", "test.log.1", zst, compressionAuto, compressionZstd},
+		{"zstd_extension", "test.log.zst", zst, compressionAuto, compressionZstd},
+		{"plain_gzip_extension", "test.log.gz", []byte("testlog\n"), compressionAuto, ""},
+		{"partial_header_gzip_extension", "test.log.gz", gz[:1], compressionAuto, compressionGzip},
+		{"empty_zstd_extension", "test.log.zst", nil, compressionAuto, compressionZstd},
+		{"empty_no_extension", "test.log", nil, compressionAuto, ""},
+		{"none", "test.log.gz", gz, compressionNone, ""},
+		{"forced_gzip", "test.log", gz, compressionGzip, compressionGzip},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), tc.fileName)
+			writeFile(t, path, tc.content)
+			file := openFile(t, path)
+
+			decompress, err := detectDecompressor(file, tc.compression)
+			require.NoError(t, err)
+			if tc.expected == "" {
+				require.Nil(t, decompress)
+				return
+			}
+			require.NotNil(t, decompress)
+
+			// The decompressor is the one of the expected format if it can read its content
+			fp, err := newDecompressedFingerprint(file, compressionFormats[tc.expected].decompressor, DefaultFingerprintSize)
+			require.NoError(t, err)
+			actual, err := newDecompressedFingerprint(file, decompress, DefaultFingerprintSize)
+			require.NoError(t, err)
+			require.Equal(t, fp, actual)
+		})
+	}
+}
+
+func TestDecompressedFingerprint(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	plain := filepath.Join(tempDir, "test.log")
+	compressed := filepath.Join(tempDir, "test.log.1.gz")
+	writeFile(t, plain, []byte("testlog1\ntestlog2\n"))
+	writeFile(t, compressed, gzipString(t, "testlog1\ntestlog2\n"))
+
+	f := readerFactory{readerConfig: &readerConfig{fingerprintSize: DefaultFingerprintSize}, compression: compressionAuto}
+
+	plainFp, err := f.newFingerprint(openFile(t, plain))
+	require.NoError(t, err)
+	compressedFp, err := f.newFingerprint(openFile(t, compressed))
+	require.NoError(t, err)
+	require.Equal(t, plainFp, compressedFp)
+
+	// A compressed file which is still being written has a partial fingerprint
+	partial := filepath.Join(tempDir, "test.log.2.gz")
+	writeFile(t, partial, gzipString(t, "testlog1\ntestlog2\n")[:20])
+	partialFp, err := f.newFingerprint(openFile(t, partial))
+	require.NoError(t, err)
+	require.True(t, plainFp.StartsWith(partialFp) || len(partialFp.FirstBytes) == 0)
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	writeFile(t, filepath.Join(tempDir, "test.log.1.gz"), gzipString(t, "testlog1\ntestlog2\n"))
+	writeFile(t, filepath.Join(tempDir, "test.log.2.zst"), zstdString(t, "testlog3\ntestlog4\n"))
+
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForTokens(t, emitCalls, [][]byte{
+		[]byte("testlog1"),
+		[]byte("testlog2"),
+		[]byte("testlog3"),
+		[]byte("testlog4"),
+	})
+}
+
+func TestCompressedFileReadOnce(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	writeFile(t, filepath.Join(tempDir, "test.log.1.gz"), gzipString(t, "testlog1\ntestlog2\n"))
+
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	require.Len(t, operator.knownFiles, 1)
+	require.NotZero(t, operator.knownFiles[0].CompressedSize)
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+func TestRotatedToCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "test.log")
+	writeFile(t, path, []byte("testlog1\n"))
+
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	// The file is written to, then rotated and compressed before the next poll
+	require.NoError(t, os.Remove(path))
+	writeFile(t, filepath.Join(tempDir, "test.log.1.gz"), gzipString(t, "testlog1\ntestlog2\n"))
+
+	// Only the lines following the previous offset are read from the archive
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestCompressedFileRestartOffsets(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "test.log.gz")
+	persister := testutil.NewMockPersister("test")
+
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+
+	writeFile(t, path, gzipString(t, "testlog1\ntestlog2\n"))
+
+	operatorOne, emitCallsOne := buildTestManager(t, cfg)
+	require.NoError(t, operatorOne.Start(persister))
+	waitForToken(t, emitCallsOne, []byte("testlog1"))
+	waitForToken(t, emitCallsOne, []byte("testlog2"))
+	require.NoError(t, operatorOne.Stop())
+
+	// A gzip member is appended to the archive while stopped, only its content is read after the restart
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = file.Write(gzipString(t, "testlog3\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+	require.NoError(t, operatorTwo.Start(persister))
+	defer func() {
+		require.NoError(t, operatorTwo.Stop())
+	}()
+	waitForToken(t, emitCallsTwo, []byte("testlog3"))
+	expectNoTokens(t, emitCallsTwo)
+}
+
+func TestCompressedFileStartAtEnd(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	writeFile(t, filepath.Join(tempDir, "test.log.1.gz"), gzipString(t, "testlog1\ntestlog2\n"))
+
+	cfg := NewConfig().includeDir(tempDir)
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	require.Len(t, operator.knownFiles, 1)
+	require.Equal(t, int64(len("testlog1\ntestlog2\n")), operator.knownFiles[0].Offset)
+}
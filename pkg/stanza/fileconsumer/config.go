@@ -27,6 +27,7 @@ import (
 const (
 	defaultMaxLogSize         = 1024 * 1024
 	defaultMaxConcurrentFiles = 1024
+	defaultQuietPeriod        = time.Minute
 )
 
 // NewConfig creates a new input config with default values
@@ -42,6 +43,8 @@ func NewConfig() *Config {
 		FingerprintSize:         DefaultFingerprintSize,
 		MaxLogSize:              defaultMaxLogSize,
 		MaxConcurrentFiles:      defaultMaxConcurrentFiles,
+		Compression:             compressionAuto,
+		QuietPeriod:             defaultQuietPeriod,
 	}
 }
 
@@ -57,6 +60,10 @@ type Config struct {
 	FingerprintSize         helper.ByteSize       `mapstructure:"fingerprint_size,omitempty"               json:"fingerprint_size,omitempty"              yaml:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"                   json:"max_log_size,omitempty"                  yaml:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"           json:"max_concurrent_files,omitempty"          yaml:"max_concurrent_files,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"                    json:"compression,omitempty"                   yaml:"compression,omitempty"`
+	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"              json:"delete_after_read,omitempty"             yaml:"delete_after_read,omitempty"`
+	MoveTo                  string                `mapstructure:"move_to,omitempty"                        json:"move_to,omitempty"                       yaml:"move_to,omitempty"`
+	QuietPeriod             time.Duration         `mapstructure:"quiet_period,omitempty"                   json:"quiet_period,omitempty"                  yaml:"quiet_period,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"                        json:",inline,omitempty"                       yaml:",inline,omitempty"`
 }
 
@@ -100,6 +107,10 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, fmt.Errorf("`fingerprint_size` must be at least %d bytes", MinFingerprintSize)
 	}
 
+	if err := validateCompression(c.Compression); err != nil {
+		return nil, err
+	}
+
 	// Ensure that splitter is buildable
 	_, err := c.Splitter.Build(false, int(c.MaxLogSize))
 	if err != nil {
@@ -116,6 +127,19 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
 
+	if c.DeleteAfterRead || c.MoveTo != "" {
+		if c.DeleteAfterRead && c.MoveTo != "" {
+			return nil, fmt.Errorf("`delete_after_read` and `move_to` cannot be used together")
+		}
+		// Files found at startup would otherwise be deleted or moved without being read
+		if !startAtBeginning {
+			return nil, fmt.Errorf("`delete_after_read` and `move_to` require `start_at: beginning`")
+		}
+		if c.QuietPeriod <= 0 {
+			return nil, fmt.Errorf("`quiet_period` must be positive")
+		}
+	}
+
 	return &Manager{
 		SugaredLogger: logger.With("component", "fileconsumer"),
 		cancel:        func() {},
@@ -128,6 +152,7 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 			},
 			fromBeginning:  startAtBeginning,
 			splitterConfig: c.Splitter,
+			compression:    c.Compression,
 		},
 		finder:        c.Finder,
 		roller:        newRoller(),
@@ -135,5 +160,10 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		maxBatchFiles: c.MaxConcurrentFiles / 2,
 		knownFiles:    make([]*Reader, 0, 10),
 		seenPaths:     make(map[string]struct{}, 100),
+		postRead: postReadConfig{
+			deleteAfterRead: c.DeleteAfterRead,
+			moveTo:          c.MoveTo,
+			quietPeriod:     c.QuietPeriod,
+		},
 	}, nil
 }
@@ -365,6 +365,26 @@ func TestUnmarshal(t *testing.T) {
 				return cfg
 			}(),
 		},
+		{
+			Name:      "compression",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.Compression = "zstd"
+				return cfg
+			}(),
+		},
+		{
+			Name:      "move_to",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.StartAt = "beginning"
+				cfg.MoveTo = "/var/log/archive"
+				cfg.QuietPeriod = 10 * time.Minute
+				return cfg
+			}(),
+		},
 		{
 			Name:      "encoding_lower",
 			ExpectErr: false,
@@ -508,6 +528,64 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"Compression",
+			func(f *Config) {
+				f.Compression = "gzip"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, "gzip", f.readerFactory.compression)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"DeleteAfterRead",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.DeleteAfterRead = true
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.True(t, f.postRead.deleteAfterRead)
+				require.Equal(t, time.Minute, f.postRead.quietPeriod)
+			},
+		},
+		{
+			"DeleteAfterReadAndMoveTo",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.DeleteAfterRead = true
+				f.MoveTo = "/var/log/archive"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"MoveToStartAtEnd",
+			func(f *Config) {
+				f.MoveTo = "/var/log/archive"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"MoveToInvalidQuietPeriod",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.MoveTo = "/var/log/archive"
+				f.QuietPeriod = 0
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidLineEndRegex",
 			func(f *Config) {
@@ -575,6 +653,8 @@ func TestMapStructureDecodeConfigWithHook(t *testing.T) {
 		"max_log_size":         "1mib",
 		"max_concurrent_files": 1024,
 		"encoding":             "utf16",
+		"compression":          "auto",
+		"quiet_period":         time.Minute,
 	}
 
 	var actual Config
@@ -606,6 +686,8 @@ func TestMapStructureDecodeConfig(t *testing.T) {
 		"max_concurrent_files": 1024,
 		"encoding":             "utf16",
 		"force_flush_period":   500 * time.Millisecond,
+		"compression":          "auto",
+		"quiet_period":         time.Minute,
 	}
 
 	var actual Config
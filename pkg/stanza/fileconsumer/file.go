@@ -42,6 +42,7 @@ type Manager struct {
 
 	pollInterval  time.Duration
 	maxBatchFiles int
+	postRead      postReadConfig
 
 	knownFiles []*Reader
 	seenPaths  map[string]struct{}
@@ -142,9 +143,18 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 	// Any new files that appear should be consumed entirely
 	m.readerFactory.fromBeginning = true
 
+	// The readers may be closed by the roller, so the files they read are identified first
+	read := m.statReaders(readers)
+
 	m.roller.roll(ctx, readers)
 	m.saveCurrent(readers)
-	m.syncLastPollFiles(ctx)
+	if err := m.syncLastPollFiles(ctx); err != nil {
+		m.Errorw("Failed to sync to database", zap.Error(err))
+		return
+	}
+
+	// Only act on the files once their offsets are persisted, so that a crash can't lose unread logs
+	m.applyPostReadActions(readers, read)
 }
 
 // makeReaders takes a list of paths, then creates readers from each of those paths,
@@ -266,26 +276,23 @@ func (m *Manager) findFingerprintMatch(fp *Fingerprint) (*Reader, bool) {
 const knownFilesKey = "knownFiles"
 
 // syncLastPollFiles syncs the most recent set of files to the database
-func (m *Manager) syncLastPollFiles(ctx context.Context) {
+func (m *Manager) syncLastPollFiles(ctx context.Context) error {
 	var buf bytes.Buffer
 	enc := json.NewEncoder(&buf)
 
 	// Encode the number of known files
 	if err := enc.Encode(len(m.knownFiles)); err != nil {
-		m.Errorw("Failed to encode known files", zap.Error(err))
-		return
+		return fmt.Errorf("encode known files: %w", err)
 	}
 
 	// Encode each known file
 	for _, fileReader := range m.knownFiles {
 		if err := enc.Encode(fileReader); err != nil {
-			m.Errorw("Failed to encode known files", zap.Error(err))
+			return fmt.Errorf("encode known files: %w", err)
 		}
 	}
 
-	if err := m.persister.Set(ctx, knownFilesKey, buf.Bytes()); err != nil {
-		m.Errorw("Failed to sync to database", zap.Error(err))
-	}
+	return m.persister.Set(ctx, knownFilesKey, buf.Bytes())
 }
 
 // syncLastPollFiles loads the most recent set of files to the database
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// postReadConfig tells what to do with the files once they have been entirely read.
+type postReadConfig struct {
+	deleteAfterRead bool
+	moveTo          string
+	quietPeriod     time.Duration
+}
+
+func (c postReadConfig) enabled() bool {
+	return c.deleteAfterRead || c.moveTo != ""
+}
+
+// statReaders returns the state of the files read by the readers, taken from their descriptors before they are
+// closed, so that the post-read actions can make sure the paths still lead to the files which were read.
+func (m *Manager) statReaders(readers []*Reader) map[*Reader]os.FileInfo {
+	if !m.postRead.enabled() {
+		return nil
+	}
+
+	infos := make(map[*Reader]os.FileInfo, len(readers))
+	for _, reader := range readers {
+		info, err := reader.file.Stat()
+		if err != nil {
+			m.Debugw("Failed to stat file", "path", reader.file.Name(), zap.Error(err))
+			continue
+		}
+		infos[reader] = info
+	}
+	return infos
+}
+
+// applyPostReadActions deletes or moves the files which have been entirely read
+// and haven't been modified for the quiet period. read is the state of the files
+// returned by statReaders.
+func (m *Manager) applyPostReadActions(readers []*Reader, read map[*Reader]os.FileInfo) {
+	if !m.postRead.enabled() {
+		return
+	}
+
+	now := time.Now()
+	for _, reader := range readers {
+		readInfo, ok := read[reader]
+		if !ok {
+			continue
+		}
+		path := reader.file.Name()
+		info, err := os.Stat(path)
+		if err != nil {
+			m.Debugw("Failed to stat file", "path", path, zap.Error(err))
+			continue
+		}
+		if !os.SameFile(readInfo, info) {
+			// the file was rotated or replaced since it was read, the path leads to a file which hasn't been read
+			m.Debugw("File was replaced since it was read", "path", path)
+			continue
+		}
+		if !reader.consumed(info) || now.Sub(info.ModTime()) < m.postRead.quietPeriod {
+			continue
+		}
+
+		if m.postRead.deleteAfterRead {
+			if err := os.Remove(path); err != nil {
+				m.Errorw("Failed to delete file after read", "path", path, zap.Error(err))
+				continue
+			}
+			m.Infow("Deleted file after read", "path", path)
+			continue
+		}
+
+		dest, err := moveFile(path, m.postRead.moveTo)
+		if err != nil {
+			m.Errorw("Failed to move file after read", "path", path, zap.Error(err))
+			continue
+		}
+		if dest != "" {
+			m.Infow("Moved file after read", "path", path, "destination", dest)
+		}
+	}
+}
+
+// consumed tells whether the file, of which info is the current state, has been entirely read.
+func (r *Reader) consumed(info os.FileInfo) bool {
+	if r.decompress != nil {
+		return r.CompressedSize == info.Size()
+	}
+	return r.Offset == info.Size()
+}
+
+// moveFile moves the file to the directory, without overwriting an existing file.
+// It returns the new path of the file, or an empty string if the file already is in the directory.
+func moveFile(path, dir string) (string, error) {
+	dest := filepath.Join(dir, filepath.Base(path))
+	if filepath.Clean(path) == dest {
+		return "", nil
+	}
+	if _, err := os.Lstat(dest); err == nil {
+		return "", fmt.Errorf("destination %s already exists", dest)
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", fmt.Errorf("create directory: %w", err)
+	}
+	if err := os.Rename(path, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+type failingPersister struct {
+	operator.Persister
+}
+
+func (p failingPersister) Set(context.Context, string, []byte) error {
+	return errors.New("failed")
+}
+
+// writeOldFile writes a file which was last modified an hour ago
+func writeOldFile(t *testing.T, path string, content []byte) {
+	writeFile(t, path, content)
+	modTime := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func buildPostReadManager(t *testing.T, tempDir string, modify func(*Config)) (*Manager, chan *emitParams) {
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	modify(cfg)
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	t.Cleanup(func() {
+		require.NoError(t, operator.Stop())
+	})
+	return operator, emitCalls
+}
+
+func TestDeleteAfterRead(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "test.log")
+	writeOldFile(t, path, []byte("testlog1\ntestlog2\n"))
+
+	operator, emitCalls := buildPostReadManager(t, tempDir, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+	})
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	_, err := os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestDeleteAfterReadQuietPeriod(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "test.log")
+	writeFile(t, path, []byte("testlog1\n"))
+
+	operator, emitCalls := buildPostReadManager(t, tempDir, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+		cfg.QuietPeriod = time.Hour
+	})
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	// The file was modified too recently
+	_, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// The file is deleted once it hasn't been modified for the quiet period
+	modTime := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestDeleteAfterReadPartiallyRead(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "test.log")
+	// The last line isn't terminated, so it isn't read until it's flushed
+	writeOldFile(t, path, []byte("testlog1\ntestlog2"))
+
+	operator, emitCalls := buildPostReadManager(t, tempDir, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+		cfg.Splitter.Flusher.Period = time.Hour
+	})
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	_, err := os.Stat(path)
+	require.NoError(t, err)
+}
+
+func TestDeleteAfterReadNotPersisted(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "test.log")
+	writeOldFile(t, path, []byte("testlog1\n"))
+
+	operator, emitCalls := buildPostReadManager(t, tempDir, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+	})
+	operator.persister = failingPersister{operator.persister}
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	// The offset couldn't be persisted
+	_, err := os.Stat(path)
+	require.NoError(t, err)
+}
+
+func TestDeleteAfterReadCompressed(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "test.log.gz")
+	writeOldFile(t, path, gzipString(t, "testlog1\n"))
+
+	operator, emitCalls := buildPostReadManager(t, tempDir, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+	})
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	_, err := os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestMoveTo(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	moveTo := filepath.Join(t.TempDir(), "archive")
+	path := filepath.Join(tempDir, "test.log")
+	writeOldFile(t, path, []byte("testlog1\n"))
+
+	operator, emitCalls := buildPostReadManager(t, tempDir, func(cfg *Config) {
+		cfg.MoveTo = moveTo
+	})
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	_, err := os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+	content, err := os.ReadFile(filepath.Join(moveTo, "test.log"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("testlog1\n"), content)
+}
+
+func TestMoveToExistingDestination(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	moveTo := t.TempDir()
+	path := filepath.Join(tempDir, "test.log")
+	writeOldFile(t, path, []byte("testlog1\n"))
+	writeFile(t, filepath.Join(moveTo, "test.log"), []byte("archived\n"))
+
+	operator, emitCalls := buildPostReadManager(t, tempDir, func(cfg *Config) {
+		cfg.MoveTo = moveTo
+	})
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	// The existing file isn't overwritten
+	_, err := os.Stat(path)
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(moveTo, "test.log"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("archived\n"), content)
+}
+
+func TestDeleteAfterReadReplacedFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "test.log")
+	writeOldFile(t, path, []byte("testlog1\n"))
+
+	operator, emitCalls := buildPostReadManager(t, tempDir, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+	})
+
+	file := openFile(t, path)
+	fp, err := operator.readerFactory.newFingerprint(file)
+	require.NoError(t, err)
+	reader, err := operator.readerFactory.newReader(file, fp)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	reader.ReadToEnd(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	read := operator.statReaders([]*Reader{reader})
+
+	// The file is rotated and replaced by a file of the same size which hasn't been read
+	require.NoError(t, os.Rename(path, filepath.Join(tempDir, "test.log.1")))
+	writeOldFile(t, path, []byte("testlog2\n"))
+
+	operator.applyPostReadActions([]*Reader{reader}, read)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("testlog2\n"), content)
+}
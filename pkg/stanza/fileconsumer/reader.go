@@ -17,6 +17,8 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"context"
 	"fmt"
+	"io"
+	"math"
 	"os"
 
 	"go.uber.org/zap"
@@ -36,11 +38,17 @@ type Reader struct {
 	*readerConfig
 	splitter *helper.Splitter
 
-	Fingerprint    *Fingerprint
-	Offset         int64
+	Fingerprint *Fingerprint
+	Offset      int64
+	// CompressedSize is the size of a compressed file once its content has been entirely read,
+	// so that it isn't decompressed again as long as it doesn't change.
+	CompressedSize int64 `json:",omitempty"`
+
 	generation     int
 	file           *os.File
 	fileAttributes *FileAttributes
+	decompress     decompressor
+	source         io.Reader
 }
 
 // offsetToEnd sets the starting offset
@@ -49,17 +57,89 @@ func (r *Reader) offsetToEnd() error {
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
 	}
-	r.Offset = info.Size()
+	if r.decompress == nil {
+		r.Offset = info.Size()
+		return nil
+	}
+
+	// The size of the decompressed content is only known once it has been decompressed
+	counter := &countingReader{}
+	dr, err := r.decompress(io.NewSectionReader(r.file, 0, math.MaxInt64))
+	if err == nil {
+		counter.Reader = dr
+		_, err = io.Copy(io.Discard, counter)
+		r.closeDecompressor(dr)
+	}
+	if err != nil && !isIncomplete(err) {
+		return fmt.Errorf("decompress: %w", err)
+	}
+	r.Offset = counter.count
+	if err == nil {
+		r.CompressedSize = info.Size()
+	}
 	return nil
 }
 
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
+	if r.decompress != nil {
+		r.readCompressedToEnd(ctx)
+		return
+	}
+
 	if _, err := r.file.Seek(r.Offset, 0); err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
+	r.source = r.file
+	r.scan(ctx)
+}
+
+// readCompressedToEnd decompresses the file from its beginning, since a compressed stream can't be seeked,
+// and reads the content following the offset. The offset is a position in the decompressed content.
+func (r *Reader) readCompressedToEnd(ctx context.Context) {
+	info, err := r.file.Stat()
+	if err != nil {
+		r.Errorw("Failed to stat", zap.Error(err))
+		return
+	}
+	if r.CompressedSize != 0 && r.CompressedSize == info.Size() {
+		// The file has been entirely read and hasn't changed since
+		return
+	}
+
+	dr, err := r.decompress(io.NewSectionReader(r.file, 0, math.MaxInt64))
+	if err != nil {
+		if !isIncomplete(err) {
+			r.Errorw("Failed to decompress", zap.Error(err))
+		}
+		return
+	}
+	defer r.closeDecompressor(dr)
+
+	counter := &countingReader{Reader: dr}
+	if _, err := io.CopyN(io.Discard, counter, r.Offset); err != nil {
+		if !isIncomplete(err) {
+			r.Errorw("Failed to skip to offset", zap.Error(err))
+		}
+		return
+	}
+	r.source = counter
+	r.scan(ctx)
+
+	if counter.eof && counter.count == r.Offset {
+		r.CompressedSize = info.Size()
+	}
+}
+
+func (r *Reader) closeDecompressor(dr io.Closer) {
+	if err := dr.Close(); err != nil {
+		r.Debugw("Problem closing decompressor", zap.Error(err))
+	}
+}
 
+// scan emits the tokens read from the source, from the offset
+func (r *Reader) scan(ctx context.Context) {
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitter.SplitFunc)
 
 	// Iterate over the tokenized file, emitting entries as we go
@@ -73,7 +153,11 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		ok := scanner.Scan()
 		if !ok {
 			if err := scanner.getError(); err != nil {
-				r.Errorw("Failed during scan", zap.Error(err))
+				if r.decompress != nil && isIncomplete(err) {
+					r.Debugw("Compressed file is incomplete", zap.Error(err))
+				} else {
+					r.Errorw("Failed during scan", zap.Error(err))
+				}
 			}
 			break
 		}
@@ -103,9 +187,9 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.source.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.source.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
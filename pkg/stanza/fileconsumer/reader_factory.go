@@ -27,6 +27,7 @@ type readerFactory struct {
 	readerConfig   *readerConfig
 	fromBeginning  bool
 	splitterConfig helper.SplitterConfig
+	compression    string
 }
 
 func (f *readerFactory) newReader(file *os.File, fp *Fingerprint) (*Reader, error) {
@@ -42,6 +43,7 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withFile(newFile).
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
+		withCompressedSize(old.CompressedSize).
 		withSplitter(old.splitter).
 		build()
 }
@@ -51,15 +53,23 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	decompress, err := detectDecompressor(file, f.compression)
+	if err != nil {
+		return nil, err
+	}
+	if decompress != nil {
+		return newDecompressedFingerprint(file, decompress, f.readerConfig.fingerprintSize)
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
 type readerBuilder struct {
 	*readerFactory
-	file     *os.File
-	fp       *Fingerprint
-	offset   int64
-	splitter *helper.Splitter
+	file           *os.File
+	fp             *Fingerprint
+	offset         int64
+	compressedSize int64
+	splitter       *helper.Splitter
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -86,10 +96,16 @@ func (b *readerBuilder) withOffset(offset int64) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withCompressedSize(size int64) *readerBuilder {
+	b.compressedSize = size
+	return b
+}
+
 func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
-		readerConfig: b.readerConfig,
-		Offset:       b.offset,
+		readerConfig:   b.readerConfig,
+		Offset:         b.offset,
+		CompressedSize: b.compressedSize,
 	}
 
 	if b.splitter != nil {
@@ -109,6 +125,11 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 			b.Errorf("resolve attributes: %w", err)
 		}
 
+		r.decompress, err = detectDecompressor(b.file, b.compression)
+		if err != nil {
+			return nil, err
+		}
+
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
 			if err := r.offsetToEnd(); err != nil {
@@ -22,6 +22,7 @@ require (
 
 require (
 	github.com/influxdata/go-syslog/v3 v3.0.1-0.20210608084020-ac565dc76ba6
+	github.com/klauspost/compress v1.15.9
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.60.0
 	go.opentelemetry.io/collector/pdata v0.60.1-0.20220916163348-84621e483dfb
 	go.uber.org/atomic v1.10.0
@@ -711,6 +711,8 @@ func TestMapStructureDecodeConfigWithHook(t *testing.T) {
 		"max_log_size":         "1mib",
 		"max_concurrent_files": 1024,
 		"encoding":             "utf16",
+		"compression":          "auto",
+		"quiet_period":         time.Minute,
 	}
 
 	var actual Config
@@ -745,6 +747,8 @@ func TestMapStructureDecodeConfig(t *testing.T) {
 		"max_concurrent_files": 1024,
 		"encoding":             "utf16",
 		"force_flush_period":   500 * time.Millisecond,
+		"compression":          "auto",
+		"quiet_period":         time.Minute,
 	}
 
 	var actual Config
@@ -0,0 +1,398 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "container_parser"
+
+	formatAuto       = "auto"
+	formatDocker     = "docker"
+	formatCRIO       = "crio"
+	formatContainerd = "containerd"
+
+	// The attributes set by the file_input operator
+	filePathAttribute = "log.file.path"
+	streamAttribute   = "log.iostream"
+
+	namespaceResource     = "k8s.namespace.name"
+	podNameResource       = "k8s.pod.name"
+	podUIDResource        = "k8s.pod.uid"
+	containerNameResource = "k8s.container.name"
+	restartCountResource  = "k8s.container.restart_count"
+	containerIDResource   = "container.id"
+)
+
+// podLogPathRegexp matches the path of the log files of the kubelet,
+// /var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log
+var podLogPathRegexp = regexp.MustCompile(`^.*/([^_/]+)_([^_/]+)_([a-f0-9-]+)/([^/]+)/(\d+)\.log$`)
+
+// containerLogPathRegexp matches the path of the symbolic links to the log files created by the kubelet,
+// /var/log/containers/<pod_name>_<namespace>_<container_name>-<container_id>.log
+var containerLogPathRegexp = regexp.MustCompile(`^.*/([^_/]+)_([^_/]+)_([^/]+)-([a-f0-9]{64})\.log$`)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new container parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig:       helper.NewTransformerConfig(operatorID, operatorType),
+		ParseFrom:               entry.NewBodyField(),
+		Format:                  formatAuto,
+		AddMetadataFromFilePath: true,
+		MaxLogSize:              1024 * 1024,
+		ForceFlushTimeout:       5 * time.Second,
+	}
+}
+
+// Config is the configuration of a container parser operator.
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash" yaml:",inline"`
+
+	ParseFrom               entry.Field     `mapstructure:"parse_from"                 json:"parse_from"                 yaml:"parse_from"`
+	Format                  string          `mapstructure:"format"                     json:"format"                     yaml:"format"`
+	AddMetadataFromFilePath bool            `mapstructure:"add_metadata_from_filepath" json:"add_metadata_from_filepath" yaml:"add_metadata_from_filepath"`
+	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size"               json:"max_log_size"               yaml:"max_log_size"`
+	ForceFlushTimeout       time.Duration   `mapstructure:"force_flush_period"         json:"force_flush_period"         yaml:"force_flush_period"`
+}
+
+// Build will build a container parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Format {
+	case formatAuto, formatDocker, formatCRIO, formatContainerd:
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'format'", c.Format)
+	}
+
+	if c.MaxLogSize <= 0 {
+		return nil, fmt.Errorf("`max_log_size` must be positive")
+	}
+
+	if c.ForceFlushTimeout <= 0 {
+		return nil, fmt.Errorf("`force_flush_period` must be positive")
+	}
+
+	return &Parser{
+		TransformerOperator:     transformerOperator,
+		parseFrom:               c.ParseFrom,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+		maxLogSize:              int(c.MaxLogSize),
+		forceFlushTimeout:       c.ForceFlushTimeout,
+		json:                    jsoniter.ConfigFastest,
+		partials:                make(map[string]*partialLog),
+		chClose:                 make(chan struct{}),
+	}, nil
+}
+
+// Parser is an operator that parses the logs written by container runtimes,
+// reassembling the lines they split.
+type Parser struct {
+	helper.TransformerOperator
+	parseFrom               entry.Field
+	format                  string
+	addMetadataFromFilePath bool
+	maxLogSize              int
+	forceFlushTimeout       time.Duration
+	json                    jsoniter.API
+	chClose                 chan struct{}
+	wg                      sync.WaitGroup
+
+	sync.Mutex
+	// partials are the lines being reassembled, keyed by the file and the stream they come from
+	partials map[string]*partialLog
+}
+
+// partialLog is a log line split by the container runtime, which hasn't been entirely read yet.
+type partialLog struct {
+	entry      *entry.Entry
+	body       strings.Builder
+	lastUpdate time.Time
+}
+
+// containerLog is a line written by a container runtime.
+type containerLog struct {
+	time    time.Time
+	stream  string
+	log     string
+	partial bool
+}
+
+// dockerLog is a line written by the json-file logging driver of Docker.
+type dockerLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// Start will start flushing the partial lines which haven't been completed within the force flush period.
+func (p *Parser) Start(_ operator.Persister) error {
+	p.wg.Add(1)
+	go p.flushLoop()
+	return nil
+}
+
+// Stop will flush the partial lines.
+func (p *Parser) Stop() error {
+	close(p.chClose)
+	p.wg.Wait()
+
+	p.Lock()
+	defer p.Unlock()
+	for source := range p.partials {
+		p.flush(context.Background(), source)
+	}
+	return nil
+}
+
+func (p *Parser) flushLoop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.forceFlushTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.Lock()
+			now := time.Now()
+			for source, partial := range p.partials {
+				if now.Sub(partial.lastUpdate) >= p.forceFlushTimeout {
+					p.flush(context.Background(), source)
+				}
+			}
+			p.Unlock()
+		case <-p.chClose:
+			return
+		}
+	}
+}
+
+// Process will parse an entry written by a container runtime.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	// Short circuit if the "if" condition does not match
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.Write(ctx, e)
+		return nil
+	}
+
+	value, ok := e.Get(p.parseFrom)
+	if !ok {
+		err := errors.NewError(
+			"Entry is missing the expected parse_from field.",
+			"Ensure that all incoming entries contain the parse_from field.",
+			"parse_from", p.parseFrom.String(),
+		)
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	line, err := p.parse(value)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	if p.addMetadataFromFilePath {
+		if err := addMetadataFromFilePath(e); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+	}
+
+	e.Body = line.log
+	e.Timestamp = line.time
+	e.AddAttribute(streamAttribute, line.stream)
+
+	p.reassemble(ctx, e, line)
+	return nil
+}
+
+// parse will parse a line written by a container runtime.
+func (p *Parser) parse(value interface{}) (*containerLog, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("type %T cannot be parsed as a container log", value)
+	}
+
+	format := p.format
+	if format == formatAuto {
+		format = detectFormat(raw)
+	}
+
+	if format == formatDocker {
+		return p.parseDocker(raw)
+	}
+	return parseCRI(raw)
+}
+
+// detectFormat tells the format of a line, the logs of CRI-O and containerd having the same format.
+func detectFormat(raw string) string {
+	if strings.HasPrefix(raw, "{") {
+		return formatDocker
+	}
+	return formatContainerd
+}
+
+// parseDocker parses a line of the json-file logging driver of Docker, such as
+// {"log":"message\n","stream":"stdout","time":"2021-06-22T10:27:25.813799277Z"}
+// Docker splits the lines longer than 16KiB, all but the last part missing the trailing newline.
+func (p *Parser) parseDocker(raw string) (*containerLog, error) {
+	var parsed dockerLog
+	if err := p.json.UnmarshalFromString(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("parse docker log: %w", err)
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, parsed.Time)
+	if err != nil {
+		return nil, fmt.Errorf("parse docker log time: %w", err)
+	}
+
+	return &containerLog{
+		time:    ts,
+		stream:  parsed.Stream,
+		log:     strings.TrimSuffix(parsed.Log, "\n"),
+		partial: !strings.HasSuffix(parsed.Log, "\n"),
+	}, nil
+}
+
+// parseCRI parses a line of the CRI log format used by CRI-O and containerd, such as
+// 2021-06-22T10:27:25.813799277Z stdout F message
+// The tag is P for all but the last part of a split line, and F for the last part.
+func parseCRI(raw string) (*containerLog, error) {
+	fields := strings.SplitN(raw, " ", 4)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("parse cri log: expected a time, a stream and a tag")
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("parse cri log time: %w", err)
+	}
+
+	line := &containerLog{
+		time:   ts,
+		stream: fields[1],
+	}
+	if len(fields) == 4 {
+		line.log = fields[3]
+	}
+
+	// The tag may be followed by other tags, separated by colons
+	switch tag := strings.SplitN(fields[2], ":", 2)[0]; tag {
+	case "P":
+		line.partial = true
+	case "F":
+	default:
+		return nil, fmt.Errorf("parse cri log: invalid tag '%s'", tag)
+	}
+	return line, nil
+}
+
+// addMetadataFromFilePath sets the resource attributes of the pod and the container,
+// from the path of the log file read by the file_input operator.
+func addMetadataFromFilePath(e *entry.Entry) error {
+	var path string
+	if err := e.Read(entry.NewAttributeField(filePathAttribute), &path); err != nil {
+		return fmt.Errorf("read %s attribute, the file_input operator must be configured with include_file_path: %w", filePathAttribute, err)
+	}
+
+	if matches := podLogPathRegexp.FindStringSubmatch(path); matches != nil {
+		e.AddResourceKey(namespaceResource, matches[1])
+		e.AddResourceKey(podNameResource, matches[2])
+		e.AddResourceKey(podUIDResource, matches[3])
+		e.AddResourceKey(containerNameResource, matches[4])
+		e.AddResourceKey(restartCountResource, matches[5])
+		return nil
+	}
+
+	if matches := containerLogPathRegexp.FindStringSubmatch(path); matches != nil {
+		e.AddResourceKey(podNameResource, matches[1])
+		e.AddResourceKey(namespaceResource, matches[2])
+		e.AddResourceKey(containerNameResource, matches[3])
+		e.AddResourceKey(containerIDResource, matches[4])
+	}
+	// The logs of other files, e.g. not written by the kubelet, are parsed without metadata.
+	return nil
+}
+
+// reassemble writes the entry, once the line it is part of has been entirely read.
+// The reassembled entry is the one of the first part of the line.
+func (p *Parser) reassemble(ctx context.Context, e *entry.Entry, line *containerLog) {
+	p.Lock()
+	defer p.Unlock()
+
+	var source string
+	if path, ok := e.Attributes[filePathAttribute].(string); ok {
+		source = path
+	}
+	source += "/" + line.stream
+
+	partial, ok := p.partials[source]
+	if !ok {
+		if !line.partial {
+			p.Write(ctx, e)
+			return
+		}
+		partial = &partialLog{entry: e}
+		p.partials[source] = partial
+	}
+
+	partial.body.WriteString(line.log)
+	partial.lastUpdate = time.Now()
+	if !line.partial || partial.body.Len() >= p.maxLogSize {
+		p.flush(ctx, source)
+	}
+}
+
+// flush writes the partial line of the source. It must be called with the lock held.
+func (p *Parser) flush(ctx context.Context, source string) {
+	partial, ok := p.partials[source]
+	if !ok {
+		return
+	}
+	delete(p.partials, source)
+
+	partial.entry.Body = partial.body.String()
+	p.Write(ctx, partial.entry)
+}
@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const testPath = "/var/log/pods/default_my-pod_3f4b3f4e-9a0c-4cd1-9b6e-5f0e1b8e2d0a/my-container/2.log"
+
+func newTestParser(t *testing.T, configure func(*Config)) (*Parser, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	configure(cfg)
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	return op.(*Parser), fake
+}
+
+func newTestEntry(body string) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.AddAttribute(filePathAttribute, testPath)
+	return e
+}
+
+func expectedResource() map[string]interface{} {
+	return map[string]interface{}{
+		namespaceResource:     "default",
+		podNameResource:       "my-pod",
+		podUIDResource:        "3f4b3f4e-9a0c-4cd1-9b6e-5f0e1b8e2d0a",
+		containerNameResource: "my-container",
+		restartCountResource:  "2",
+	}
+}
+
+func TestConfigBuild(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		expected  string
+	}{
+		{"format", func(cfg *Config) { cfg.Format = "podman" }, "invalid value 'podman' for parameter 'format'"},
+		{"max_log_size", func(cfg *Config) { cfg.MaxLogSize = 0 }, "`max_log_size` must be positive"},
+		{"force_flush_period", func(cfg *Config) { cfg.ForceFlushTimeout = 0 }, "`force_flush_period` must be positive"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name     string
+		format   string
+		input    string
+		expected *containerLog
+	}{
+		{
+			"docker",
+			formatAuto,
+			`{"log":"message\n","stream":"stdout","time":"2021-06-22T10:27:25.813799277Z"}`,
+			&containerLog{
+				time:   time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC),
+				stream: "stdout",
+				log:    "message",
+			},
+		},
+		{
+			"docker_partial",
+			formatDocker,
+			`{"log":"mess","stream":"stderr","time":"2021-06-22T10:27:25.813799277Z"}`,
+			&containerLog{
+				time:    time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC),
+				stream:  "stderr",
+				log:     "mess",
+				partial: true,
+			},
+		},
+		{
+			"containerd",
+			formatAuto,
+			"2021-06-22T10:27:25.813799277Z stdout F message with spaces",
+			&containerLog{
+				time:   time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC),
+				stream: "stdout",
+				log:    "message with spaces",
+			},
+		},
+		{
+			"crio",
+			formatCRIO,
+			"2021-06-22T12:27:25.813799277+02:00 stderr P mess",
+			&containerLog{
+				time:    time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC),
+				stream:  "stderr",
+				log:     "mess",
+				partial: true,
+			},
+		},
+		{
+			"cri_empty_line",
+			formatAuto,
+			"2021-06-22T10:27:25.813799277Z stdout F",
+			&containerLog{
+				time:   time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC),
+				stream: "stdout",
+			},
+		},
+		{
+			"cri_multiple_tags",
+			formatContainerd,
+			"2021-06-22T10:27:25.813799277Z stdout F:extra message",
+			&containerLog{
+				time:   time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC),
+				stream: "stdout",
+				log:    "message",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parser, _ := newTestParser(t, func(cfg *Config) { cfg.Format = tc.format })
+			actual, err := parser.parse(tc.input)
+			require.NoError(t, err)
+			require.True(t, tc.expected.time.Equal(actual.time))
+			tc.expected.time = actual.time
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseFailure(t *testing.T) {
+	cases := []struct {
+		name     string
+		format   string
+		input    interface{}
+		expected string
+	}{
+		{"type", formatAuto, []byte("message"), "type []uint8 cannot be parsed as a container log"},
+		{"docker_json", formatAuto, `{"log":`, "parse docker log"},
+		{"docker_time", formatAuto, `{"log":"message\n","stream":"stdout","time":"yesterday"}`, "parse docker log time"},
+		{"docker_format_cri_log", formatDocker, "2021-06-22T10:27:25.813799277Z stdout F message", "parse docker log"},
+		{"cri_fields", formatAuto, "2021-06-22T10:27:25.813799277Z", "expected a time, a stream and a tag"},
+		{"cri_time", formatAuto, "yesterday stdout F message", "parse cri log time"},
+		{"cri_tag", formatAuto, "2021-06-22T10:27:25.813799277Z stdout X message", "invalid tag 'X'"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parser, _ := newTestParser(t, func(cfg *Config) { cfg.Format = tc.format })
+			_, err := parser.parse(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {})
+
+	input := newTestEntry("2021-06-22T10:27:25.813799277Z stdout F message")
+	require.NoError(t, parser.Process(context.Background(), input))
+
+	expected := &entry.Entry{
+		ObservedTimestamp: input.ObservedTimestamp,
+		Timestamp:         time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC),
+		Body:              "message",
+		Attributes: map[string]interface{}{
+			filePathAttribute: testPath,
+			streamAttribute:   "stdout",
+		},
+		Resource: expectedResource(),
+	}
+	fake.ExpectEntry(t, expected)
+}
+
+func TestProcessWithoutMetadata(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {
+		cfg.AddMetadataFromFilePath = false
+	})
+
+	input := entry.New()
+	input.Body = `{"log":"message\n","stream":"stdout","time":"2021-06-22T10:27:25.813799277Z"}`
+	require.NoError(t, parser.Process(context.Background(), input))
+
+	expected := &entry.Entry{
+		ObservedTimestamp: input.ObservedTimestamp,
+		Timestamp:         time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC),
+		Body:              "message",
+		Attributes: map[string]interface{}{
+			streamAttribute: "stdout",
+		},
+	}
+	fake.ExpectEntry(t, expected)
+}
+
+func TestProcessMetadataFailure(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {})
+
+	input := entry.New()
+	input.Body = "2021-06-22T10:27:25.813799277Z stdout F message"
+	err := parser.Process(context.Background(), input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be configured with include_file_path")
+
+	// The entry is sent unchanged with on_error: send
+	fake.ExpectBody(t, "2021-06-22T10:27:25.813799277Z stdout F message")
+}
+
+func TestProcessMetadataFromFilePath(t *testing.T) {
+	const containerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	cases := []struct {
+		name     string
+		path     string
+		expected map[string]interface{}
+	}{
+		{"pod_log", testPath, expectedResource()},
+		{
+			"container_log",
+			"/var/log/containers/my-pod_default_my-container-" + containerID + ".log",
+			map[string]interface{}{
+				namespaceResource:     "default",
+				podNameResource:       "my-pod",
+				containerNameResource: "my-container",
+				containerIDResource:   containerID,
+			},
+		},
+		{"other_log", "/var/log/containers/my-container.log", nil},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parser, fake := newTestParser(t, func(cfg *Config) {})
+
+			input := entry.New()
+			input.Body = "2021-06-22T10:27:25.813799277Z stdout F message"
+			input.AddAttribute(filePathAttribute, tc.path)
+			require.NoError(t, parser.Process(context.Background(), input))
+
+			expected := &entry.Entry{
+				ObservedTimestamp: input.ObservedTimestamp,
+				Timestamp:         time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC),
+				Body:              "message",
+				Attributes: map[string]interface{}{
+					filePathAttribute: tc.path,
+					streamAttribute:   "stdout",
+				},
+				Resource: tc.expected,
+			}
+			fake.ExpectEntry(t, expected)
+		})
+	}
+}
+
+func TestProcessPartialLines(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {})
+
+	first := newTestEntry("2021-06-22T10:27:25.000000000Z stdout P first ")
+	require.NoError(t, parser.Process(context.Background(), first))
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2021-06-22T10:27:25.000000000Z stderr F error")))
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2021-06-22T10:27:26.000000000Z stdout P second ")))
+
+	// The line of the other stream isn't reassembled with the partial line
+	fake.ExpectBody(t, "error")
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2021-06-22T10:27:27.000000000Z stdout F last")))
+
+	expected := &entry.Entry{
+		ObservedTimestamp: first.ObservedTimestamp,
+		Timestamp:         time.Date(2021, 6, 22, 10, 27, 25, 0, time.UTC),
+		Body:              "first second last",
+		Attributes: map[string]interface{}{
+			filePathAttribute: testPath,
+			streamAttribute:   "stdout",
+		},
+		Resource: expectedResource(),
+	}
+	fake.ExpectEntry(t, expected)
+}
+
+func TestProcessDockerPartialLines(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {})
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry(`{"log":"first ","stream":"stdout","time":"2021-06-22T10:27:25.813799277Z"}`)))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+	require.NoError(t, parser.Process(context.Background(), newTestEntry(`{"log":"last\n","stream":"stdout","time":"2021-06-22T10:27:25.813799277Z"}`)))
+	fake.ExpectBody(t, "first last")
+}
+
+func TestProcessPartialLinesMaxLogSize(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {
+		cfg.MaxLogSize = 10
+	})
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2021-06-22T10:27:25.000000000Z stdout P 0123456")))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2021-06-22T10:27:25.000000000Z stdout P 789")))
+	fake.ExpectBody(t, "0123456789")
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2021-06-22T10:27:25.000000000Z stdout F end")))
+	fake.ExpectBody(t, "end")
+}
+
+func TestProcessPartialLinesForceFlush(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {
+		cfg.ForceFlushTimeout = 100 * time.Millisecond
+	})
+	require.NoError(t, parser.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, parser.Stop())
+	}()
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2021-06-22T10:27:25.000000000Z stdout P partial")))
+	fake.ExpectBody(t, "partial")
+}
+
+func TestStopFlushesPartialLines(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {})
+	require.NoError(t, parser.Start(testutil.NewMockPersister("test")))
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2021-06-22T10:27:25.000000000Z stdout P partial")))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	require.NoError(t, parser.Stop())
+	fake.ExpectBody(t, "partial")
+}
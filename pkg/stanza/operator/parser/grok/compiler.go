@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grok // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/grok"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	typeString = "string"
+	typeInt    = "int"
+	typeFloat  = "float"
+)
+
+// referenceRegexp matches the references to the patterns, %{SYNTAX}, %{SYNTAX:SEMANTIC} or %{SYNTAX:SEMANTIC:TYPE}.
+var referenceRegexp = regexp.MustCompile(`%\{(\w+)(?::([^:{}]+))?(?::(\w+))?\}`)
+
+// field is a value captured by a pattern.
+type field struct {
+	name string
+	typ  string
+}
+
+// capture is a capture group of a compiled pattern.
+type capture struct {
+	index int
+	field
+}
+
+// pattern is a compiled grok pattern.
+type pattern struct {
+	regexp   *regexp.Regexp
+	captures []capture
+}
+
+// match returns the values captured by the pattern, or nil if the pattern doesn't match.
+// Only the first capture group participating in the match sets a field.
+func (p *pattern) match(value string) (map[string]interface{}, error) {
+	indexes := p.regexp.FindStringSubmatchIndex(value)
+	if indexes == nil {
+		return nil, nil
+	}
+
+	parsedValues := map[string]interface{}{}
+	for _, c := range p.captures {
+		start, end := indexes[2*c.index], indexes[2*c.index+1]
+		if start < 0 {
+			continue
+		}
+		if _, ok := parsedValues[c.name]; ok {
+			continue
+		}
+		converted, err := convert(value[start:end], c.typ)
+		if err != nil {
+			return nil, fmt.Errorf("convert field %s: %w", c.name, err)
+		}
+		parsedValues[c.name] = converted
+	}
+	return parsedValues, nil
+}
+
+func convert(value, typ string) (interface{}, error) {
+	switch typ {
+	case typeInt:
+		return strconv.ParseInt(value, 10, 64)
+	case typeFloat:
+		return strconv.ParseFloat(value, 64)
+	default:
+		return value, nil
+	}
+}
+
+// compiler compiles grok patterns into regular expressions.
+type compiler struct {
+	definitions map[string]string
+}
+
+// newCompiler creates a compiler knowing the builtin patterns, the patterns of the files and the given definitions,
+// each overriding the previous ones.
+func newCompiler(files []string, definitions map[string]string) (*compiler, error) {
+	c := &compiler{definitions: make(map[string]string, len(builtinPatterns))}
+	for name, definition := range builtinPatterns {
+		c.definitions[name] = definition
+	}
+	for _, file := range files {
+		if err := c.loadPatternFile(file); err != nil {
+			return nil, fmt.Errorf("load pattern file %s: %w", file, err)
+		}
+	}
+	for name, definition := range definitions {
+		c.definitions[name] = definition
+	}
+	return c, nil
+}
+
+// loadPatternFile loads the patterns of a file in the format used by Logstash, one `NAME PATTERN` per line,
+// ignoring the empty lines and the comments starting with #.
+func (c *compiler) loadPatternFile(path string) error {
+	file, err := os.Open(path) // #nosec - operator must read in files defined by user
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, definition, ok := strings.Cut(line, " ")
+		if !ok {
+			return fmt.Errorf("line %d: expected a name and a pattern", lineNumber)
+		}
+		c.definitions[name] = strings.TrimSpace(definition)
+	}
+	return scanner.Err()
+}
+
+// compile compiles a grok pattern. The named capture groups of the regular expression are captured as strings too.
+func (c *compiler) compile(grok string) (*pattern, error) {
+	fields := map[string]field{}
+	expanded, err := c.expand(grok, fields, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := regexp.Compile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("compiling pattern %s: %w", grok, err)
+	}
+
+	p := &pattern{regexp: r}
+	for i, name := range r.SubexpNames() {
+		if f, ok := fields[name]; ok {
+			p.captures = append(p.captures, capture{index: i, field: f})
+		} else if name != "" {
+			p.captures = append(p.captures, capture{index: i, field: field{name: name, typ: typeString}})
+		}
+	}
+	if len(p.captures) == 0 {
+		return nil, fmt.Errorf("pattern %s doesn't capture any field, use references like %%{SYNTAX:SEMANTIC}", grok)
+	}
+	return p, nil
+}
+
+// expand replaces the references to other patterns recursively. The references with a semantic
+// become capture groups, named after the fields which are added to fields.
+func (c *compiler) expand(grok string, fields map[string]field, stack []string) (string, error) {
+	var expanded strings.Builder
+	last := 0
+	for _, match := range referenceRegexp.FindAllStringSubmatchIndex(grok, -1) {
+		expanded.WriteString(grok[last:match[0]])
+		last = match[1]
+
+		name := grok[match[2]:match[3]]
+		for _, parent := range stack {
+			if parent == name {
+				return "", fmt.Errorf("pattern %%{%s} references itself", name)
+			}
+		}
+		definition, ok := c.definitions[name]
+		if !ok {
+			return "", fmt.Errorf("pattern %%{%s} is not defined", name)
+		}
+
+		inner, err := c.expand(definition, fields, append(stack, name))
+		if err != nil {
+			return "", err
+		}
+
+		if match[4] < 0 {
+			expanded.WriteString("(?:" + inner + ")")
+			continue
+		}
+
+		f := field{name: grok[match[4]:match[5]], typ: typeString}
+		if match[6] >= 0 {
+			f.typ = grok[match[6]:match[7]]
+		}
+		switch f.typ {
+		case typeString, typeInt, typeFloat:
+		default:
+			return "", fmt.Errorf("invalid type '%s' of field %s, must be one of %s, %s or %s", f.typ, f.name, typeString, typeInt, typeFloat)
+		}
+
+		group := fmt.Sprintf("_grok%d", len(fields))
+		fields[group] = f
+		expanded.WriteString("(?P<" + group + ">" + inner + ")")
+	}
+	expanded.WriteString(grok[last:])
+	return expanded.String(), nil
+}
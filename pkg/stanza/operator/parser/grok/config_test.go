@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grok
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestParserGoldenConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "parse_from_simple",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseFrom = entry.NewBodyField("from")
+					return cfg
+				}(),
+			},
+			{
+				Name: "patterns",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Patterns = []string{
+						"%{COMBINEDAPACHELOG}",
+						"%{IP:client} %{WORD:method} %{NUMBER:bytes:int}",
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name: "pattern_definitions",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Patterns = []string{"%{QUEUE_ID:queue_id}"}
+					cfg.PatternDefinitions = map[string]string{
+						"QUEUE_ID": "[0-9A-F]{10,11}",
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name: "pattern_files",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Patterns = []string{"%{POSTFIX_QUEUEID:queue_id}"}
+					cfg.PatternFiles = []string{"/etc/grok/postfix", "/etc/grok/custom"}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grok // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/grok"
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "grok_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new grok parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new grok parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a grok parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+
+	Patterns           []string          `mapstructure:"patterns"            json:"patterns"            yaml:"patterns"`
+	PatternDefinitions map[string]string `mapstructure:"pattern_definitions" json:"pattern_definitions" yaml:"pattern_definitions"`
+	PatternFiles       []string          `mapstructure:"pattern_files"       json:"pattern_files"       yaml:"pattern_files"`
+}
+
+// Build will build a grok parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Patterns) == 0 {
+		return nil, fmt.Errorf("missing required field 'patterns'")
+	}
+
+	compiler, err := newCompiler(c.PatternFiles, c.PatternDefinitions)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := make([]*pattern, 0, len(c.Patterns))
+	for _, grok := range c.Patterns {
+		p, err := compiler.compile(grok)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+		patterns:       patterns,
+	}, nil
+}
+
+// Parser is an operator that parses grok patterns in an entry.
+type Parser struct {
+	helper.ParserOperator
+	patterns []*pattern
+}
+
+// Process will parse an entry with the grok patterns.
+func (g *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return g.ParserOperator.ProcessWith(ctx, entry, g.parse)
+}
+
+// parse will parse a value with the first grok pattern matching it.
+func (g *Parser) parse(value interface{}) (interface{}, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("type '%T' cannot be parsed as grok", value)
+	}
+
+	for _, p := range g.patterns {
+		parsedValues, err := p.match(raw)
+		if err != nil {
+			return nil, err
+		}
+		if parsedValues != nil {
+			return parsedValues, nil
+		}
+	}
+	return nil, fmt.Errorf("no grok pattern matches")
+}
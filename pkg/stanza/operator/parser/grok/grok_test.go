@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grok
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T, configure func(*Config)) *Parser {
+	cfg := NewConfigWithID("test")
+	configure(cfg)
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestConfigBuild(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Patterns = []string{"%{WORD:word}"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		expected  string
+	}{
+		{
+			name:      "missing_patterns",
+			configure: func(cfg *Config) {},
+			expected:  "missing required field 'patterns'",
+		},
+		{
+			name: "unknown_pattern",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"%{UNKNOWN:value}"}
+			},
+			expected: "pattern %{UNKNOWN} is not defined",
+		},
+		{
+			name: "cycle",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"%{FIRST:value}"}
+				cfg.PatternDefinitions = map[string]string{
+					"FIRST":  "a%{SECOND}",
+					"SECOND": "b%{FIRST}",
+				}
+			},
+			expected: "pattern %{FIRST} references itself",
+		},
+		{
+			name: "invalid_type",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"%{NUMBER:value:bool}"}
+			},
+			expected: "invalid type 'bool' of field value",
+		},
+		{
+			name: "no_capture",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"%{WORD} %{NUMBER}"}
+			},
+			expected: "doesn't capture any field",
+		},
+		{
+			name: "invalid_regex",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"%{WORD:word} (unclosed"}
+			},
+			expected: "compiling pattern",
+		},
+		{
+			name: "missing_pattern_file",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"%{WORD:word}"}
+				cfg.PatternFiles = []string{filepath.Join("testdata", "missing")}
+			},
+			expected: "load pattern file",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     string
+		expected  map[string]interface{}
+	}{
+		{
+			name: "combined_apache_log",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"%{COMBINEDAPACHELOG}"}
+			},
+			input: `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			expected: map[string]interface{}{
+				"clientip":    "127.0.0.1",
+				"ident":       "-",
+				"auth":        "frank",
+				"timestamp":   "10/Oct/2000:13:55:36 -0700",
+				"verb":        "GET",
+				"request":     "/apache_pb.gif",
+				"httpversion": "1.0",
+				"response":    "200",
+				"bytes":       "2326",
+				"referrer":    `"http://www.example.com/start.html"`,
+				"agent":       `"Mozilla/4.08"`,
+			},
+		},
+		{
+			name: "typed_captures",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{`%{IP:client} %{WORD:method} %{URIPATHPARAM:request} %{NUMBER:bytes:int} %{NUMBER:duration:float} %{INT:status:string}`}
+			},
+			input: "55.3.244.1 GET /index.html 15824 0.043 200",
+			expected: map[string]interface{}{
+				"client":   "55.3.244.1",
+				"method":   "GET",
+				"request":  "/index.html",
+				"bytes":    int64(15824),
+				"duration": 0.043,
+				"status":   "200",
+			},
+		},
+		{
+			name: "first_matching_pattern",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{
+					"^%{INT:code:int} %{GREEDYDATA:message}$",
+					"^%{WORD:level} %{GREEDYDATA:message}$",
+					"^%{GREEDYDATA:message}$",
+				}
+			},
+			input: "ERROR connection refused",
+			expected: map[string]interface{}{
+				"level":   "ERROR",
+				"message": "connection refused",
+			},
+		},
+		{
+			name: "alternation",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"^%{DATE:date} %{WORD:word}$"}
+			},
+			input: "25.12.2022 hello",
+			expected: map[string]interface{}{
+				"date": "25.12.2022",
+				"word": "hello",
+			},
+		},
+		{
+			name: "non_participating_captures",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{`^(?:%{INT:value:int}|%{WORD:value})$`}
+			},
+			input: "hello",
+			expected: map[string]interface{}{
+				"value": "hello",
+			},
+		},
+		{
+			name: "named_groups",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{`^(?P<prefix>\w+)-%{INT:id:int}$`}
+			},
+			input: "order-42",
+			expected: map[string]interface{}{
+				"prefix": "order",
+				"id":     int64(42),
+			},
+		},
+		{
+			name: "pattern_definitions",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"^%{QUEUE_ID:queue_id}: %{GREEDYDATA:message}$"}
+				cfg.PatternDefinitions = map[string]string{
+					"QUEUE_ID": "[0-9A-F]{10,11}",
+				}
+			},
+			input: "4DCB2A4F3A: removed",
+			expected: map[string]interface{}{
+				"queue_id": "4DCB2A4F3A",
+				"message":  "removed",
+			},
+		},
+		{
+			name: "pattern_files",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"%{POSTFIX_QMGR}"}
+				cfg.PatternFiles = []string{filepath.Join("testdata", "postfix")}
+			},
+			input: "Oct 11 22:14:15 mailhost postfix/qmgr[2145]: 4DCB2A4F3A: from=<alice@example.com>, size=1024",
+			expected: map[string]interface{}{
+				"timestamp": "Oct 11 22:14:15",
+				"logsource": "mailhost",
+				"program":   "postfix/qmgr",
+				"pid":       "2145",
+				"queue_id":  "4DCB2A4F3A",
+				"from":      "alice@example.com",
+				"size":      int64(1024),
+			},
+		},
+		{
+			name: "pattern_definitions_override_files",
+			configure: func(cfg *Config) {
+				cfg.Patterns = []string{"^%{POSTFIX_QUEUEID:queue_id}$"}
+				cfg.PatternFiles = []string{filepath.Join("testdata", "postfix")}
+				cfg.PatternDefinitions = map[string]string{
+					"POSTFIX_QUEUEID": "[a-z]+",
+				}
+			},
+			input: "abcdef",
+			expected: map[string]interface{}{
+				"queue_id": "abcdef",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := newTestParser(t, tc.configure)
+			parsed, err := parser.parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func TestParseFailure(t *testing.T) {
+	parser := newTestParser(t, func(cfg *Config) {
+		cfg.Patterns = []string{"^%{INT:code:int}$", "^%{WORD:word}$"}
+	})
+
+	_, err := parser.parse("not matching")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no grok pattern matches")
+
+	_, err = parser.parse([]byte("42"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type '[]uint8' cannot be parsed as grok")
+}
+
+func TestParseConversionFailure(t *testing.T) {
+	parser := newTestParser(t, func(cfg *Config) {
+		cfg.Patterns = []string{"^%{NUMBER:value:int}$"}
+	})
+
+	_, err := parser.parse("4.2")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "convert field value")
+}
+
+func TestProcess(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Patterns = []string{"%{IP:client} %{WORD:method} %{NUMBER:bytes:int}"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	e := entry.New()
+	e.Body = "55.3.244.1 GET 15824"
+	require.NoError(t, op.Process(context.Background(), e))
+
+	expected := entry.New()
+	expected.ObservedTimestamp = e.ObservedTimestamp
+	expected.Body = e.Body
+	expected.Attributes = map[string]interface{}{
+		"client": "55.3.244.1",
+		"method": "GET",
+		"bytes":  int64(15824),
+	}
+	fake.ExpectEntry(t, expected)
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xml
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestParserGoldenConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "parse_from_simple",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseFrom = entry.NewBodyField("from")
+					return cfg
+				}(),
+			},
+			{
+				Name: "parse_to_simple",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseTo = entry.NewBodyField("log")
+					return cfg
+				}(),
+			},
+			{
+				Name: "conventions",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.AttributePrefix = "-"
+					cfg.TextKey = "value"
+					return cfg
+				}(),
+			},
+			{
+				Name: "strip_namespaces",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.StripNamespaces = true
+					return cfg
+				}(),
+			},
+			{
+				Name: "namespaces",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Namespaces = map[string]string{
+						"http://schemas.xmlsoap.org/soap/envelope/": "soap",
+						"http://example.com/orders":                 "",
+					}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xmlNamespace is the namespace bound to the prefix xml by definition.
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+var (
+	errDTD          = errors.New("document type definitions are not allowed")
+	errNoRoot       = errors.New("no root element")
+	errMultipleRoot = errors.New("unexpected content after the root element")
+)
+
+// element is an element being decoded.
+type element struct {
+	key    string
+	fields map[string]interface{}
+	text   strings.Builder
+	// namespaces are the prefixes declared by the element, keyed by URI.
+	namespaces map[string]string
+}
+
+// value returns the text of the element if it has no attributes nor children, or the map of them otherwise.
+func (e *element) value(textKey string) interface{} {
+	text := strings.TrimSpace(e.text.String())
+	if len(e.fields) == 0 {
+		return text
+	}
+	if text != "" {
+		e.fields[textKey] = text
+	}
+	return e.fields
+}
+
+// add adds a child to the element, the children of the same name being grouped in a list.
+func (e *element) add(key string, value interface{}) {
+	existing, ok := e.fields[key]
+	if !ok {
+		e.fields[key] = value
+		return
+	}
+	if values, ok := existing.([]interface{}); ok {
+		e.fields[key] = append(values, value)
+		return
+	}
+	e.fields[key] = []interface{}{existing, value}
+}
+
+// decode converts an XML document into nested maps. The document type definitions are refused, so that
+// no entity besides the predefined ones can be expanded.
+func (x *Parser) decode(r io.Reader) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = true
+
+	var root map[string]interface{}
+	stack := []*element{{namespaces: map[string]string{xmlNamespace: "xml"}}}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode xml: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if root != nil {
+				return nil, errMultipleRoot
+			}
+			e := &element{fields: map[string]interface{}{}, namespaces: map[string]string{}}
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "xmlns":
+					e.namespaces[attr.Value] = attr.Name.Local
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					e.namespaces[attr.Value] = ""
+				}
+			}
+			stack = append(stack, e)
+			e.key = x.qualify(t.Name, stack)
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+				e.fields[x.attributePrefix+x.qualify(attr.Name, stack)] = attr.Value
+			}
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 1 {
+				root = map[string]interface{}{e.key: e.value(x.textKey)}
+			} else {
+				stack[len(stack)-1].add(e.key, e.value(x.textKey))
+			}
+		case xml.CharData:
+			if len(stack) > 1 {
+				stack[len(stack)-1].text.Write(t)
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return nil, errMultipleRoot
+			}
+		case xml.Directive:
+			if bytes.HasPrefix(bytes.TrimSpace(t), []byte("DOCTYPE")) {
+				return nil, errDTD
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errNoRoot
+	}
+	return root, nil
+}
+
+// qualify returns the key of an element or of an attribute, prefixed by its namespace prefix unless the namespaces
+// are stripped. The prefix is the one configured for the namespace if any, or the one declared in the document.
+func (x *Parser) qualify(name xml.Name, stack []*element) string {
+	if x.stripNamespaces || name.Space == "" {
+		return name.Local
+	}
+
+	prefix, ok := x.namespaces[name.Space]
+	for i := len(stack) - 1; !ok && i >= 0; i-- {
+		prefix, ok = stack[i].namespaces[name.Space]
+	}
+	if !ok {
+		// the prefix isn't declared, the decoder leaves it as is
+		prefix = name.Space
+	}
+
+	if prefix == "" {
+		return name.Local
+	}
+	return prefix + ":" + name.Local
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "xml_parser"
+
+	defaultAttributePrefix = "@"
+	defaultTextKey         = "#text"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new XML parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new XML parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:    helper.NewParserConfig(operatorID, operatorType),
+		AttributePrefix: defaultAttributePrefix,
+		TextKey:         defaultTextKey,
+	}
+}
+
+// Config is the configuration of an XML parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+
+	AttributePrefix string            `mapstructure:"attribute_prefix" json:"attribute_prefix" yaml:"attribute_prefix"`
+	TextKey         string            `mapstructure:"text_key"         json:"text_key"         yaml:"text_key"`
+	StripNamespaces bool              `mapstructure:"strip_namespaces" json:"strip_namespaces" yaml:"strip_namespaces"`
+	Namespaces      map[string]string `mapstructure:"namespaces"       json:"namespaces"       yaml:"namespaces"`
+}
+
+// Build will build an XML parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.TextKey == "" {
+		return nil, fmt.Errorf("missing required field 'text_key'")
+	}
+
+	for uri, prefix := range c.Namespaces {
+		if strings.Contains(prefix, ":") {
+			return nil, fmt.Errorf("invalid prefix '%s' of namespace %s, it can't contain ':'", prefix, uri)
+		}
+	}
+
+	return &Parser{
+		ParserOperator:  parserOperator,
+		attributePrefix: c.AttributePrefix,
+		textKey:         c.TextKey,
+		stripNamespaces: c.StripNamespaces,
+		namespaces:      c.Namespaces,
+	}, nil
+}
+
+// Parser is an operator that parses XML.
+type Parser struct {
+	helper.ParserOperator
+	attributePrefix string
+	textKey         string
+	stripNamespaces bool
+	// namespaces are the prefixes used for the namespaces, keyed by URI, overriding the ones of the documents.
+	namespaces map[string]string
+}
+
+// Process will parse an entry for XML.
+func (x *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return x.ParserOperator.ProcessWith(ctx, entry, x.parse)
+}
+
+// parse will parse a value as XML.
+func (x *Parser) parse(value interface{}) (interface{}, error) {
+	switch m := value.(type) {
+	case string:
+		return x.decode(strings.NewReader(m))
+	case []byte:
+		return x.decode(bytes.NewReader(m))
+	default:
+		return nil, fmt.Errorf("type '%T' cannot be parsed as XML", value)
+	}
+}
@@ -0,0 +1,365 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xml
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const soapEnvelope = `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ord="http://example.com/orders">
+  <soap:Header/>
+  <soap:Body>
+    <ord:CreateOrder ord:priority="high">
+      <ord:Id>42</ord:Id>
+    </ord:CreateOrder>
+  </soap:Body>
+</soap:Envelope>`
+
+func newTestParser(t *testing.T, configure func(*Config)) *Parser {
+	cfg := NewConfigWithID("test")
+	configure(cfg)
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestConfigBuild(t *testing.T) {
+	op, err := NewConfigWithID("test").Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		expected  string
+	}{
+		{
+			name: "missing_text_key",
+			configure: func(cfg *Config) {
+				cfg.TextKey = ""
+			},
+			expected: "missing required field 'text_key'",
+		},
+		{
+			name: "invalid_prefix",
+			configure: func(cfg *Config) {
+				cfg.Namespaces = map[string]string{"http://example.com/orders": "a:b"}
+			},
+			expected: "invalid prefix 'a:b'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     interface{}
+		expected  map[string]interface{}
+	}{
+		{
+			name:  "text",
+			input: "<message>hello</message>",
+			expected: map[string]interface{}{
+				"message": "hello",
+			},
+		},
+		{
+			name:  "bytes",
+			input: []byte("<message>hello</message>"),
+			expected: map[string]interface{}{
+				"message": "hello",
+			},
+		},
+		{
+			name:  "empty",
+			input: "<message/>",
+			expected: map[string]interface{}{
+				"message": "",
+			},
+		},
+		{
+			name: "nested",
+			input: `<event>
+  <level>ERROR</level>
+  <source><host>web-1</host><port>8080</port></source>
+</event>`,
+			expected: map[string]interface{}{
+				"event": map[string]interface{}{
+					"level": "ERROR",
+					"source": map[string]interface{}{
+						"host": "web-1",
+						"port": "8080",
+					},
+				},
+			},
+		},
+		{
+			name:  "attributes_and_text",
+			input: `<message level="error" code="500">connection refused</message>`,
+			expected: map[string]interface{}{
+				"message": map[string]interface{}{
+					"@level": "error",
+					"@code":  "500",
+					"#text":  "connection refused",
+				},
+			},
+		},
+		{
+			name:  "repeated_children",
+			input: `<items><item>a</item><other>b</other><item>c</item><item id="4"/></items>`,
+			expected: map[string]interface{}{
+				"items": map[string]interface{}{
+					"item": []interface{}{
+						"a",
+						"c",
+						map[string]interface{}{"@id": "4"},
+					},
+					"other": "b",
+				},
+			},
+		},
+		{
+			name:  "cdata_and_comments",
+			input: `<!-- request --><query><![CDATA[SELECT * FROM t WHERE a < 1]]><!-- inline --></query>`,
+			expected: map[string]interface{}{
+				"query": "SELECT * FROM t WHERE a < 1",
+			},
+		},
+		{
+			name:  "predefined_entities",
+			input: `<message>a &lt; b &amp;&amp; c &#62; d</message>`,
+			expected: map[string]interface{}{
+				"message": "a < b && c > d",
+			},
+		},
+		{
+			name: "conventions",
+			configure: func(cfg *Config) {
+				cfg.AttributePrefix = ""
+				cfg.TextKey = "value"
+			},
+			input: `<message level="error">connection refused</message>`,
+			expected: map[string]interface{}{
+				"message": map[string]interface{}{
+					"level": "error",
+					"value": "connection refused",
+				},
+			},
+		},
+		{
+			name:  "namespaces",
+			input: soapEnvelope,
+			expected: map[string]interface{}{
+				"soap:Envelope": map[string]interface{}{
+					"soap:Header": "",
+					"soap:Body": map[string]interface{}{
+						"ord:CreateOrder": map[string]interface{}{
+							"@ord:priority": "high",
+							"ord:Id":        "42",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "configured_namespaces",
+			configure: func(cfg *Config) {
+				cfg.Namespaces = map[string]string{
+					"http://schemas.xmlsoap.org/soap/envelope/": "env",
+					"http://example.com/orders":                 "",
+				}
+			},
+			input: soapEnvelope,
+			expected: map[string]interface{}{
+				"env:Envelope": map[string]interface{}{
+					"env:Header": "",
+					"env:Body": map[string]interface{}{
+						"CreateOrder": map[string]interface{}{
+							"@priority": "high",
+							"Id":        "42",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "strip_namespaces",
+			configure: func(cfg *Config) {
+				cfg.StripNamespaces = true
+			},
+			input: soapEnvelope,
+			expected: map[string]interface{}{
+				"Envelope": map[string]interface{}{
+					"Header": "",
+					"Body": map[string]interface{}{
+						"CreateOrder": map[string]interface{}{
+							"@priority": "high",
+							"Id":        "42",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "default_namespace",
+			input: `<order xmlns="http://example.com/orders" xml:lang="en"><id>42</id></order>`,
+			expected: map[string]interface{}{
+				"order": map[string]interface{}{
+					"@xml:lang": "en",
+					"id":        "42",
+				},
+			},
+		},
+		{
+			name:  "redeclared_prefix",
+			input: `<a:root xmlns:a="urn:one"><a:child xmlns:a="urn:two"/><b:child xmlns:b="urn:one"/></a:root>`,
+			expected: map[string]interface{}{
+				"a:root": map[string]interface{}{
+					"a:child": "",
+					"b:child": "",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := newTestParser(t, func(cfg *Config) {
+				if tc.configure != nil {
+					tc.configure(cfg)
+				}
+			})
+			parsed, err := parser.parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func TestParseFailure(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "invalid_type",
+			input:    map[string]interface{}{},
+			expected: "type 'map[string]interface {}' cannot be parsed as XML",
+		},
+		{
+			name:     "empty",
+			input:    "  ",
+			expected: "no root element",
+		},
+		{
+			name:     "malformed",
+			input:    "<message>hello</msg>",
+			expected: "decode xml",
+		},
+		{
+			name:     "unclosed",
+			input:    "<message>hello",
+			expected: "decode xml",
+		},
+		{
+			name:     "multiple_roots",
+			input:    "<a/><b/>",
+			expected: "unexpected content after the root element",
+		},
+		{
+			name:     "trailing_text",
+			input:    "<a/>text",
+			expected: "unexpected content after the root element",
+		},
+		{
+			name: "entity_expansion",
+			input: `<?xml version="1.0"?>
+<!DOCTYPE lolz [
+  <!ENTITY lol "lol">
+  <!ENTITY lol2 "&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;">
+]>
+<lolz>&lol2;</lolz>`,
+			expected: "document type definitions are not allowed",
+		},
+		{
+			name: "external_entity",
+			input: `<?xml version="1.0"?>
+<!DOCTYPE foo [<!ENTITY xxe SYSTEM "file:///etc/passwd">]>
+<foo>&xxe;</foo>`,
+			expected: "document type definitions are not allowed",
+		},
+		{
+			name:     "undefined_entity",
+			input:    "<foo>&xxe;</foo>",
+			expected: "decode xml",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := newTestParser(t, func(cfg *Config) {})
+			_, err := parser.parse(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	e := entry.New()
+	e.Body = `<message level="error">connection refused</message>`
+	require.NoError(t, op.Process(context.Background(), e))
+
+	expected := entry.New()
+	expected.ObservedTimestamp = e.ObservedTimestamp
+	expected.Body = e.Body
+	expected.Attributes = map[string]interface{}{
+		"message": map[string]interface{}{
+			"@level": "error",
+			"#text":  "connection refused",
+		},
+	}
+	fake.ExpectEntry(t, expected)
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottlspanevents"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/functions/ottlotel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
+)
+
+// functions are the OTTL functions available in the conditions.
+var functions = ottlotel.ReadOnlyFunctions()
+
+func parseSpanConditions(conditions []string) ([]ottl.Condition, error) {
+	parser := ottl.NewParser(functions, ottltraces.ParsePath, ottltraces.ParseEnum, ottl.NoOpLogger{})
+	return parser.ParseConditions(conditions)
+}
+
+func parseSpanEventConditions(conditions []string) ([]ottl.Condition, error) {
+	parser := ottl.NewParser(functions, ottlspanevents.ParsePath, ottlspanevents.ParseEnum, ottl.NoOpLogger{})
+	return parser.ParseConditions(conditions)
+}
+
+func parseDataPointConditions(conditions []string) ([]ottl.Condition, error) {
+	parser := ottl.NewParser(functions, ottlmetrics.ParsePath, ottlmetrics.ParseEnum, ottl.NoOpLogger{})
+	return parser.ParseConditions(conditions)
+}
+
+func parseLogConditions(conditions []string) ([]ottl.Condition, error) {
+	parser := ottl.NewParser(functions, ottllogs.ParsePath, ottllogs.ParseEnum, ottl.NoOpLogger{})
+	return parser.ParseConditions(conditions)
+}
+
+// matchesAny returns whether any of the conditions is true for the given context.
+func matchesAny(conditions []ottl.Condition, ctx ottl.TransformContext) bool {
+	for _, condition := range conditions {
+		if condition(ctx) {
+			return true
+		}
+	}
+	return false
+}
@@ -38,8 +38,18 @@ type Config struct {
 	Logs LogFilters `mapstructure:"logs"`
 
 	Spans SpanFilters `mapstructure:"spans"`
+
+	Traces TraceFilters `mapstructure:"traces"`
 }
 
+// Conditions is a list of OTTL conditions, each one a boolean expression evaluated against the
+// telemetry of an OTTL context. A condition compares Values with `==`, `!=`, `<`, `<=`, `>`, `>=`
+// and `in`, the right side of `in` being a list literal such as `["a", "b"]` or a slice attribute.
+// Conditions can be combined with `and` and `or`, negated with `not` and grouped with `()`, for
+// example `not (attributes["env"] in ["prod", "staging"] or IsMatch(name, "health.*"))`.
+// Only the functions that don't modify the telemetry are available.
+type Conditions []string
+
 // MetricFilters filters by Metric properties.
 type MetricFilters struct {
 	// Include match properties describe metrics that should be included in the Collector Service pipeline,
@@ -54,6 +64,12 @@ type MetricFilters struct {
 
 	// RegexpConfig specifies options for the Regexp match type
 	RegexpConfig *regexp.Config `mapstructure:"regexp"`
+
+	// DataPointConditions is a list of Conditions for an ottlmetrics context.
+	// If any condition resolves to true, the data point will be dropped, and metrics
+	// left without data points are dropped too.
+	// Can't be used together with Include or Exclude.
+	DataPointConditions Conditions `mapstructure:"datapoint"`
 }
 
 // SpanFilters filters by Span attributes and various other fields, Regexp config is per matcher
@@ -69,6 +85,18 @@ type SpanFilters struct {
 	Exclude *filterconfig.MatchProperties `mapstructure:"exclude"`
 }
 
+// TraceFilters filters by OTTL conditions.
+// Can't be used together with the Include or Exclude span filters.
+type TraceFilters struct {
+	// SpanConditions is a list of Conditions for an ottltraces context.
+	// If any condition resolves to true, the span will be dropped.
+	SpanConditions Conditions `mapstructure:"span"`
+
+	// SpanEventConditions is a list of Conditions for an ottlspanevents context.
+	// If any condition resolves to true, the span event will be dropped.
+	SpanEventConditions Conditions `mapstructure:"spanevent"`
+}
+
 // LogFilters filters by Log properties.
 type LogFilters struct {
 	// Include match properties describe logs that should be included in the Collector Service pipeline,
@@ -79,6 +107,11 @@ type LogFilters struct {
 	// all other logs should be included.
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *LogMatchProperties `mapstructure:"exclude"`
+
+	// LogConditions is a list of Conditions for an ottllogs context.
+	// If any condition resolves to true, the log record will be dropped.
+	// Can't be used together with Include or Exclude.
+	LogConditions Conditions `mapstructure:"log_record"`
 }
 
 // LogMatchType specifies the strategy for matching against `plog.Log`s.
@@ -142,7 +175,13 @@ var severityToNumber = map[string]plog.SeverityNumber{
 	"FATAL4": plog.SeverityNumberFatal4,
 }
 
-var errInvalidSeverity = errors.New("not a valid severity")
+var (
+	errInvalidSeverity = errors.New("not a valid severity")
+
+	errMixedTraceFilters  = errors.New("cannot use ottl conditions and the include/exclude span filters at the same time")
+	errMixedMetricFilters = errors.New("cannot use ottl conditions and the include/exclude metric filters at the same time")
+	errMixedLogFilters    = errors.New("cannot use ottl conditions and the include/exclude log filters at the same time")
+)
 
 // logSeverity is a type that represents a SeverityNumber as a string
 type logSeverity string
@@ -263,5 +302,35 @@ func (cfg *Config) Validate() error {
 		err = multierr.Append(err, cfg.Logs.Exclude.validate())
 	}
 
+	if cfg.Traces.hasConditions() {
+		if cfg.Spans.Include != nil || cfg.Spans.Exclude != nil {
+			err = multierr.Append(err, errMixedTraceFilters)
+		}
+		_, spanErr := parseSpanConditions(cfg.Traces.SpanConditions)
+		_, eventErr := parseSpanEventConditions(cfg.Traces.SpanEventConditions)
+		err = multierr.Append(err, multierr.Append(spanErr, eventErr))
+	}
+
+	if len(cfg.Metrics.DataPointConditions) > 0 {
+		if cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil {
+			err = multierr.Append(err, errMixedMetricFilters)
+		}
+		_, dataPointErr := parseDataPointConditions(cfg.Metrics.DataPointConditions)
+		err = multierr.Append(err, dataPointErr)
+	}
+
+	if len(cfg.Logs.LogConditions) > 0 {
+		if cfg.Logs.Include != nil || cfg.Logs.Exclude != nil {
+			err = multierr.Append(err, errMixedLogFilters)
+		}
+		_, logErr := parseLogConditions(cfg.Logs.LogConditions)
+		err = multierr.Append(err, logErr)
+	}
+
 	return err
 }
+
+// hasConditions returns whether any OTTL condition is configured for traces.
+func (tf TraceFilters) hasConditions() bool {
+	return len(tf.SpanConditions) > 0 || len(tf.SpanEventConditions) > 0
+}
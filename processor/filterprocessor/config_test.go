@@ -643,6 +643,69 @@ func TestLoadingSpans(t *testing.T) {
 	}
 }
 
+func TestLoadingConfigOTTL(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_ottl.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           config.ComponentID
+		expected     config.Processor
+		errorMessage string
+	}{
+		{
+			id: config.NewComponentIDWithName("filter", "ottl"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Traces: TraceFilters{
+					SpanConditions: []string{
+						`attributes["http.target"] == "/health" and IsMatch(resource.attributes["service.name"], "pinger-.*")`,
+						`kind == SPAN_KIND_INTERNAL`,
+					},
+					SpanEventConditions: []string{
+						`name == "debug"`,
+					},
+				},
+				Metrics: MetricFilters{
+					DataPointConditions: []string{
+						`metric.name == "http.server.requests" and attributes["http.route"] == "/health"`,
+					},
+				},
+				Logs: LogFilters{
+					LogConditions: []string{
+						`severity_number < SEVERITY_NUMBER_WARN`,
+					},
+				},
+			},
+		},
+		{
+			id:           config.NewComponentIDWithName("filter", "ottl_mixed"),
+			errorMessage: errMixedTraceFilters.Error(),
+		},
+		{
+			id:           config.NewComponentIDWithName("filter", "ottl_invalid"),
+			errorMessage: "unrecognized field unknown_field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+			if tt.expected == nil {
+				assert.ErrorContains(t, cfg.Validate(), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
 func TestLoadingConfigExpr(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_expr.yaml"))
 	require.NoError(t, err)
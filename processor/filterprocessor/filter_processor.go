@@ -26,6 +26,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
 )
 
 type filterMetricProcessor struct {
@@ -37,9 +39,28 @@ type filterMetricProcessor struct {
 	logger           *zap.Logger
 	checksMetrics    bool
 	checksResouces   bool
+
+	dataPointConditions []ottl.Condition
 }
 
 func newFilterMetricProcessor(logger *zap.Logger, cfg *Config) (*filterMetricProcessor, error) {
+	if len(cfg.Metrics.DataPointConditions) > 0 {
+		dataPointConditions, err := parseDataPointConditions(cfg.Metrics.DataPointConditions)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Info(
+			"Metric filter configured",
+			zap.Strings("data point conditions", cfg.Metrics.DataPointConditions),
+		)
+
+		return &filterMetricProcessor{
+			cfg:                 cfg,
+			logger:              logger,
+			dataPointConditions: dataPointConditions,
+		}, nil
+	}
 
 	inc, includeAttr, err := createMatcher(cfg.Metrics.Include)
 	if err != nil {
@@ -125,6 +146,19 @@ func createMatcher(mp *filtermetric.MatchProperties) (filtermetric.Matcher, filt
 
 // processMetrics filters the given metrics based off the filterMetricProcessor's filters.
 func (fmp *filterMetricProcessor) processMetrics(_ context.Context, pdm pmetric.Metrics) (pmetric.Metrics, error) {
+	if len(fmp.dataPointConditions) > 0 {
+		fmp.removeDataPoints(pdm)
+	} else {
+		fmp.removeMetrics(pdm)
+	}
+	if pdm.ResourceMetrics().Len() == 0 {
+		return pdm, processorhelper.ErrSkipProcessingData
+	}
+	return pdm, nil
+}
+
+// removeMetrics drops the metrics that don't pass the include/exclude filters.
+func (fmp *filterMetricProcessor) removeMetrics(pdm pmetric.Metrics) {
 	pdm.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
 		keepMetricsForResource := fmp.shouldKeepMetricsForResource(rm.Resource())
 		if !keepMetricsForResource {
@@ -150,10 +184,59 @@ func (fmp *filterMetricProcessor) processMetrics(_ context.Context, pdm pmetric.
 		// Filter out empty ResourceMetrics
 		return rm.ScopeMetrics().Len() == 0
 	})
-	if pdm.ResourceMetrics().Len() == 0 {
-		return pdm, processorhelper.ErrSkipProcessingData
+}
+
+// removeDataPoints drops the data points matching any of the data point conditions. Metrics
+// that are left without data points are dropped as well.
+func (fmp *filterMetricProcessor) removeDataPoints(pdm pmetric.Metrics) {
+	pdm.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return fmp.removeMetricDataPoints(m, sm, rm.Resource())
+			})
+			// Filter out empty ScopeMetrics
+			return sm.Metrics().Len() == 0
+		})
+		// Filter out empty ResourceMetrics
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// removeMetricDataPoints drops the data points of the given metric matching any of the data
+// point conditions, and returns whether the metric was left without data points because of it.
+func (fmp *filterMetricProcessor) removeMetricDataPoints(m pmetric.Metric, sm pmetric.ScopeMetrics, resource pcommon.Resource) bool {
+	matches := func(dp interface{}) bool {
+		return matchesAny(fmp.dataPointConditions, ottlmetrics.NewTransformContext(dp, m, sm.Metrics(), sm.Scope(), resource))
 	}
-	return pdm, nil
+
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		before := dps.Len()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return matches(dp) })
+		return before > 0 && dps.Len() == 0
+	case pmetric.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		before := dps.Len()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return matches(dp) })
+		return before > 0 && dps.Len() == 0
+	case pmetric.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		before := dps.Len()
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return matches(dp) })
+		return before > 0 && dps.Len() == 0
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		before := dps.Len()
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return matches(dp) })
+		return before > 0 && dps.Len() == 0
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		before := dps.Len()
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return matches(dp) })
+		return before > 0 && dps.Len() == 0
+	}
+	return false
 }
 
 func (fmp *filterMetricProcessor) shouldKeepMetric(metric pmetric.Metric) (bool, error) {
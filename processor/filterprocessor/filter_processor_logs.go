@@ -23,6 +23,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterlog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
 )
 
 type filterLogProcessor struct {
@@ -30,9 +32,23 @@ type filterLogProcessor struct {
 	excludeMatcher filterlog.Matcher
 	includeMatcher filterlog.Matcher
 	logger         *zap.Logger
+
+	logConditions []ottl.Condition
 }
 
 func newFilterLogsProcessor(logger *zap.Logger, cfg *Config) (*filterLogProcessor, error) {
+	if len(cfg.Logs.LogConditions) > 0 {
+		logConditions, err := parseLogConditions(cfg.Logs.LogConditions)
+		if err != nil {
+			return nil, err
+		}
+		return &filterLogProcessor{
+			cfg:           cfg,
+			logger:        logger,
+			logConditions: logConditions,
+		}, nil
+	}
+
 	var includeMatcher filterlog.Matcher
 	var excludeMatcher filterlog.Matcher
 
@@ -97,6 +113,13 @@ func (flp *filterLogProcessor) filterLogRecords(rLogs plog.ResourceLogsSlice) {
 					return flp.excludeMatcher.MatchLogRecord(lr, resource, instrumentationScope)
 				})
 			}
+
+			if len(flp.logConditions) > 0 {
+				// If conditions exist, remove all records that match any of them.
+				lrs.RemoveIf(func(lr plog.LogRecord) bool {
+					return matchesAny(flp.logConditions, ottllogs.NewTransformContext(lr, instrumentationScope, resource))
+				})
+			}
 		}
 
 		scopes.RemoveIf(func(sl plog.ScopeLogs) bool {
@@ -623,6 +623,77 @@ func TestFilterLogProcessor(t *testing.T) {
 	}
 }
 
+func TestFilterLogProcessorWithOTTL(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		outLN      [][]string
+	}{
+		{
+			name:       "drop by severity",
+			conditions: []string{`severity_number < SEVERITY_NUMBER_WARN`},
+			outLN:      [][]string{{"log2"}, {"log3", "log4"}},
+		},
+		{
+			name:       "drop by resource and attribute",
+			conditions: []string{`resource.attributes["service.name"] == "api" and attributes["name"] == "log3"`, `body == "debug message"`},
+			outLN:      [][]string{{"log2"}, {"log4"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			next := new(consumertest.LogsSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Logs: LogFilters{
+					LogConditions: tt.conditions,
+				},
+			}
+			require.NoError(t, cfg.Validate())
+
+			flp, err := NewFactory().CreateLogsProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, next)
+			require.NoError(t, err)
+			require.NoError(t, flp.Start(ctx, nil))
+			require.NoError(t, flp.ConsumeLogs(ctx, testResourceLogs([]logWithResource{
+				{
+					logNames:           []string{"log1"},
+					resourceAttributes: map[string]interface{}{"service.name": "web"},
+					body:               "debug message",
+					severityNumber:     plog.SeverityNumberDebug,
+				},
+				{
+					logNames:           []string{"log2"},
+					resourceAttributes: map[string]interface{}{"service.name": "web"},
+					body:               "something failed",
+					severityNumber:     plog.SeverityNumberError,
+				},
+				{
+					logNames:           []string{"log3", "log4"},
+					resourceAttributes: map[string]interface{}{"service.name": "api"},
+					body:               "something looks wrong",
+					severityNumber:     plog.SeverityNumberWarn,
+				},
+			})))
+			require.NoError(t, flp.Shutdown(ctx))
+
+			require.Len(t, next.AllLogs(), 1)
+			rLogs := next.AllLogs()[0].ResourceLogs()
+			require.Equal(t, len(tt.outLN), rLogs.Len())
+			for i, wantOut := range tt.outLN {
+				gotLogs := rLogs.At(i).ScopeLogs().At(0).LogRecords()
+				require.Equal(t, len(wantOut), gotLogs.Len())
+				for idx := range wantOut {
+					val, ok := gotLogs.At(idx).Attributes().Get("name")
+					require.True(t, ok)
+					assert.Equal(t, wantOut[idx], val.AsString())
+				}
+			}
+		})
+	}
+}
+
 func testResourceLogs(lwrs []logWithResource) plog.Logs {
 	ld := plog.NewLogs()
 
@@ -379,6 +379,98 @@ func TestFilterMetricProcessor(t *testing.T) {
 	}
 }
 
+func TestFilterMetricProcessorWithOTTL(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		want       map[string]int // number of data points left per metric
+	}{
+		{
+			name:       "drop data points by attribute",
+			conditions: []string{`metric.name == "http.server.requests" and attributes["http.route"] == "/health"`},
+			want:       map[string]int{"http.server.requests": 1, "http.server.duration": 2, "process.cpu": 1},
+		},
+		{
+			name:       "drop metrics left without data points",
+			conditions: []string{`metric.name == "http.server.duration"`, `resource.attributes["host.name"] == "localhost" and metric.type == METRIC_DATA_TYPE_SUM`},
+			want:       map[string]int{"http.server.requests": 2},
+		},
+		{
+			name:       "drop histogram data points by count",
+			conditions: []string{`count < 10`},
+			want:       map[string]int{"http.server.requests": 2, "http.server.duration": 1, "process.cpu": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			next := new(consumertest.MetricsSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Metrics: MetricFilters{
+					DataPointConditions: tt.conditions,
+				},
+			}
+			require.NoError(t, cfg.Validate())
+
+			fp, err := NewFactory().CreateMetricsProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, next)
+			require.NoError(t, err)
+			require.NoError(t, fp.Start(ctx, nil))
+			require.NoError(t, fp.ConsumeMetrics(ctx, generateOTTLMetrics()))
+			require.NoError(t, fp.Shutdown(ctx))
+
+			require.Len(t, next.AllMetrics(), 1)
+			got := map[string]int{}
+			rms := next.AllMetrics()[0].ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+				for j := 0; j < ms.Len(); j++ {
+					m := ms.At(j)
+					switch m.DataType() {
+					case pmetric.MetricDataTypeSum:
+						got[m.Name()] += m.Sum().DataPoints().Len()
+					case pmetric.MetricDataTypeHistogram:
+						got[m.Name()] += m.Histogram().DataPoints().Len()
+					}
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// generateOTTLMetrics returns a sum with a data point per route, a histogram with two data
+// points, and a sum on a separate resource.
+func generateOTTLMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	requests := ms.AppendEmpty()
+	requests.SetName("http.server.requests")
+	requestsDps := requests.SetEmptySum().DataPoints()
+	for _, route := range []string{"/health", "/users"} {
+		dp := requestsDps.AppendEmpty()
+		dp.Attributes().PutString("http.route", route)
+		dp.SetIntVal(5)
+	}
+
+	duration := ms.AppendEmpty()
+	duration.SetName("http.server.duration")
+	durationDps := duration.SetEmptyHistogram().DataPoints()
+	for _, count := range []uint64{5, 20} {
+		durationDps.AppendEmpty().SetCount(count)
+	}
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutString("host.name", "localhost")
+	cpu := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	cpu.SetName("process.cpu")
+	cpu.SetEmptySum().DataPoints().AppendEmpty().SetDoubleVal(0.5)
+
+	return md
+}
+
 func testResourceMetrics(mwrs []metricWithResource) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
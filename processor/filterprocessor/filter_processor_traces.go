@@ -23,6 +23,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottlspanevents"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
 )
 
 type filterSpanProcessor struct {
@@ -30,9 +33,16 @@ type filterSpanProcessor struct {
 	include filterspan.Matcher
 	exclude filterspan.Matcher
 	logger  *zap.Logger
+
+	spanConditions      []ottl.Condition
+	spanEventConditions []ottl.Condition
 }
 
 func newFilterSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	if cfg.Traces.hasConditions() {
+		return newFilterSpansProcessorWithConditions(logger, cfg)
+	}
+
 	if cfg.Spans.Include == nil && cfg.Spans.Exclude == nil {
 		return nil, nil
 	}
@@ -66,6 +76,31 @@ func newFilterSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProces
 	}, nil
 }
 
+func newFilterSpansProcessorWithConditions(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	spanConditions, err := parseSpanConditions(cfg.Traces.SpanConditions)
+	if err != nil {
+		return nil, err
+	}
+	spanEventConditions, err := parseSpanEventConditions(cfg.Traces.SpanEventConditions)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(
+		"Span filter configured",
+		zap.String("ID", cfg.ID().String()),
+		zap.Strings("span conditions", cfg.Traces.SpanConditions),
+		zap.Strings("span event conditions", cfg.Traces.SpanEventConditions),
+	)
+
+	return &filterSpanProcessor{
+		cfg:                 cfg,
+		logger:              logger,
+		spanConditions:      spanConditions,
+		spanEventConditions: spanEventConditions,
+	}, nil
+}
+
 func createSpanMatcher(cfg *Config) (filterspan.Matcher, filterspan.Matcher, error) {
 	var includeMatcher filterspan.Matcher
 	var excludeMatcher filterspan.Matcher
@@ -94,6 +129,9 @@ func (fsp *filterSpanProcessor) processTraces(_ context.Context, pdt ptrace.Trac
 			ils.Spans().RemoveIf(func(span ptrace.Span) bool {
 				return fsp.shouldRemoveSpan(span, resSpan.Resource(), ils.Scope())
 			})
+			if len(fsp.spanEventConditions) > 0 {
+				fsp.removeSpanEvents(ils.Spans(), resSpan.Resource(), ils.Scope())
+			}
 		}
 		// Remove empty elements, that way if we delete everything we can tell
 		// the pipeline to stop processing completely (ErrSkipProcessingData)
@@ -111,6 +149,10 @@ func (fsp *filterSpanProcessor) processTraces(_ context.Context, pdt ptrace.Trac
 }
 
 func (fsp *filterSpanProcessor) shouldRemoveSpan(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope) bool {
+	if len(fsp.spanConditions) > 0 {
+		return matchesAny(fsp.spanConditions, ottltraces.NewTransformContext(span, library, resource))
+	}
+
 	if fsp.include != nil {
 		if !fsp.include.MatchSpan(span, resource, library) {
 			return true
@@ -125,3 +167,13 @@ func (fsp *filterSpanProcessor) shouldRemoveSpan(span ptrace.Span, resource pcom
 
 	return false
 }
+
+// removeSpanEvents drops the events matching any of the span event conditions from the given spans.
+func (fsp *filterSpanProcessor) removeSpanEvents(spans ptrace.SpanSlice, resource pcommon.Resource, library pcommon.InstrumentationScope) {
+	for i := 0; i < spans.Len(); i++ {
+		span := spans.At(i)
+		span.Events().RemoveIf(func(event ptrace.SpanEvent) bool {
+			return matchesAny(fsp.spanEventConditions, ottlspanevents.NewTransformContext(event, span, library, resource))
+		})
+	}
+}
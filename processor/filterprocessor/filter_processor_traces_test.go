@@ -179,3 +179,101 @@ func generateTraces(traces []testTrace) ptrace.Traces {
 	}
 	return td
 }
+
+func TestFilterTraceProcessorWithOTTL(t *testing.T) {
+	tests := []struct {
+		name                string
+		spanConditions      []string
+		spanEventConditions []string
+		wantSpans           []string
+		wantEvents          int
+	}{
+		{
+			name:           "drop spans by attribute and resource",
+			spanConditions: []string{`attributes["http.target"] == "/health" and IsMatch(resource.attributes["service.name"], "pinger-.*")`},
+			wantSpans:      []string{"health", "operation"},
+			wantEvents:     2,
+		},
+		{
+			name:           "any condition drops the span",
+			spanConditions: []string{`name == "health" and resource.attributes["service.name"] == "api"`, `kind == SPAN_KIND_INTERNAL`},
+			wantSpans:      []string{"health"},
+			wantEvents:     1,
+		},
+		{
+			name:                "drop span events",
+			spanEventConditions: []string{`name == "debug" and span.name != "operation"`},
+			wantSpans:           []string{"health", "health", "operation"},
+			wantEvents:          1,
+		},
+		{
+			name:           "drop all spans",
+			spanConditions: []string{`true`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			next := new(consumertest.TracesSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Traces: TraceFilters{
+					SpanConditions:      tt.spanConditions,
+					SpanEventConditions: tt.spanEventConditions,
+				},
+			}
+			require.NoError(t, cfg.Validate())
+
+			fp, err := NewFactory().CreateTracesProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, next)
+			require.NoError(t, err)
+			require.NoError(t, fp.Start(ctx, nil))
+			require.NoError(t, fp.ConsumeTraces(ctx, generateOTTLTraces()))
+			require.NoError(t, fp.Shutdown(ctx))
+
+			if len(tt.wantSpans) == 0 {
+				require.Empty(t, next.AllTraces())
+				return
+			}
+			require.Len(t, next.AllTraces(), 1)
+
+			var gotSpans []string
+			gotEvents := 0
+			rss := next.AllTraces()[0].ResourceSpans()
+			for i := 0; i < rss.Len(); i++ {
+				spans := rss.At(i).ScopeSpans().At(0).Spans()
+				for j := 0; j < spans.Len(); j++ {
+					gotSpans = append(gotSpans, spans.At(j).Name())
+					gotEvents += spans.At(j).Events().Len()
+				}
+			}
+			require.Equal(t, tt.wantSpans, gotSpans)
+			require.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+// generateOTTLTraces returns a health check span for the "pinger-1" and "api" services, and
+// an internal span for the "api" service. Each span has a "debug" event.
+func generateOTTLTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, service := range []string{"pinger-1", "api"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutString("service.name", service)
+		ss := rs.ScopeSpans().AppendEmpty()
+
+		span := ss.Spans().AppendEmpty()
+		span.SetName("health")
+		span.SetKind(ptrace.SpanKindServer)
+		span.Attributes().PutString("http.target", "/health")
+		span.Events().AppendEmpty().SetName("debug")
+
+		if service == "api" {
+			span = ss.Spans().AppendEmpty()
+			span.SetName("operation")
+			span.SetKind(ptrace.SpanKindInternal)
+			span.Events().AppendEmpty().SetName("debug")
+		}
+	}
+	return td
+}
@@ -0,0 +1,380 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbyattrsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor"
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// metricsAggregator merges the data points of a metric that have identical attributes.
+type metricsAggregator struct {
+	dropKeys []string
+	gauge    string
+}
+
+func newMetricsAggregator(cfg *AggregationConfig) *metricsAggregator {
+	gauge := cfg.Gauge
+	if gauge == "" {
+		gauge = aggregateSum
+	}
+	return &metricsAggregator{dropKeys: cfg.DropKeys, gauge: gauge}
+}
+
+// aggregate merges the data points with identical attributes of each metric in the specified ResourceMetrics.
+// Summary data points are left untouched, as their quantiles can't be merged.
+func (ma *metricsAggregator) aggregate(ctx context.Context, rms pmetric.ResourceMetricsSlice) {
+	merged := 0
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+
+				switch metric.DataType() {
+
+				case pmetric.MetricDataTypeGauge:
+					merged += ma.aggregateNumberDataPoints(metric.Gauge().DataPoints(), ma.gauge, false)
+
+				case pmetric.MetricDataTypeSum:
+					sum := metric.Sum()
+					merged += ma.aggregateNumberDataPoints(sum.DataPoints(), aggregateSum, isCumulative(sum.AggregationTemporality()))
+
+				case pmetric.MetricDataTypeHistogram:
+					histogram := metric.Histogram()
+					merged += ma.aggregateHistogramDataPoints(histogram.DataPoints(), isCumulative(histogram.AggregationTemporality()))
+
+				case pmetric.MetricDataTypeExponentialHistogram:
+					histogram := metric.ExponentialHistogram()
+					merged += ma.aggregateExponentialHistogramDataPoints(histogram.DataPoints(), isCumulative(histogram.AggregationTemporality()))
+
+				}
+			}
+		}
+	}
+	stats.Record(ctx, mNumAggregatedDataPoints.M(int64(merged)))
+}
+
+// aggregateNumberDataPoints merges the data points with identical attributes and times using the specified function.
+// Returns the number of data points that were merged into another one.
+func (ma *metricsAggregator) aggregateNumberDataPoints(dps pmetric.NumberDataPointSlice, function string, cumulative bool) int {
+	merged := 0
+	aggregated := make(map[string]pmetric.NumberDataPoint, dps.Len())
+	dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+		deleteKeys(ma.dropKeys, dp.Attributes())
+		if dp.Flags().NoRecordedValue() {
+			return false
+		}
+
+		key := attributesKey(dp.Attributes()) + timesKey(dp, cumulative)
+		target, found := aggregated[key]
+		if !found {
+			aggregated[key] = dp
+			return false
+		}
+
+		mergeNumberDataPoint(target, dp, function)
+		merged++
+		return true
+	})
+	return merged
+}
+
+// aggregateHistogramDataPoints merges the data points with identical attributes, times and explicit bounds.
+// Returns the number of data points that were merged into another one.
+func (ma *metricsAggregator) aggregateHistogramDataPoints(dps pmetric.HistogramDataPointSlice, cumulative bool) int {
+	merged := 0
+	aggregated := make(map[string]pmetric.HistogramDataPoint, dps.Len())
+	dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+		deleteKeys(ma.dropKeys, dp.Attributes())
+		if dp.Flags().NoRecordedValue() || dp.BucketCounts().Len() != dp.ExplicitBounds().Len()+1 {
+			return false
+		}
+
+		key := attributesKey(dp.Attributes()) + timesKey(dp, cumulative) + boundsKey(dp.ExplicitBounds())
+		target, found := aggregated[key]
+		if !found {
+			aggregated[key] = dp
+			return false
+		}
+
+		mergeHistogramDataPoint(target, dp)
+		merged++
+		return true
+	})
+	return merged
+}
+
+// aggregateExponentialHistogramDataPoints merges the data points with identical attributes, times and scale.
+// Returns the number of data points that were merged into another one.
+func (ma *metricsAggregator) aggregateExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, cumulative bool) int {
+	merged := 0
+	aggregated := make(map[string]pmetric.ExponentialHistogramDataPoint, dps.Len())
+	dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+		deleteKeys(ma.dropKeys, dp.Attributes())
+		if dp.Flags().NoRecordedValue() {
+			return false
+		}
+
+		key := attributesKey(dp.Attributes()) + timesKey(dp, cumulative) + "\x01" + strconv.Itoa(int(dp.Scale()))
+		target, found := aggregated[key]
+		if !found {
+			aggregated[key] = dp
+			return false
+		}
+
+		mergeExponentialHistogramDataPoint(target, dp)
+		merged++
+		return true
+	})
+	return merged
+}
+
+func mergeNumberDataPoint(target, source pmetric.NumberDataPoint, function string) {
+	mergeTimestamps(target, source)
+	source.Exemplars().MoveAndAppendTo(target.Exemplars())
+
+	if target.ValueType() == pmetric.NumberDataPointValueTypeInt && source.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		switch function {
+		case aggregateMin:
+			if source.IntVal() < target.IntVal() {
+				target.SetIntVal(source.IntVal())
+			}
+		case aggregateMax:
+			if source.IntVal() > target.IntVal() {
+				target.SetIntVal(source.IntVal())
+			}
+		default:
+			target.SetIntVal(target.IntVal() + source.IntVal())
+		}
+		return
+	}
+
+	// At least one of the values is a double, so the result is a double as well
+	targetVal, sourceVal := numberValue(target), numberValue(source)
+	switch function {
+	case aggregateMin:
+		target.SetDoubleVal(math.Min(targetVal, sourceVal))
+	case aggregateMax:
+		target.SetDoubleVal(math.Max(targetVal, sourceVal))
+	default:
+		target.SetDoubleVal(targetVal + sourceVal)
+	}
+}
+
+func mergeHistogramDataPoint(target, source pmetric.HistogramDataPoint) {
+	// the min and max are only known if both data points have them
+	min, hasMin := math.Min(target.Min(), source.Min()), target.HasMin() && source.HasMin()
+	max, hasMax := math.Max(target.Max(), source.Max()), target.HasMax() && source.HasMax()
+	if (target.HasMin() && !hasMin) || (target.HasMax() && !hasMax) {
+		removeHistogramMinMax(target)
+	}
+	if hasMin {
+		target.SetMin(min)
+	}
+	if hasMax {
+		target.SetMax(max)
+	}
+
+	mergeTimestamps(target, source)
+	source.Exemplars().MoveAndAppendTo(target.Exemplars())
+	if target.HasSum() && source.HasSum() {
+		target.SetSum(target.Sum() + source.Sum())
+	}
+	target.SetCount(target.Count() + source.Count())
+
+	counts := target.BucketCounts()
+	for i := 0; i < counts.Len(); i++ {
+		counts.SetAt(i, counts.At(i)+source.BucketCounts().At(i))
+	}
+}
+
+func mergeExponentialHistogramDataPoint(target, source pmetric.ExponentialHistogramDataPoint) {
+	// the min and max are only known if both data points have them
+	min, hasMin := math.Min(target.Min(), source.Min()), target.HasMin() && source.HasMin()
+	max, hasMax := math.Max(target.Max(), source.Max()), target.HasMax() && source.HasMax()
+	if (target.HasMin() && !hasMin) || (target.HasMax() && !hasMax) {
+		removeExponentialHistogramMinMax(target)
+	}
+	if hasMin {
+		target.SetMin(min)
+	}
+	if hasMax {
+		target.SetMax(max)
+	}
+
+	mergeTimestamps(target, source)
+	source.Exemplars().MoveAndAppendTo(target.Exemplars())
+	if target.HasSum() && source.HasSum() {
+		target.SetSum(target.Sum() + source.Sum())
+	}
+	target.SetCount(target.Count() + source.Count())
+	target.SetZeroCount(target.ZeroCount() + source.ZeroCount())
+
+	mergeBuckets(target.Positive(), source.Positive())
+	mergeBuckets(target.Negative(), source.Negative())
+}
+
+// removeHistogramMinMax removes the min and the max of the data point, by replacing it with a copy without them.
+func removeHistogramMinMax(dp pmetric.HistogramDataPoint) {
+	stripped := pmetric.NewHistogramDataPoint()
+	dp.Attributes().CopyTo(stripped.Attributes())
+	stripped.SetStartTimestamp(dp.StartTimestamp())
+	stripped.SetTimestamp(dp.Timestamp())
+	stripped.SetCount(dp.Count())
+	if dp.HasSum() {
+		stripped.SetSum(dp.Sum())
+	}
+	dp.BucketCounts().CopyTo(stripped.BucketCounts())
+	dp.ExplicitBounds().CopyTo(stripped.ExplicitBounds())
+	dp.Exemplars().CopyTo(stripped.Exemplars())
+	stripped.SetFlags(dp.Flags())
+	stripped.MoveTo(dp)
+}
+
+// removeExponentialHistogramMinMax removes the min and the max of the data point, by replacing it with a copy
+// without them.
+func removeExponentialHistogramMinMax(dp pmetric.ExponentialHistogramDataPoint) {
+	stripped := pmetric.NewExponentialHistogramDataPoint()
+	dp.Attributes().CopyTo(stripped.Attributes())
+	stripped.SetStartTimestamp(dp.StartTimestamp())
+	stripped.SetTimestamp(dp.Timestamp())
+	stripped.SetCount(dp.Count())
+	if dp.HasSum() {
+		stripped.SetSum(dp.Sum())
+	}
+	stripped.SetScale(dp.Scale())
+	stripped.SetZeroCount(dp.ZeroCount())
+	dp.Positive().CopyTo(stripped.Positive())
+	dp.Negative().CopyTo(stripped.Negative())
+	dp.Exemplars().CopyTo(stripped.Exemplars())
+	stripped.SetFlags(dp.Flags())
+	stripped.MoveTo(dp)
+}
+
+// mergeBuckets adds the counts of the source buckets to the target ones, both having the same scale.
+func mergeBuckets(target, source pmetric.Buckets) {
+	if source.BucketCounts().Len() == 0 {
+		return
+	}
+	if target.BucketCounts().Len() == 0 {
+		source.CopyTo(target)
+		return
+	}
+
+	offset := target.Offset()
+	if source.Offset() < offset {
+		offset = source.Offset()
+	}
+	end := target.Offset() + int32(target.BucketCounts().Len())
+	if sourceEnd := source.Offset() + int32(source.BucketCounts().Len()); sourceEnd > end {
+		end = sourceEnd
+	}
+
+	counts := make([]uint64, end-offset)
+	for _, buckets := range []pmetric.Buckets{target, source} {
+		shift := int(buckets.Offset() - offset)
+		for i := 0; i < buckets.BucketCounts().Len(); i++ {
+			counts[shift+i] += buckets.BucketCounts().At(i)
+		}
+	}
+
+	target.SetOffset(offset)
+	target.BucketCounts().FromRaw(counts)
+}
+
+type timestamped interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+// mergeTimestamps makes the target data point cover the time range of both data points.
+func mergeTimestamps(target, source timestamped) {
+	if source.StartTimestamp() != 0 && (target.StartTimestamp() == 0 || source.StartTimestamp() < target.StartTimestamp()) {
+		target.SetStartTimestamp(source.StartTimestamp())
+	}
+	if source.Timestamp() > target.Timestamp() {
+		target.SetTimestamp(source.Timestamp())
+	}
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntVal())
+	}
+	return dp.DoubleVal()
+}
+
+func deleteKeys(keys []string, attrs pcommon.Map) {
+	for _, key := range keys {
+		attrs.Remove(key)
+	}
+}
+
+// attributesKey builds a string uniquely identifying the specified attributes, regardless of their order.
+func attributesKey(attrs pcommon.Map) string {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(v.Type().String())
+		b.WriteByte(0)
+		b.WriteString(v.AsString())
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+func isCumulative(temporality pmetric.MetricAggregationTemporality) bool {
+	return temporality == pmetric.MetricAggregationTemporalityCumulative
+}
+
+// timesKey builds a string identifying the time of the data point, so that only the data points reported at the
+// same time are merged. Cumulative data points must also count from the same start time, their values otherwise
+// covering different ranges.
+func timesKey(dp timestamped, cumulative bool) string {
+	key := "\x02" + strconv.FormatUint(uint64(dp.Timestamp()), 10)
+	if cumulative {
+		key += "\x02" + strconv.FormatUint(uint64(dp.StartTimestamp()), 10)
+	}
+	return key
+}
+
+func boundsKey(bounds pcommon.Float64Slice) string {
+	var b strings.Builder
+	for i := 0; i < bounds.Len(); i++ {
+		b.WriteByte(1)
+		b.WriteString(strconv.FormatFloat(bounds.At(i), 'g', -1, 64))
+	}
+	return b.String()
+}
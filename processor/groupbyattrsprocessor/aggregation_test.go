@@ -0,0 +1,329 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbyattrsprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func TestAggregateAfterGrouping(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutString("source", "prom")
+	metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("requests")
+	metric.SetEmptySum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	metric.Sum().SetIsMonotonic(true)
+	for i, host := range []string{"host-A", "host-A", "host-B", "host-A"} {
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutString("host.name", host)
+		dp.Attributes().PutString("pod.name", []string{"pod-1", "pod-2"}[i%2])
+		dp.Attributes().PutString("method", "GET")
+		dp.SetIntVal(int64(i + 1))
+		dp.SetStartTimestamp(pcommon.Timestamp(100 - i))
+		dp.SetTimestamp(pcommon.Timestamp(200))
+	}
+
+	gap := createGroupByAttrsProcessor(zap.NewNop(), []string{"host.name"})
+	gap.aggregator = newMetricsAggregator(&AggregationConfig{DropKeys: []string{"pod.name"}})
+
+	processedMetrics, err := gap.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	require.Equal(t, 2, processedMetrics.ResourceMetrics().Len())
+
+	hostA, found := retrieveHostResource(processedMetrics.ResourceMetrics(), "host-A")
+	require.True(t, found)
+	sum, found := retrieveMetric(hostA.ScopeMetrics().At(0).Metrics(), "requests", pmetric.MetricDataTypeSum)
+	require.True(t, found)
+	assert.True(t, sum.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, sum.Sum().AggregationTemporality())
+	require.Equal(t, 1, sum.Sum().DataPoints().Len())
+	dp := sum.Sum().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"method": "GET"}, dp.Attributes().AsRaw())
+	assert.Equal(t, int64(1+2+4), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(97), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(200), dp.Timestamp())
+
+	hostB, found := retrieveHostResource(processedMetrics.ResourceMetrics(), "host-B")
+	require.True(t, found)
+	sum, found = retrieveMetric(hostB.ScopeMetrics().At(0).Metrics(), "requests", pmetric.MetricDataTypeSum)
+	require.True(t, found)
+	require.Equal(t, 1, sum.Sum().DataPoints().Len())
+	assert.Equal(t, int64(3), sum.Sum().DataPoints().At(0).IntVal())
+}
+
+func TestAggregateCumulativeSums(t *testing.T) {
+	dps := pmetric.NewNumberDataPointSlice()
+	for _, point := range []struct {
+		pod   string
+		start pcommon.Timestamp
+		ts    pcommon.Timestamp
+		value int64
+	}{
+		// two points of the same series, which must not be added
+		{pod: "pod-1", start: 100, ts: 200, value: 100},
+		{pod: "pod-1", start: 100, ts: 300, value: 110},
+		// the points of another series at the same times, which can be added
+		{pod: "pod-2", start: 100, ts: 200, value: 5},
+		{pod: "pod-2", start: 100, ts: 300, value: 7},
+		// a series restarted since, counting from another start time
+		{pod: "pod-3", start: 250, ts: 300, value: 1},
+	} {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutString("pod.name", point.pod)
+		dp.SetStartTimestamp(point.start)
+		dp.SetTimestamp(point.ts)
+		dp.SetIntVal(point.value)
+	}
+
+	ma := newMetricsAggregator(&AggregationConfig{DropKeys: []string{"pod.name"}})
+	assert.Equal(t, 2, ma.aggregateNumberDataPoints(dps, aggregateSum, true))
+
+	require.Equal(t, 3, dps.Len())
+	for i, expected := range []struct {
+		start pcommon.Timestamp
+		ts    pcommon.Timestamp
+		value int64
+	}{
+		{start: 100, ts: 200, value: 105},
+		{start: 100, ts: 300, value: 117},
+		{start: 250, ts: 300, value: 1},
+	} {
+		assert.Equal(t, expected.start, dps.At(i).StartTimestamp())
+		assert.Equal(t, expected.ts, dps.At(i).Timestamp())
+		assert.Equal(t, expected.value, dps.At(i).IntVal())
+	}
+}
+
+func TestAggregateGaugesAtDifferentTimes(t *testing.T) {
+	dps := pmetric.NewNumberDataPointSlice()
+	for i, v := range []int64{3, 9} {
+		dp := dps.AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(100 * (i + 1)))
+		dp.SetIntVal(v)
+	}
+
+	ma := newMetricsAggregator(&AggregationConfig{})
+	assert.Equal(t, 0, ma.aggregateNumberDataPoints(dps, ma.gauge, false))
+	assert.Equal(t, 2, dps.Len())
+}
+
+func TestAggregateGauges(t *testing.T) {
+	tests := []struct {
+		function string
+		expected float64
+	}{
+		{function: aggregateSum, expected: 13.5},
+		{function: aggregateMin, expected: 2.5},
+		{function: aggregateMax, expected: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			dps := pmetric.NewNumberDataPointSlice()
+			dps.AppendEmpty().SetIntVal(7)
+			dps.AppendEmpty().SetDoubleVal(2.5)
+			dps.AppendEmpty().SetIntVal(4)
+			other := dps.AppendEmpty()
+			other.SetIntVal(100)
+			other.Attributes().PutString("id", "eth1")
+			noValue := dps.AppendEmpty()
+			noValue.SetFlags(pmetric.DefaultMetricDataPointFlags.WithNoRecordedValue(true))
+
+			ma := newMetricsAggregator(&AggregationConfig{Gauge: tt.function})
+			assert.Equal(t, 2, ma.aggregateNumberDataPoints(dps, ma.gauge, false))
+
+			require.Equal(t, 3, dps.Len())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dps.At(0).ValueType())
+			assert.Equal(t, tt.expected, dps.At(0).DoubleVal())
+			assert.Equal(t, int64(100), dps.At(1).IntVal())
+			assert.True(t, dps.At(2).Flags().NoRecordedValue())
+		})
+	}
+}
+
+func TestAggregateIntGauges(t *testing.T) {
+	dps := pmetric.NewNumberDataPointSlice()
+	for _, v := range []int64{3, 9, 5} {
+		dp := dps.AppendEmpty()
+		dp.SetIntVal(v)
+		dp.Exemplars().AppendEmpty().SetIntVal(v)
+	}
+
+	ma := newMetricsAggregator(&AggregationConfig{Gauge: aggregateMax})
+	assert.Equal(t, 2, ma.aggregateNumberDataPoints(dps, ma.gauge, false))
+
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dps.At(0).ValueType())
+	assert.Equal(t, int64(9), dps.At(0).IntVal())
+	assert.Equal(t, 3, dps.At(0).Exemplars().Len())
+}
+
+func TestAggregateHistograms(t *testing.T) {
+	dps := pmetric.NewHistogramDataPointSlice()
+	for i, bounds := range [][]float64{{1, 10}, {1, 10}, {1, 5}} {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutString("pod.name", []string{"pod-1", "pod-2", "pod-3"}[i])
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw([]uint64{uint64(i + 1), 2, 3})
+		dp.SetCount(uint64(i + 6))
+		dp.SetSum(float64(10 * (i + 1)))
+		dp.SetMin(float64(i))
+		dp.SetMax(float64(20 - i))
+	}
+
+	ma := newMetricsAggregator(&AggregationConfig{DropKeys: []string{"pod.name"}})
+	assert.Equal(t, 1, ma.aggregateHistogramDataPoints(dps, false))
+
+	require.Equal(t, 2, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, 0, dp.Attributes().Len())
+	assert.Equal(t, []float64{1, 10}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{3, 4, 6}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(13), dp.Count())
+	assert.Equal(t, float64(30), dp.Sum())
+	assert.Equal(t, float64(0), dp.Min())
+	assert.Equal(t, float64(20), dp.Max())
+
+	// Different bounds can't be merged
+	assert.Equal(t, []float64{1, 5}, dps.At(1).ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{3, 2, 3}, dps.At(1).BucketCounts().AsRaw())
+}
+
+func TestAggregateHistogramsWithoutMinMax(t *testing.T) {
+	dps := pmetric.NewHistogramDataPointSlice()
+	withMinMax := dps.AppendEmpty()
+	withMinMax.SetCount(2)
+	withMinMax.SetSum(3)
+	withMinMax.SetMin(1)
+	withMinMax.SetMax(2)
+	withMinMax.BucketCounts().FromRaw([]uint64{2})
+	withMinMax.Exemplars().AppendEmpty().SetDoubleVal(1)
+	withoutMinMax := dps.AppendEmpty()
+	withoutMinMax.SetCount(1)
+	withoutMinMax.SetSum(5)
+	withoutMinMax.BucketCounts().FromRaw([]uint64{1})
+
+	ma := newMetricsAggregator(&AggregationConfig{})
+	assert.Equal(t, 1, ma.aggregateHistogramDataPoints(dps, false))
+
+	require.Equal(t, 1, dps.Len())
+	dp := dps.At(0)
+	assert.False(t, dp.HasMin())
+	assert.False(t, dp.HasMax())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, float64(8), dp.Sum())
+	assert.Equal(t, []uint64{3}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, 1, dp.Exemplars().Len())
+}
+
+func TestAggregateExponentialHistogramsWithoutMinMax(t *testing.T) {
+	dps := pmetric.NewExponentialHistogramDataPointSlice()
+	withoutMinMax := dps.AppendEmpty()
+	withoutMinMax.SetCount(1)
+	withoutMinMax.SetScale(2)
+	withoutMinMax.Positive().BucketCounts().FromRaw([]uint64{1})
+	withMinMax := dps.AppendEmpty()
+	withMinMax.SetCount(2)
+	withMinMax.SetScale(2)
+	withMinMax.SetMin(1)
+	withMinMax.SetMax(2)
+	withMinMax.Positive().BucketCounts().FromRaw([]uint64{2})
+	bothMinMax := dps.AppendEmpty()
+	bothMinMax.SetCount(1)
+	bothMinMax.SetScale(3)
+	bothMinMax.SetMin(1)
+	bothMinMax.SetMax(2)
+	otherMinMax := dps.AppendEmpty()
+	otherMinMax.SetCount(1)
+	otherMinMax.SetScale(3)
+	otherMinMax.SetMin(0.5)
+	otherMinMax.SetMax(1.5)
+
+	ma := newMetricsAggregator(&AggregationConfig{})
+	assert.Equal(t, 2, ma.aggregateExponentialHistogramDataPoints(dps, false))
+
+	require.Equal(t, 2, dps.Len())
+	assert.False(t, dps.At(0).HasMin())
+	assert.False(t, dps.At(0).HasMax())
+	assert.Equal(t, uint64(3), dps.At(0).Count())
+	assert.Equal(t, int32(2), dps.At(0).Scale())
+	assert.Equal(t, []uint64{3}, dps.At(0).Positive().BucketCounts().AsRaw())
+	assert.Equal(t, 0.5, dps.At(1).Min())
+	assert.Equal(t, float64(2), dps.At(1).Max())
+}
+
+func TestAggregateExponentialHistograms(t *testing.T) {
+	dps := pmetric.NewExponentialHistogramDataPointSlice()
+
+	first := dps.AppendEmpty()
+	first.SetScale(2)
+	first.SetCount(7)
+	first.SetZeroCount(1)
+	first.Positive().SetOffset(3)
+	first.Positive().BucketCounts().FromRaw([]uint64{1, 2, 3})
+
+	second := dps.AppendEmpty()
+	second.SetScale(2)
+	second.SetCount(6)
+	second.SetZeroCount(2)
+	second.Positive().SetOffset(1)
+	second.Positive().BucketCounts().FromRaw([]uint64{1, 1, 1})
+	second.Negative().SetOffset(-2)
+	second.Negative().BucketCounts().FromRaw([]uint64{1})
+
+	otherScale := dps.AppendEmpty()
+	otherScale.SetScale(3)
+	otherScale.SetCount(1)
+
+	ma := newMetricsAggregator(&AggregationConfig{})
+	assert.Equal(t, 1, ma.aggregateExponentialHistogramDataPoints(dps, false))
+
+	require.Equal(t, 2, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, uint64(13), dp.Count())
+	assert.Equal(t, uint64(3), dp.ZeroCount())
+	assert.Equal(t, int32(1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 1, 2, 2, 3}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(-2), dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+	assert.Equal(t, int32(3), dps.At(1).Scale())
+}
+
+func TestAggregationDisabledByDefault(t *testing.T) {
+	factory := NewFactory()
+	sink := new(consumertest.MetricsSink)
+	mp, err := factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), factory.CreateDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("gauge")
+	metric.SetEmptyGauge().DataPoints().AppendEmpty().SetIntVal(1)
+	metric.Gauge().DataPoints().AppendEmpty().SetIntVal(2)
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 2, sink.AllMetrics()[0].DataPointCount())
+}
@@ -15,9 +15,20 @@
 package groupbyattrsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	// aggregateSum adds the values of the aggregated data points.
+	aggregateSum = "sum"
+	// aggregateMin keeps the smallest value of the aggregated data points.
+	aggregateMin = "min"
+	// aggregateMax keeps the largest value of the aggregated data points.
+	aggregateMax = "max"
+)
+
 // Config is the configuration for the processor.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -25,4 +36,36 @@ type Config struct {
 	// GroupByKeys describes the attribute names that are going to be used for grouping.
 	// Empty value is allowed, since processor in such case can compact data
 	GroupByKeys []string `mapstructure:"keys"`
+
+	// Aggregation enables merging the metric data points that have identical attributes once grouped.
+	// Aggregation is disabled if not set.
+	Aggregation *AggregationConfig `mapstructure:"aggregation"`
+}
+
+// AggregationConfig describes how metric data points with identical attributes are merged together.
+type AggregationConfig struct {
+	// DropKeys describes the data point attribute names that are removed before aggregating,
+	// so that the data points differing only by these attributes get merged.
+	DropKeys []string `mapstructure:"drop_keys"`
+
+	// Gauge is the function used to aggregate gauge data points, one of "sum", "min" or "max".
+	// Sum, histogram and exponential histogram data points are always merged by adding them up.
+	// Default is "sum".
+	Gauge string `mapstructure:"gauge"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Aggregation == nil {
+		return nil
+	}
+	switch cfg.Aggregation.Gauge {
+	case "", aggregateSum, aggregateMin, aggregateMax:
+		return nil
+	default:
+		return fmt.Errorf("invalid gauge aggregation %q, must be one of %q, %q or %q",
+			cfg.Aggregation.Gauge, aggregateSum, aggregateMin, aggregateMax)
+	}
 }
@@ -45,6 +45,17 @@ func TestLoadingConfig(t *testing.T) {
 				GroupByKeys:       []string{},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "aggregation"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				GroupByKeys:       []string{"host.name"},
+				Aggregation: &AggregationConfig{
+					DropKeys: []string{"pod.name"},
+					Gauge:    aggregateMax,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -64,3 +75,17 @@ func TestLoadingConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestInvalidConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "invalid_aggregation").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+	assert.EqualError(t, cfg.Validate(), `invalid gauge aggregation "avg", must be one of "sum", "min" or "max"`)
+}
@@ -128,6 +128,9 @@ func createMetricsProcessor(
 
 	oCfg := cfg.(*Config)
 	gap := createGroupByAttrsProcessor(set.Logger, oCfg.GroupByKeys)
+	if oCfg.Aggregation != nil {
+		gap.aggregator = newMetricsAggregator(oCfg.Aggregation)
+	}
 
 	return processorhelper.NewMetricsProcessor(
 		ctx,
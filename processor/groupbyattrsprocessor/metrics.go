@@ -32,6 +32,8 @@ var (
 	mNumGroupedMetrics    = stats.Int64("num_grouped_metrics", "Number of metrics that had attributes grouped", stats.UnitDimensionless)
 	mNumNonGroupedMetrics = stats.Int64("num_non_grouped_metrics", "Number of metrics that did not have attributes grouped", stats.UnitDimensionless)
 	mDistMetricGroups     = stats.Int64("metric_groups", "Distribution of groups extracted for metrics", stats.UnitDimensionless)
+
+	mNumAggregatedDataPoints = stats.Int64("num_aggregated_datapoints", "Number of metric data points that were merged into another one", stats.UnitDimensionless)
 )
 
 // MetricViews return the metrics views according to given telemetry level.
@@ -95,5 +97,12 @@ func MetricViews() []*view.View {
 			Description: mDistMetricGroups.Description(),
 			Aggregation: distributionGroups,
 		},
+
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mNumAggregatedDataPoints.Name()),
+			Measure:     mNumAggregatedDataPoints,
+			Description: mNumAggregatedDataPoints.Description(),
+			Aggregation: view.Sum(),
+		},
 	}
 }
@@ -28,6 +28,8 @@ import (
 type groupByAttrsProcessor struct {
 	logger      *zap.Logger
 	groupByKeys []string
+	// aggregator merges the grouped metric data points with identical attributes, nil if disabled
+	aggregator *metricsAggregator
 }
 
 // ProcessTraces process traces and groups traces by attribute.
@@ -167,6 +169,10 @@ func (gap *groupByAttrsProcessor) processMetrics(ctx context.Context, md pmetric
 		}
 	}
 
+	if gap.aggregator != nil {
+		gap.aggregator.aggregate(ctx, groupedResourceMetrics.ResourceMetricsSlice)
+	}
+
 	// Copy the grouped data into output
 	groupedMetrics := pmetric.NewMetrics()
 	groupedResourceMetrics.MoveAndAppendTo(groupedMetrics.ResourceMetrics())
@@ -15,6 +15,7 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -39,12 +40,26 @@ type Config struct {
 	// DiscardOrphans instructs the processor to discard traces without the root span.
 	// This typically indicates that the trace is incomplete.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
 	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageID is the ID of the storage extension used to persist the trace spans when StoreOnDisk is set.
+	// Required when StoreOnDisk is true.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+}
+
+var errStorageRequired = errors.New("the 'storage' option is required when 'store_on_disk' is enabled")
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.StoreOnDisk && cfg.StorageID == nil {
+		return errStorageRequired
+	}
+	return nil
 }
@@ -16,7 +16,6 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"go.opencensus.io/stats/view"
@@ -38,11 +37,6 @@ const (
 	defaultStoreOnDisk    = false
 )
 
-var (
-	errDiskStorageNotSupported    = fmt.Errorf("option 'disk storage' not supported in this release")
-	errDiscardOrphansNotSupported = fmt.Errorf("option 'discard orphans' not supported in this release")
-)
-
 // NewFactory returns a new factory for the Filter processor.
 func NewFactory() component.ProcessorFactory {
 	// TODO: find a more appropriate way to get this done, as we are swallowing the error here
@@ -61,10 +55,8 @@ func createDefaultConfig() config.Processor {
 		NumTraces:         defaultNumTraces,
 		NumWorkers:        defaultNumWorkers,
 		WaitDuration:      defaultWaitDuration,
-
-		// not supported for now
-		DiscardOrphans: defaultDiscardOrphans,
-		StoreOnDisk:    defaultStoreOnDisk,
+		DiscardOrphans:    defaultDiscardOrphans,
+		StoreOnDisk:       defaultStoreOnDisk,
 	}
 }
 
@@ -79,14 +71,13 @@ func createTracesProcessor(
 
 	var st storage
 	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
+		if err := oCfg.Validate(); err != nil {
+			return nil, err
+		}
+		st = newDiskStorage(params.Logger, *oCfg.StorageID, oCfg.ID())
+	} else {
+		st = newMemoryStorage()
 	}
-	if oCfg.DiscardOrphans {
-		return nil, errDiscardOrphansNotSupported
-	}
-
-	// the only supported storage for now
-	st = newMemoryStorage()
 
 	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg), nil
 }
@@ -19,7 +19,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestDefaultConfiguration(t *testing.T) {
@@ -47,33 +50,50 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
-func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
+	// prepare
+	f := NewFactory()
+	next := &mockProcessor{}
+	storageID := storagetest.NewStorageID("groupbytrace")
+
+	// test
+	p, err := f.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), &Config{
+		StoreOnDisk: true,
+		StorageID:   &storageID,
+	}, next)
+
+	// verify
+	assert.NoError(t, err)
+	require.NotNil(t, p)
+	assert.IsType(t, &diskStorage{}, p.(*groupByTraceProcessor).st)
+}
+
+func TestCreateTestProcessorWithDiskStorageWithoutStorageID(t *testing.T) {
+	// prepare
+	f := NewFactory()
+	next := &mockProcessor{}
+
+	// test
+	p, err := f.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), &Config{
+		StoreOnDisk: true,
+	}, next)
+
+	// verify
+	assert.ErrorIs(t, err, errStorageRequired)
+	assert.Nil(t, p)
+}
+
+func TestCreateTestProcessorWithDiscardOrphans(t *testing.T) {
 	// prepare
 	f := NewFactory()
 	next := &mockProcessor{}
 
 	// test
-	for _, tt := range []struct {
-		config      *Config
-		expectedErr error
-	}{
-		{
-			&Config{
-				DiscardOrphans: true,
-			},
-			errDiscardOrphansNotSupported,
-		},
-		{
-			&Config{
-				StoreOnDisk: true,
-			},
-			errDiskStorageNotSupported,
-		},
-	} {
-		p, err := f.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), tt.config, next)
-
-		// verify
-		assert.Error(t, tt.expectedErr, err)
-		assert.Nil(t, p)
-	}
+	p, err := f.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), &Config{
+		DiscardOrphans: true,
+	}, next)
+
+	// verify
+	assert.NoError(t, err)
+	assert.NotNil(t, p)
 }
@@ -93,14 +93,51 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
+	stats.Record(context.Background(), mDiscardedOrphans.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
+	if rst, ok := sp.st.(recoverableStorage); ok {
+		sp.recover(rst.recovered())
+	}
+
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	return nil
+}
+
+// recover places the given trace IDs back into the workers' buffers and schedules them to be released.
+// It must be called before the event machine starts, as it accesses the buffers outside of the workers.
+func (sp *groupByTraceProcessor) recover(traceIDs []pcommon.TraceID) {
+	for _, traceID := range traceIDs {
+		var bucket uint64
+		if len(sp.eventMachine.workers) != 1 {
+			bucket = workerIndexForTraceID(traceID, len(sp.eventMachine.workers))
+		}
+		worker := sp.eventMachine.workers[bucket]
+
+		if evicted := worker.buffer.put(traceID); !evicted.IsEmpty() {
+			// the workers aren't running yet, so we remove the evicted trace straight away
+			if _, err := sp.st.delete(evicted); err != nil {
+				sp.logger.Warn("failed to remove evicted trace from the storage", zap.String("traceID", evicted.HexString()), zap.Error(err))
+			}
+			stats.Record(context.Background(), mTracesEvicted.M(1))
+		}
+
+		traceID := traceID
+		time.AfterFunc(sp.config.WaitDuration, func() {
+			worker.fire(event{
+				typ:     traceExpired,
+				payload: traceID,
+			})
+		})
+	}
 }
 
 // Shutdown is invoked during service shutdown.
@@ -212,6 +249,12 @@ func (sp *groupByTraceProcessor) markAsReleased(traceID pcommon.TraceID, fire fu
 }
 
 func (sp *groupByTraceProcessor) onTraceReleased(rss []ptrace.ResourceSpans) error {
+	if sp.config.DiscardOrphans && !hasRootSpan(rss) {
+		sp.logger.Debug("discarding trace without a root span")
+		stats.Record(context.Background(), mDiscardedOrphans.M(1))
+		return nil
+	}
+
 	trace := ptrace.NewTraces()
 	for _, rs := range rss {
 		trs := trace.ResourceSpans().AppendEmpty()
@@ -248,3 +291,18 @@ func (sp *groupByTraceProcessor) addSpans(traceID pcommon.TraceID, trace ptrace.
 	sp.logger.Debug("creating trace at the storage", zap.String("traceID", traceID.HexString()))
 	return sp.st.createOrAppend(traceID, trace)
 }
+
+// hasRootSpan returns whether any of the spans in the given resource spans has no parent.
+func hasRootSpan(rss []ptrace.ResourceSpans) bool {
+	for _, rs := range rss {
+		for i := 0; i < rs.ScopeSpans().Len(); i++ {
+			spans := rs.ScopeSpans().At(i).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				if spans.At(j).ParentSpanID().IsEmpty() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
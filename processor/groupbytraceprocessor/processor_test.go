@@ -24,11 +24,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 )
 
@@ -555,6 +557,89 @@ func TestAsyncOnRelease(t *testing.T) {
 	close(blockCh)
 }
 
+func TestDiscardOrphans(t *testing.T) {
+	// prepare
+	wg := &sync.WaitGroup{}
+	var received []ptrace.Traces
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			received = append(received, td)
+			wg.Done()
+			return nil
+		},
+	}
+
+	sp := &groupByTraceProcessor{
+		logger:       zap.NewNop(),
+		nextConsumer: next,
+		config: Config{
+			DiscardOrphans: true,
+		},
+	}
+
+	orphan := simpleTraces()
+	orphan.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetParentSpanID(pcommon.SpanID([8]byte{1, 2, 3, 4}))
+	complete := simpleTraces()
+
+	// test
+	wg.Add(1)
+	assert.NoError(t, sp.onTraceReleased([]ptrace.ResourceSpans{orphan.ResourceSpans().At(0)}))
+	assert.NoError(t, sp.onTraceReleased([]ptrace.ResourceSpans{complete.ResourceSpans().At(0)}))
+
+	// verify
+	wg.Wait()
+	next.mutex.Lock()
+	defer next.mutex.Unlock()
+	require.Len(t, received, 1)
+	assert.Equal(t, complete, received[0])
+}
+
+func TestTracesAreRecoveredFromDisk(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("groupbytrace", storageDir)
+	storageID := storagetest.NewStorageID("groupbytrace")
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		WaitDuration:      time.Hour,
+		NumTraces:         10,
+		NumWorkers:        2,
+		StoreOnDisk:       true,
+		StorageID:         &storageID,
+	}
+	ctx := context.Background()
+	traces := simpleTraces()
+
+	// the first processor receives the trace, but is shut down before the trace is released
+	p := newGroupByTraceProcessor(zap.NewNop(), newDiskStorage(zap.NewNop(), storageID, cfg.ID()), &mockProcessor{}, cfg)
+	require.NoError(t, p.Start(ctx, host))
+	require.NoError(t, p.ConsumeTraces(ctx, traces))
+	require.Eventually(t, func() bool {
+		return p.st.(*diskStorage).count() == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(ctx))
+
+	// test
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, received ptrace.Traces) error {
+			assert.Equal(t, traces, received)
+			wg.Done()
+			return nil
+		},
+	}
+	cfg.WaitDuration = time.Nanosecond
+	p = newGroupByTraceProcessor(zap.NewNop(), newDiskStorage(zap.NewNop(), storageID, cfg.ID()), next, cfg)
+	require.NoError(t, p.Start(ctx, host))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// verify
+	wg.Wait()
+}
+
 func BenchmarkConsumeTracesCompleteOnFirstBatch(b *testing.B) {
 	// prepare
 	config := Config{
@@ -616,7 +701,7 @@ type mockStorage struct {
 	onCreateOrAppend func(pcommon.TraceID, ptrace.Traces) error
 	onGet            func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 	onDelete         func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
-	onStart          func() error
+	onStart          func(context.Context, component.Host) error
 	onShutdown       func() error
 }
 
@@ -640,9 +725,9 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) start(ctx context.Context, host component.Host) error {
 	if st.onStart != nil {
-		return st.onStart()
+		return st.onStart(ctx, host)
 	}
 	return nil
 }
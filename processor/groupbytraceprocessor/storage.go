@@ -15,6 +15,9 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -36,8 +39,18 @@ type storage interface {
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(context.Context, component.Host) error
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
 }
+
+// recoverableStorage is implemented by storages that keep traces across restarts. The trace IDs
+// it returns after start are re-scheduled by the processor, so that they are released once the wait
+// duration expires again.
+type recoverableStorage interface {
+	storage
+
+	// recovered returns the IDs of the traces that were found in the storage when it started
+	recovered() []pcommon.TraceID
+}
@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	storageext "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// indexKey is the storage key holding the IDs of the traces currently in the storage. Trace keys
+// are the hex representation of the trace ID, so they can't collide with it.
+const indexKey = "trace_ids"
+
+var (
+	errStorageNotFound   = errors.New("storage extension not found")
+	errNotStorage        = errors.New("the extension isn't a storage extension")
+	errStorageNotStarted = errors.New("the storage hasn't been started")
+	errInvalidIndex      = errors.New("the stored trace index is corrupted")
+)
+
+// diskStorage keeps only the trace IDs in memory, serializing the spans to a storage extension client.
+// The set of trace IDs is persisted along with each trace added or deleted, in the same batch, so that
+// the traces can be recovered when the processor starts again, even after a crash.
+type diskStorage struct {
+	logger      *zap.Logger
+	storageID   config.ComponentID
+	componentID config.ComponentID
+	client      storageext.Client
+
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+
+	sync.Mutex
+	traceIDs     map[pcommon.TraceID]struct{}
+	recoveredIDs []pcommon.TraceID
+	// indexLock serializes the changes of the set of trace IDs with their persistence,
+	// so that the last index written is the current one.
+	indexLock sync.Mutex
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+var _ recoverableStorage = (*diskStorage)(nil)
+
+func newDiskStorage(logger *zap.Logger, storageID config.ComponentID, componentID config.ComponentID) *diskStorage {
+	return &diskStorage{
+		logger:                    logger,
+		storageID:                 storageID,
+		componentID:               componentID,
+		marshaler:                 ptrace.NewProtoMarshaler(),
+		unmarshaler:               ptrace.NewProtoUnmarshaler(),
+		traceIDs:                  make(map[pcommon.TraceID]struct{}),
+		metricsCollectionInterval: time.Second,
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	if st.client == nil {
+		return errStorageNotStarted
+	}
+
+	ctx := context.Background()
+	key := traceID.HexString()
+
+	trace, found, err := st.load(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		trace = ptrace.NewTraces()
+	}
+	newRss := ptrace.NewResourceSpansSlice()
+	td.ResourceSpans().CopyTo(newRss)
+	newRss.MoveAndAppendTo(trace.ResourceSpans())
+
+	buf, err := st.marshaler.MarshalTraces(trace)
+	if err != nil {
+		return fmt.Errorf("couldn't serialize trace: %w", err)
+	}
+
+	st.indexLock.Lock()
+	defer st.indexLock.Unlock()
+
+	st.Lock()
+	_, known := st.traceIDs[traceID]
+	st.Unlock()
+	if known {
+		return st.client.Set(ctx, key, buf)
+	}
+
+	return st.updateIndex(ctx, traceID, true, storageext.SetOperation(key, buf))
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	if st.client == nil {
+		return nil, errStorageNotStarted
+	}
+
+	trace, found, err := st.load(context.Background(), traceID.HexString())
+	if err != nil || !found {
+		return nil, err
+	}
+	return resourceSpansOf(trace), nil
+}
+
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	if st.client == nil {
+		return nil, errStorageNotStarted
+	}
+
+	ctx := context.Background()
+	key := traceID.HexString()
+
+	trace, found, err := st.load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	st.indexLock.Lock()
+	err = st.updateIndex(ctx, traceID, false, storageext.DeleteOperation(key))
+	st.indexLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+	return resourceSpansOf(trace), nil
+}
+
+func (st *diskStorage) start(ctx context.Context, host component.Host) error {
+	ext, found := host.GetExtensions()[st.storageID]
+	if !found {
+		return fmt.Errorf("%w: %s", errStorageNotFound, st.storageID)
+	}
+	storageExt, ok := ext.(storageext.Extension)
+	if !ok {
+		return fmt.Errorf("%w: %s", errNotStorage, st.storageID)
+	}
+
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, st.componentID, "")
+	if err != nil {
+		return fmt.Errorf("couldn't get the storage client: %w", err)
+	}
+	st.client = client
+
+	buf, err := client.Get(ctx, indexKey)
+	if err != nil {
+		return fmt.Errorf("couldn't read the trace index: %w", err)
+	}
+	if len(buf)%len(pcommon.TraceID{}) != 0 {
+		return errInvalidIndex
+	}
+	for i := 0; i < len(buf); i += len(pcommon.TraceID{}) {
+		var traceID pcommon.TraceID
+		copy(traceID[:], buf[i:])
+		st.traceIDs[traceID] = struct{}{}
+		st.recoveredIDs = append(st.recoveredIDs, traceID)
+	}
+	if len(st.recoveredIDs) > 0 {
+		st.logger.Info("recovered traces from the storage", zap.Int("traces", len(st.recoveredIDs)))
+	}
+
+	go st.periodicMetrics()
+	return nil
+}
+
+func (st *diskStorage) recovered() []pcommon.TraceID {
+	return st.recoveredIDs
+}
+
+func (st *diskStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	if st.client == nil {
+		return nil
+	}
+
+	return st.client.Close(context.Background())
+}
+
+// load returns the trace stored under the given key, and whether it was found.
+func (st *diskStorage) load(ctx context.Context, key string) (ptrace.Traces, bool, error) {
+	buf, err := st.client.Get(ctx, key)
+	if err != nil {
+		return ptrace.Traces{}, false, fmt.Errorf("couldn't read trace from the storage: %w", err)
+	}
+	if buf == nil {
+		return ptrace.Traces{}, false, nil
+	}
+
+	trace, err := st.unmarshaler.UnmarshalTraces(buf)
+	if err != nil {
+		return ptrace.Traces{}, false, fmt.Errorf("couldn't deserialize trace: %w", err)
+	}
+	return trace, true, nil
+}
+
+// updateIndex adds or removes the trace ID from the set of trace IDs, and persists the set in the same
+// batch as the given operation on the trace. The set is left unchanged if the batch fails.
+// It must be called with the indexLock held.
+func (st *diskStorage) updateIndex(ctx context.Context, traceID pcommon.TraceID, add bool, op storageext.Operation) error {
+	st.Lock()
+	_, known := st.traceIDs[traceID]
+	if add {
+		st.traceIDs[traceID] = struct{}{}
+	} else {
+		delete(st.traceIDs, traceID)
+	}
+	buf := make([]byte, 0, len(st.traceIDs)*len(pcommon.TraceID{}))
+	for id := range st.traceIDs {
+		buf = append(buf, id[:]...)
+	}
+	st.Unlock()
+
+	if err := st.client.Batch(ctx, op, storageext.SetOperation(indexKey, buf)); err != nil {
+		st.Lock()
+		if known {
+			st.traceIDs[traceID] = struct{}{}
+		} else {
+			delete(st.traceIDs, traceID)
+		}
+		st.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (st *diskStorage) periodicMetrics() {
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	stats.Record(context.Background(), mNumTracesInMemory.M(int64(st.count())))
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func (st *diskStorage) count() int {
+	st.Lock()
+	defer st.Unlock()
+	return len(st.traceIDs)
+}
+
+func resourceSpansOf(trace ptrace.Traces) []ptrace.ResourceSpans {
+	rss := make([]ptrace.ResourceSpans, 0, trace.ResourceSpans().Len())
+	for i := 0; i < trace.ResourceSpans().Len(); i++ {
+		rss = append(rss, trace.ResourceSpans().At(i))
+	}
+	return rss
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newStartedDiskStorage(t *testing.T, storageDir string) *diskStorage {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("groupbytrace", storageDir)
+	st := newDiskStorage(zap.NewNop(), storagetest.NewStorageID("groupbytrace"), config.NewComponentID(typeStr))
+	require.NoError(t, st.start(context.Background(), host))
+	return st
+}
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, t.TempDir())
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("second")
+
+	// test
+	assert.NoError(t, st.createOrAppend(traceID, first))
+	assert.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	assert.Equal(t, 1, st.count())
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, first.ResourceSpans().At(0), retrieved[0])
+	assert.Equal(t, second.ResourceSpans().At(0), retrieved[1])
+
+	missing, err := st.get(pcommon.TraceID([16]byte{2, 3, 4, 5}))
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, t.TempDir())
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+	assert.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, trace.ResourceSpans().At(0), deleted[0])
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	assert.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	deleted, err = st.delete(traceID)
+	assert.NoError(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestDiskRecoversTracesAfterRestart(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	st := newStartedDiskStorage(t, storageDir)
+
+	kept := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	removed := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	assert.NoError(t, st.createOrAppend(kept, simpleTracesWithID(kept)))
+	assert.NoError(t, st.createOrAppend(removed, simpleTracesWithID(removed)))
+	_, err := st.delete(removed)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+
+	// test
+	restarted := newStartedDiskStorage(t, storageDir)
+	defer func() {
+		assert.NoError(t, restarted.shutdown())
+	}()
+
+	// verify
+	assert.Equal(t, []pcommon.TraceID{kept}, restarted.recovered())
+	retrieved, err := restarted.get(kept)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 1)
+}
+
+func TestDiskPersistsIndexWithEachTrace(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	st := newStartedDiskStorage(t, storageDir)
+
+	kept := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	removed := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	assert.NoError(t, st.createOrAppend(kept, simpleTracesWithID(kept)))
+	assert.NoError(t, st.createOrAppend(removed, simpleTracesWithID(removed)))
+	_, err := st.delete(removed)
+	require.NoError(t, err)
+
+	// test: the processor crashes, the storage being closed without shutting down the processor
+	index, err := st.client.Get(context.Background(), indexKey)
+	require.NoError(t, err)
+	require.NoError(t, st.client.Close(context.Background()))
+	restarted := newStartedDiskStorage(t, storageDir)
+	defer func() {
+		assert.NoError(t, restarted.shutdown())
+	}()
+
+	// verify
+	assert.Equal(t, kept[:], index)
+	assert.Equal(t, []pcommon.TraceID{kept}, restarted.recovered())
+	retrieved, err := restarted.get(kept)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 1)
+}
+
+func TestDiskStartErrors(t *testing.T) {
+	st := newDiskStorage(zap.NewNop(), storagetest.NewStorageID("missing"), config.NewComponentID(typeStr))
+	assert.ErrorIs(t, st.start(context.Background(), componenttest.NewNopHost()), errStorageNotFound)
+
+	host := storagetest.NewStorageHost().WithNonStorageExtension("other")
+	st = newDiskStorage(zap.NewNop(), storagetest.NewNonStorageID("other"), config.NewComponentID(typeStr))
+	assert.ErrorIs(t, st.start(context.Background(), host), errNotStorage)
+}
+
+func TestDiskNotStarted(t *testing.T) {
+	st := newDiskStorage(zap.NewNop(), storagetest.NewStorageID("groupbytrace"), config.NewComponentID(typeStr))
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	assert.ErrorIs(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)), errStorageNotStarted)
+	_, err := st.get(traceID)
+	assert.ErrorIs(t, err, errStorageNotStarted)
+	_, err = st.delete(traceID)
+	assert.ErrorIs(t, err, errStorageNotStarted)
+	assert.NoError(t, st.shutdown())
+}
@@ -84,6 +84,11 @@ type ExtractConfig struct {
 	// By default all of the fields are extracted and added to spans and metrics.
 	Metadata []string `mapstructure:"metadata"`
 
+	// OwnerLookup allows resolving k8s.deployment.name and k8s.cronjob.name by watching the
+	// replicasets and jobs owning the pods, instead of deriving them from the owner names.
+	// It requires permissions on replicasets and jobs, and is disabled by default.
+	OwnerLookup bool `mapstructure:"owner_lookup"`
+
 	// Annotations allows extracting data from pod annotations and record it
 	// as resource attributes.
 	// It is a list of FieldExtractConfig type. See FieldExtractConfig
@@ -107,6 +112,8 @@ type ExtractConfig struct {
 //	    When not specified a default tag name will be used of the format:
 //	    k8s.pod.annotations.<annotation key>
 //	    k8s.pod.labels.<label key>
+//	    k8s.namespace.annotations.<annotation key>, k8s.node.annotations.<annotation key>, etc.
+//	    when extracted from namespaces or nodes.
 //	    For example, if tag_name is not specified and the key is git_sha,
 //	    then the attribute name will be `k8s.pod.annotations.git_sha`.
 //	    When key_regex is present, tag_name supports back reference to both named capturing and positioned capturing.
@@ -161,7 +168,7 @@ type FieldExtractConfig struct {
 	KeyRegex string `mapstructure:"key_regex"`
 	Regex    string `mapstructure:"regex"`
 	// From represents the source of the labels/annotations.
-	// Allowed values are "pod" and "namespace". The default is pod.
+	// Allowed values are "pod", "namespace" and "node". The default is pod.
 	From string `mapstructure:"from"`
 }
 
@@ -70,12 +70,20 @@
 //     require identifier of a particular container run set as `k8s.container.restart_count` in resource attributes:
 //     - container.id
 //
-// The k8sattributesprocessor can be used for automatic tagging of spans, metrics and logs with k8s labels and annotations from pods and namespaces.
+// The workloads owning the pods are derived from the names of their owners: k8s.deployment.name from the name of the
+// pod's ReplicaSet, and k8s.cronjob.name from the name of the pod's Job. With `owner_lookup: true` in the extract
+// section, they are resolved through the owner references instead: k8s.deployment.name is taken from the Deployment
+// owning the pod's ReplicaSet, and k8s.cronjob.name from the CronJob owning the pod's Job. The ReplicaSets and Jobs
+// are then watched when these attributes are extracted. For owners that are not known yet, e.g. when the processor
+// is not allowed to watch them, the workload names are still derived from the owner names.
+//
+// The k8sattributesprocessor can be used for automatic tagging of spans, metrics and logs with k8s labels and annotations from pods, namespaces and nodes.
 // The config for associating the data passing through the processor (spans, metrics and logs) with specific Pod/Namespace annotations/labels is configured via "annotations"  and "labels" keys.
-// This config represents a list of annotations/labels that are extracted from pods/namespaces and added to spans, metrics and logs.
+// This config represents a list of annotations/labels that are extracted from pods/namespaces/nodes and added to spans, metrics and logs.
 // Each item is specified as a config of tag_name (representing the tag name to tag the spans with),
 // key (representing the key used to extract value) and from (representing the kubernetes object used to extract the value).
-// The "from" field has only two possible values "pod" and "namespace" and defaults to "pod" if none is specified.
+// The "from" field has three possible values "pod", "namespace" and "node" and defaults to "pod" if none is specified.
+// The node of the pod, or the node named by the k8s.node.name resource attribute, is used when extracting from nodes.
 //
 // A few examples to use this config are as follows:
 // annotations:
@@ -95,10 +103,15 @@
 //     key: label2
 //     regex: field=(?P<value>.+)
 //     from: pod
+//   - tag_name: k8s.node.zone # extracts value of label from nodes with key `topology.kubernetes.io/zone` and inserts it as a tag with key `k8s.node.zone`
+//     key: topology.kubernetes.io/zone
+//     from: node
 //
 // # RBAC
 //
 // The k8sattributesprocessor needs `get`, `watch` and `list` permissions on both `pods` and `namespaces` resources, for all namespaces and pods included in the configured filters.
+// The same permissions are needed on `nodes` to extract node labels and annotations, and with `owner_lookup` on `replicasets` to extract k8s.deployment.name
+// and on `jobs` to extract k8s.cronjob.name.
 // Here is an example of a `ClusterRole` to give a `ServiceAccount` the necessary permissions for all pods and namespaces in the cluster (replace `<OTEL_COL_NAMESPACE>` with a namespace where collector is deployed):
 //
 //	apiVersion: v1
@@ -113,7 +126,13 @@
 //	  name: otel-collector
 //	rules:
 //	- apiGroups: [""]
-//	  resources: ["pods", "namespaces"]
+//	  resources: ["pods", "namespaces", "nodes"]
+//	  verbs: ["get", "watch", "list"]
+//	- apiGroups: ["apps"]
+//	  resources: ["replicasets"]
+//	  verbs: ["get", "watch", "list"]
+//	- apiGroups: ["batch"]
+//	  resources: ["jobs"]
 //	  verbs: ["get", "watch", "list"]
 //	---
 //	apiVersion: rbac.authorization.k8s.io/v1
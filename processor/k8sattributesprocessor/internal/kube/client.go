@@ -23,6 +23,8 @@ import (
 
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.uber.org/zap"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -36,16 +38,19 @@ import (
 
 // WatchClient is the main interface provided by this package to a kubernetes cluster.
 type WatchClient struct {
-	m                 sync.RWMutex
-	deleteMut         sync.Mutex
-	logger            *zap.Logger
-	kc                kubernetes.Interface
-	informer          cache.SharedInformer
-	namespaceInformer cache.SharedInformer
-	replicasetRegex   *regexp.Regexp
-	cronJobRegex      *regexp.Regexp
-	deleteQueue       []deleteRequest
-	stopCh            chan struct{}
+	m                  sync.RWMutex
+	deleteMut          sync.Mutex
+	logger             *zap.Logger
+	kc                 kubernetes.Interface
+	informer           cache.SharedInformer
+	namespaceInformer  cache.SharedInformer
+	nodeInformer       cache.SharedInformer
+	replicaSetInformer cache.SharedInformer
+	jobInformer        cache.SharedInformer
+	replicasetRegex    *regexp.Regexp
+	cronJobRegex       *regexp.Regexp
+	deleteQueue        []deleteRequest
+	stopCh             chan struct{}
 
 	// A map containing Pod related data, used to associate them with resources.
 	// Key can be either an IP address or Pod UID
@@ -58,18 +63,27 @@ type WatchClient struct {
 	// A map containing Namespace related data, used to associate them with resources.
 	// Key is namespace name
 	Namespaces map[string]*Namespace
+
+	// A map containing Node related data, used to associate them with resources.
+	// Key is node name
+	Nodes map[string]*Node
+
+	// Maps containing the owners of the pods, used to resolve the workloads they belong to.
+	// Keys are the replicaset and job UIDs
+	ReplicaSets map[string]*ReplicaSet
+	Jobs        map[string]*Job
 }
 
-// Extract replicaset name from the pod name. Pod name is created using
-// format: [deployment-name]-[Random-String-For-ReplicaSet]
+// Extract deployment name from the replicaset name, for replicasets that aren't known, e.g. without owner lookup.
+// Replicaset name is created using format: [deployment-name]-[Random-String-For-ReplicaSet]
 var rRegex = regexp.MustCompile(`^(.*)-[0-9a-zA-Z]+$`)
 
-// Extract CronJob name from the Job name. Job name is created using
-// format: [cronjob-name]-[time-hash-int]
+// Extract CronJob name from the Job name, for jobs that aren't known, e.g. without owner lookup. Job name is
+// created using format: [cronjob-name]-[time-hash-int]
 var cronJobRegex = regexp.MustCompile(`^(.*)-[0-9]+$`)
 
 // New initializes a new k8s Client.
-func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, associations []Association, exclude Excludes, newClientSet APIClientsetProvider, newInformer InformerProvider, newNamespaceInformer InformerProviderNamespace) (Client, error) {
+func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, associations []Association, exclude Excludes, newClientSet APIClientsetProvider, newInformer InformerProvider, newNamespaceInformer InformerProviderNamespace, newNodeInformer InformerProviderNode, newReplicaSetInformer InformerProviderReplicaSet, newJobInformer InformerProviderJob) (Client, error) {
 	c := &WatchClient{
 		logger:          logger,
 		Rules:           rules,
@@ -84,6 +98,9 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 
 	c.Pods = map[PodIdentifier]*Pod{}
 	c.Namespaces = map[string]*Namespace{}
+	c.Nodes = map[string]*Node{}
+	c.ReplicaSets = map[string]*ReplicaSet{}
+	c.Jobs = map[string]*Job{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -111,17 +128,64 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 		newNamespaceInformer = newNamespaceSharedInformer
 	}
 
+	if newNodeInformer == nil {
+		newNodeInformer = newNodeSharedInformer
+	}
+
+	if newReplicaSetInformer == nil {
+		newReplicaSetInformer = newReplicaSetSharedInformer
+	}
+
+	if newJobInformer == nil {
+		newJobInformer = newJobSharedInformer
+	}
+
 	c.informer = newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
 	if c.extractNamespaceLabelsAnnotations() {
 		c.namespaceInformer = newNamespaceInformer(c.kc)
 	} else {
 		c.namespaceInformer = NewNoOpInformer(c.kc)
 	}
+
+	if c.extractNodeLabelsAnnotations() {
+		c.nodeInformer = newNodeInformer(c.kc, c.Filters.Node)
+	} else {
+		c.nodeInformer = NewNoOpInformer(c.kc)
+	}
+
+	if c.Rules.OwnerLookup && c.Rules.Deployment {
+		c.replicaSetInformer = newReplicaSetInformer(c.kc, c.Filters.Namespace)
+	} else {
+		c.replicaSetInformer = NewNoOpInformer(c.kc)
+	}
+
+	if c.Rules.OwnerLookup && c.Rules.CronJobName {
+		c.jobInformer = newJobInformer(c.kc, c.Filters.Namespace)
+	} else {
+		c.jobInformer = NewNoOpInformer(c.kc)
+	}
 	return c, err
 }
 
 // Start registers pod event handlers and starts watching the kubernetes cluster for pod changes.
+// The pod owners are watched first, so that the workloads of the pods can be resolved when they are added.
 func (c *WatchClient) Start() {
+	c.replicaSetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleReplicaSetAdd,
+		UpdateFunc: c.handleReplicaSetUpdate,
+		DeleteFunc: c.handleReplicaSetDelete,
+	})
+	go c.replicaSetInformer.Run(c.stopCh)
+
+	c.jobInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleJobAdd,
+		UpdateFunc: c.handleJobUpdate,
+		DeleteFunc: c.handleJobDelete,
+	})
+	go c.jobInformer.Run(c.stopCh)
+
+	c.waitForOwnersSync(ownersSyncTimeout)
+
 	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
 		UpdateFunc: c.handlePodUpdate,
@@ -135,6 +199,25 @@ func (c *WatchClient) Start() {
 		DeleteFunc: c.handleNamespaceDelete,
 	})
 	go c.namespaceInformer.Run(c.stopCh)
+
+	c.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleNodeAdd,
+		UpdateFunc: c.handleNodeUpdate,
+		DeleteFunc: c.handleNodeDelete,
+	})
+	go c.nodeInformer.Run(c.stopCh)
+}
+
+// waitForOwnersSync waits until the replicaset and job informers are synced, or the timeout expires.
+// Pods added before their owners are resolved by name, until the next pod resync.
+func (c *WatchClient) waitForOwnersSync(timeout time.Duration) {
+	timeoutCh := make(chan struct{})
+	timer := time.AfterFunc(timeout, func() { close(timeoutCh) })
+	defer timer.Stop()
+
+	if !cache.WaitForCacheSync(timeoutCh, c.replicaSetInformer.HasSynced, c.jobInformer.HasSynced) {
+		c.logger.Warn("timed out waiting for the pod owners to be synced, their workloads will be resolved by name")
+	}
 }
 
 // Stop signals the the k8s watcher/informer to stop watching for new events.
@@ -210,6 +293,95 @@ func (c *WatchClient) handleNamespaceDelete(obj interface{}) {
 	}
 }
 
+func (c *WatchClient) handleNodeAdd(obj interface{}) {
+	if node, ok := obj.(*api_v1.Node); ok {
+		c.addOrUpdateNode(node)
+	} else {
+		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleNodeUpdate(old, new interface{}) {
+	if node, ok := new.(*api_v1.Node); ok {
+		c.addOrUpdateNode(node)
+	} else {
+		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", new))
+	}
+}
+
+func (c *WatchClient) handleNodeDelete(obj interface{}) {
+	if node, ok := deletedObject(obj).(*api_v1.Node); ok {
+		c.m.Lock()
+		delete(c.Nodes, node.Name)
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleReplicaSetAdd(obj interface{}) {
+	if replicaset, ok := obj.(*apps_v1.ReplicaSet); ok {
+		c.addOrUpdateReplicaSet(replicaset)
+	} else {
+		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleReplicaSetUpdate(old, new interface{}) {
+	if replicaset, ok := new.(*apps_v1.ReplicaSet); ok {
+		c.addOrUpdateReplicaSet(replicaset)
+	} else {
+		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", new))
+	}
+}
+
+func (c *WatchClient) handleReplicaSetDelete(obj interface{}) {
+	if replicaset, ok := deletedObject(obj).(*apps_v1.ReplicaSet); ok {
+		// The attributes of the pods are extracted when they are added or updated, so the replicaset can be
+		// forgotten right away.
+		c.m.Lock()
+		delete(c.ReplicaSets, string(replicaset.UID))
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleJobAdd(obj interface{}) {
+	if job, ok := obj.(*batch_v1.Job); ok {
+		c.addOrUpdateJob(job)
+	} else {
+		c.logger.Error("object received was not of type batch_v1.Job", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleJobUpdate(old, new interface{}) {
+	if job, ok := new.(*batch_v1.Job); ok {
+		c.addOrUpdateJob(job)
+	} else {
+		c.logger.Error("object received was not of type batch_v1.Job", zap.Any("received", new))
+	}
+}
+
+func (c *WatchClient) handleJobDelete(obj interface{}) {
+	if job, ok := deletedObject(obj).(*batch_v1.Job); ok {
+		c.m.Lock()
+		delete(c.Jobs, string(job.UID))
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type batch_v1.Job", zap.Any("received", obj))
+	}
+}
+
+// deletedObject returns the object of a delete event, unwrapping the tombstone
+// received when the informer missed the deletion and only noticed it when relisting.
+func deletedObject(obj interface{}) interface{} {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return tombstone.Obj
+	}
+	return obj
+}
+
 func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Duration) {
 	// This loop runs after N seconds and deletes pods from cache.
 	// It iterates over the delete queue and deletes all that aren't
@@ -276,6 +448,31 @@ func (c *WatchClient) GetNamespace(namespace string) (*Namespace, bool) {
 	return nil, false
 }
 
+// GetNode takes a node name and returns the node object the node name is associated with.
+func (c *WatchClient) GetNode(nodeName string) (*Node, bool) {
+	c.m.RLock()
+	node, ok := c.Nodes[nodeName]
+	c.m.RUnlock()
+	if ok {
+		return node, ok
+	}
+	return nil, false
+}
+
+func (c *WatchClient) getReplicaSet(uid string) (*ReplicaSet, bool) {
+	c.m.RLock()
+	replicaset, ok := c.ReplicaSets[uid]
+	c.m.RUnlock()
+	return replicaset, ok
+}
+
+func (c *WatchClient) getJob(uid string) (*Job, bool) {
+	c.m.RLock()
+	job, ok := c.Jobs[uid]
+	c.m.RUnlock()
+	return job, ok
+}
+
 func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 	tags := map[string]string{}
 	if c.Rules.PodName {
@@ -313,10 +510,16 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 					tags[conventions.AttributeK8SReplicaSetName] = ref.Name
 				}
 				if c.Rules.Deployment {
-					// format: [deployment-name]-[Random-String-For-ReplicaSet]
-					parts := c.replicasetRegex.FindStringSubmatch(ref.Name)
-					if len(parts) == 2 {
-						tags[conventions.AttributeK8SDeploymentName] = parts[1]
+					if replicaset, ok := c.getReplicaSet(string(ref.UID)); ok {
+						if replicaset.Deployment.Name != "" {
+							tags[conventions.AttributeK8SDeploymentName] = replicaset.Deployment.Name
+						}
+					} else {
+						// format: [deployment-name]-[Random-String-For-ReplicaSet]
+						parts := c.replicasetRegex.FindStringSubmatch(ref.Name)
+						if len(parts) == 2 {
+							tags[conventions.AttributeK8SDeploymentName] = parts[1]
+						}
 					}
 				}
 			case "DaemonSet":
@@ -335,9 +538,15 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 				}
 			case "Job":
 				if c.Rules.CronJobName {
-					parts := c.cronJobRegex.FindStringSubmatch(ref.Name)
-					if len(parts) == 2 {
-						tags[conventions.AttributeK8SCronJobName] = parts[1]
+					if job, ok := c.getJob(string(ref.UID)); ok {
+						if job.CronJob.Name != "" {
+							tags[conventions.AttributeK8SCronJobName] = job.CronJob.Name
+						}
+					} else {
+						parts := c.cronJobRegex.FindStringSubmatch(ref.Name)
+						if len(parts) == 2 {
+							tags[conventions.AttributeK8SCronJobName] = parts[1]
+						}
 					}
 				}
 				if c.Rules.JobUID {
@@ -420,10 +629,25 @@ func (c *WatchClient) extractNamespaceAttributes(namespace *api_v1.Namespace) ma
 	return tags
 }
 
+func (c *WatchClient) extractNodeAttributes(node *api_v1.Node) map[string]string {
+	tags := map[string]string{}
+
+	for _, r := range c.Rules.Labels {
+		r.extractFromNodeMetadata(node.Labels, tags, "k8s.node.labels.%s")
+	}
+
+	for _, r := range c.Rules.Annotations {
+		r.extractFromNodeMetadata(node.Annotations, tags, "k8s.node.annotations.%s")
+	}
+
+	return tags
+}
+
 func (c *WatchClient) podFromAPI(pod *api_v1.Pod) *Pod {
 	newPod := &Pod{
 		Name:        pod.Name,
 		Namespace:   pod.GetNamespace(),
+		NodeName:    pod.Spec.NodeName,
 		Address:     pod.Status.PodIP,
 		HostNetwork: pod.Spec.HostNetwork,
 		PodUID:      string(pod.UID),
@@ -620,6 +844,60 @@ func (c *WatchClient) addOrUpdateNamespace(namespace *api_v1.Namespace) {
 	c.m.Unlock()
 }
 
+func (c *WatchClient) addOrUpdateNode(node *api_v1.Node) {
+	newNode := &Node{
+		Name:    node.Name,
+		NodeUID: string(node.UID),
+	}
+	newNode.Attributes = c.extractNodeAttributes(node)
+
+	c.m.Lock()
+	if node.Name != "" {
+		c.Nodes[node.Name] = newNode
+	}
+	c.m.Unlock()
+}
+
+func (c *WatchClient) addOrUpdateReplicaSet(replicaset *apps_v1.ReplicaSet) {
+	newReplicaSet := &ReplicaSet{
+		Name:      replicaset.Name,
+		Namespace: replicaset.Namespace,
+		UID:       string(replicaset.UID),
+	}
+	for _, ref := range replicaset.OwnerReferences {
+		if ref.Kind == "Deployment" {
+			newReplicaSet.Deployment = Deployment{Name: ref.Name, UID: string(ref.UID)}
+			break
+		}
+	}
+
+	c.m.Lock()
+	if replicaset.UID != "" {
+		c.ReplicaSets[string(replicaset.UID)] = newReplicaSet
+	}
+	c.m.Unlock()
+}
+
+func (c *WatchClient) addOrUpdateJob(job *batch_v1.Job) {
+	newJob := &Job{
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		UID:       string(job.UID),
+	}
+	for _, ref := range job.OwnerReferences {
+		if ref.Kind == "CronJob" {
+			newJob.CronJob = CronJob{Name: ref.Name, UID: string(ref.UID)}
+			break
+		}
+	}
+
+	c.m.Lock()
+	if job.UID != "" {
+		c.Jobs[string(job.UID)] = newJob
+	}
+	c.m.Unlock()
+}
+
 func (c *WatchClient) extractNamespaceLabelsAnnotations() bool {
 	for _, r := range c.Rules.Labels {
 		if r.From == MetadataFromNamespace {
@@ -636,6 +914,22 @@ func (c *WatchClient) extractNamespaceLabelsAnnotations() bool {
 	return false
 }
 
+func (c *WatchClient) extractNodeLabelsAnnotations() bool {
+	for _, r := range c.Rules.Labels {
+		if r.From == MetadataFromNode {
+			return true
+		}
+	}
+
+	for _, r := range c.Rules.Annotations {
+		if r.From == MetadataFromNode {
+			return true
+		}
+	}
+
+	return false
+}
+
 func needContainerAttributes(rules ExtractionRules) bool {
 	return rules.ContainerImageName || rules.ContainerImageTag || rules.ContainerID
 }
@@ -26,6 +26,8 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -33,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
@@ -128,12 +131,12 @@ func namespaceAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj in
 }
 
 func TestDefaultClientset(t *testing.T) {
-	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil)
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil, nil, nil, nil)
 	assert.Error(t, err)
 	assert.Equal(t, "invalid authType for kubernetes: ", err.Error())
 	assert.Nil(t, c)
 
-	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil)
+	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil, nil, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
 }
@@ -149,6 +152,9 @@ func TestBadFilters(t *testing.T) {
 		newFakeAPIClientset,
 		NewFakeInformer,
 		NewFakeNamespaceInformer,
+		NewFakeNodeInformer,
+		NewFakeReplicaSetInformer,
+		NewFakeJobInformer,
 	)
 	assert.Error(t, err)
 	assert.Nil(t, c)
@@ -185,7 +191,7 @@ func TestConstructorErrors(t *testing.T) {
 			gotAPIConfig = c
 			return nil, fmt.Errorf("error creating k8s client")
 		}
-		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer)
+		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer, NewFakeNodeInformer, NewFakeReplicaSetInformer, NewFakeJobInformer)
 		assert.Nil(t, c)
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "error creating k8s client")
@@ -1337,6 +1343,251 @@ func TestExtractNamespaceLabelsAnnotations(t *testing.T) {
 	}
 }
 
+func TestNodeExtractionRules(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+
+	node := &api_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "node1",
+			UID:  "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+			Labels: map[string]string{
+				"cloud.google.com/gke-nodepool": "pool-1",
+				"topology.kubernetes.io/zone":   "us-east1-b",
+			},
+			Annotations: map[string]string{
+				"annotation1": "av1",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		rules      ExtractionRules
+		attributes map[string]string
+	}{{
+		name:       "no-rules",
+		rules:      ExtractionRules{},
+		attributes: nil,
+	}, {
+		name: "labels",
+		rules: ExtractionRules{
+			Annotations: []FieldExtractionRule{{
+				Name: "a1",
+				Key:  "annotation1",
+				From: MetadataFromNode,
+			},
+			},
+			Labels: []FieldExtractionRule{{
+				Name: "zone",
+				Key:  "topology.kubernetes.io/zone",
+				From: MetadataFromNode,
+			}, {
+				Name: "pod-label",
+				Key:  "topology.kubernetes.io/zone",
+				From: MetadataFromPod,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"zone": "us-east1-b",
+			"a1":   "av1",
+		},
+	}, {
+		name: "all-labels",
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				KeyRegex: regexp.MustCompile("^(?:cloud.google.com/.*)$"),
+				From:     MetadataFromNode,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"k8s.node.labels.cloud.google.com/gke-nodepool": "pool-1",
+		},
+	},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Rules = tc.rules
+			c.handleNodeAdd(node)
+			n, ok := c.GetNode(node.Name)
+			require.True(t, ok)
+			assert.Equal(t, "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee", n.NodeUID)
+
+			assert.Equal(t, len(tc.attributes), len(n.Attributes))
+			for k, v := range tc.attributes {
+				got, ok := n.Attributes[k]
+				assert.True(t, ok)
+				assert.Equal(t, v, got)
+			}
+		})
+	}
+
+	c.handleNodeDelete(cache.DeletedFinalStateUnknown{Key: node.Name, Obj: node})
+	_, ok := c.GetNode(node.Name)
+	assert.False(t, ok)
+}
+
+func TestExtractionRulesWithOwners(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{Deployment: true, CronJobName: true, OwnerLookup: true}, Filters{})
+
+	c.handleReplicaSetAdd(&apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "auth-service-66f5996c7c",
+			Namespace: "ns1",
+			UID:       "207ea729-c779-401d-8347-008ecbc137e3",
+			OwnerReferences: []meta_v1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "auth-deployment",
+				UID:        "ffff-gggg-hhhh",
+			}},
+		},
+	})
+	// Owned by a custom controller, so there is no deployment.
+	c.handleReplicaSetAdd(&apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "custom-service-7b5d8c9f4d",
+			Namespace: "ns1",
+			UID:       "9b3c1e5a-2f4d-4c8e-9a1b-3d5e7f9a1b3c",
+			OwnerReferences: []meta_v1.OwnerReference{{
+				APIVersion: "example.com/v1",
+				Kind:       "Rollout",
+				Name:       "custom-service",
+				UID:        "iiii-jjjj-kkkk",
+			}},
+		},
+	})
+	c.handleJobAdd(&batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "auth-cronjob-27667920",
+			Namespace: "ns1",
+			UID:       "59f27ac1-5c71-42e5-abe9-2c499d603706",
+			OwnerReferences: []meta_v1.OwnerReference{{
+				APIVersion: "batch/v1",
+				Kind:       "CronJob",
+				Name:       "auth-backup",
+				UID:        "llll-mmmm-nnnn",
+			}},
+		},
+	})
+	require.Len(t, c.ReplicaSets, 2)
+	require.Len(t, c.Jobs, 1)
+
+	testCases := []struct {
+		name       string
+		owner      meta_v1.OwnerReference
+		attributes map[string]string
+	}{{
+		name: "deployment",
+		owner: meta_v1.OwnerReference{
+			Kind: "ReplicaSet",
+			Name: "auth-service-66f5996c7c",
+			UID:  "207ea729-c779-401d-8347-008ecbc137e3",
+		},
+		attributes: map[string]string{
+			"k8s.deployment.name": "auth-deployment",
+		},
+	}, {
+		name: "custom-controller",
+		owner: meta_v1.OwnerReference{
+			Kind: "ReplicaSet",
+			Name: "custom-service-7b5d8c9f4d",
+			UID:  "9b3c1e5a-2f4d-4c8e-9a1b-3d5e7f9a1b3c",
+		},
+		attributes: map[string]string{},
+	}, {
+		name: "unknown-replicaset",
+		owner: meta_v1.OwnerReference{
+			Kind: "ReplicaSet",
+			Name: "other-service-5f6d7c8b9a",
+			UID:  "0a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d",
+		},
+		attributes: map[string]string{
+			"k8s.deployment.name": "other-service",
+		},
+	}, {
+		name: "cronjob",
+		owner: meta_v1.OwnerReference{
+			Kind: "Job",
+			Name: "auth-cronjob-27667920",
+			UID:  "59f27ac1-5c71-42e5-abe9-2c499d603706",
+		},
+		attributes: map[string]string{
+			"k8s.cronjob.name": "auth-backup",
+		},
+	},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:            "pod1",
+					Namespace:       "ns1",
+					OwnerReferences: []meta_v1.OwnerReference{tc.owner},
+				},
+				Status: api_v1.PodStatus{
+					PodIP: "1.1.1.1",
+				},
+			}
+			c.handlePodAdd(pod)
+			p, ok := c.GetPod(newPodIdentifier("connection", "", pod.Status.PodIP))
+			require.True(t, ok)
+			assert.Equal(t, tc.attributes, p.Attributes)
+		})
+	}
+
+	c.handleReplicaSetDelete(&apps_v1.ReplicaSet{ObjectMeta: meta_v1.ObjectMeta{UID: "207ea729-c779-401d-8347-008ecbc137e3"}})
+	// The job deletion was missed by the informer, which only noticed it when relisting.
+	c.handleJobDelete(cache.DeletedFinalStateUnknown{
+		Key: "ns1/auth-cronjob-27667920",
+		Obj: &batch_v1.Job{ObjectMeta: meta_v1.ObjectMeta{UID: "59f27ac1-5c71-42e5-abe9-2c499d603706"}},
+	})
+	assert.Len(t, c.ReplicaSets, 1)
+	assert.Len(t, c.Jobs, 0)
+}
+
+func TestOwnerInformers(t *testing.T) {
+	c, _ := newTestClient(t)
+	assert.IsType(t, &NoOpInformer{}, c.replicaSetInformer)
+	assert.IsType(t, &NoOpInformer{}, c.jobInformer)
+	assert.IsType(t, &NoOpInformer{}, c.nodeInformer)
+
+	// The owners are only watched with owner lookup.
+	c, _ = newTestClientWithRulesAndFilters(t, ExtractionRules{Deployment: true, CronJobName: true}, Filters{})
+	assert.IsType(t, &NoOpInformer{}, c.replicaSetInformer)
+	assert.IsType(t, &NoOpInformer{}, c.jobInformer)
+
+	c, _ = newTestClientWithRulesAndFilters(t, ExtractionRules{
+		Deployment:  true,
+		CronJobName: true,
+		OwnerLookup: true,
+		Labels:      []FieldExtractionRule{{Name: "zone", Key: "topology.kubernetes.io/zone", From: MetadataFromNode}},
+	}, Filters{Namespace: "ns1"})
+	require.IsType(t, &FakeInformer{}, c.replicaSetInformer)
+	assert.Equal(t, "ns1", c.replicaSetInformer.(*FakeInformer).namespace)
+	require.IsType(t, &FakeInformer{}, c.jobInformer)
+	assert.Equal(t, "ns1", c.jobInformer.(*FakeInformer).namespace)
+	assert.IsType(t, &FakeInformer{}, c.nodeInformer)
+}
+
+func TestOwnerHandlersWrongType(t *testing.T) {
+	c, logs := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+	assert.Equal(t, 0, logs.Len())
+
+	for _, handler := range []func(interface{}){
+		c.handleNodeAdd, c.handleNodeDelete,
+		c.handleReplicaSetAdd, c.handleReplicaSetDelete,
+		c.handleJobAdd, c.handleJobDelete,
+	} {
+		handler(1)
+	}
+	c.handleNodeUpdate(nil, 1)
+	c.handleReplicaSetUpdate(nil, 1)
+	c.handleJobUpdate(nil, 1)
+	assert.Equal(t, 9, logs.Len())
+}
+
 func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters) (*WatchClient, *observer.ObservedLogs) {
 	observedLogger, logs := observer.New(zapcore.WarnLevel)
 	logger := zap.New(observedLogger)
@@ -1363,7 +1614,7 @@ func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters
 			},
 		},
 	}
-	c, err := New(logger, k8sconfig.APIConfig{}, e, f, associations, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer)
+	c, err := New(logger, k8sconfig.APIConfig{}, e, f, associations, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeNodeInformer, NewFakeReplicaSetInformer, NewFakeJobInformer)
 	require.NoError(t, err)
 	return c.(*WatchClient), logs
 }
@@ -88,6 +88,35 @@ func (f *FakeNamespaceInformer) GetController() cache.Controller {
 	return f.FakeController
 }
 
+func NewFakeNodeInformer(
+	_ kubernetes.Interface,
+	_ string,
+) cache.SharedInformer {
+	return &FakeInformer{
+		FakeController: &FakeController{},
+	}
+}
+
+func NewFakeReplicaSetInformer(
+	_ kubernetes.Interface,
+	namespace string,
+) cache.SharedInformer {
+	return &FakeInformer{
+		FakeController: &FakeController{},
+		namespace:      namespace,
+	}
+}
+
+func NewFakeJobInformer(
+	_ kubernetes.Interface,
+	namespace string,
+) cache.SharedInformer {
+	return &FakeInformer{
+		FakeController: &FakeController{},
+		namespace:      namespace,
+	}
+}
+
 type FakeController struct {
 	sync.Mutex
 	stopped bool
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 	api_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/tools/cache"
 
@@ -44,6 +45,53 @@ func Test_newSharedNamespaceInformer(t *testing.T) {
 	assert.NotNil(t, informer)
 }
 
+func Test_newSharedNodeInformer(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	informer := newNodeSharedInformer(client, "node1")
+	assert.NotNil(t, informer)
+}
+
+func Test_newSharedReplicaSetInformer(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	informer := newReplicaSetSharedInformer(client, "testns")
+	assert.NotNil(t, informer)
+}
+
+func Test_newSharedJobInformer(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	informer := newJobSharedInformer(client, "testns")
+	assert.NotNil(t, informer)
+}
+
+func Test_ownerInformerListAndWatchFuncs(t *testing.T) {
+	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	fs := fields.OneTermEqualSelector(nodeNameField, "node1")
+
+	for name, listFunc := range map[string]cache.ListFunc{
+		"node":       nodeInformerListFunc(c, fs),
+		"replicaset": replicasetInformerListFunc(c, "test-ns"),
+		"job":        jobInformerListFunc(c, "test-ns"),
+	} {
+		obj, err := listFunc(metav1.ListOptions{})
+		assert.NoError(t, err, name)
+		assert.NotNil(t, obj, name)
+	}
+
+	for name, watchFunc := range map[string]cache.WatchFunc{
+		"node":       nodeInformerWatchFunc(c, fs),
+		"replicaset": replicasetInformerWatchFunc(c, "test-ns"),
+		"job":        jobInformerWatchFunc(c, "test-ns"),
+	} {
+		obj, err := watchFunc(metav1.ListOptions{})
+		assert.NoError(t, err, name)
+		assert.NotNil(t, obj, name)
+	}
+}
+
 func Test_informerListFuncWithSelectors(t *testing.T) {
 	ls, fs, err := selectorsFromFilters(Filters{
 		Fields: []FieldFilter{
@@ -29,13 +29,16 @@ import (
 
 const (
 	podNodeField            = "spec.nodeName"
+	nodeNameField           = "metadata.name"
 	ignoreAnnotation string = "opentelemetry.io/k8s-processor/ignore"
 	tagNodeName             = "k8s.node.name"
 	tagStartTime            = "k8s.pod.start_time"
 	// MetadataFromPod is used to specify to extract metadata/labels/annotations from pod
 	MetadataFromPod = "pod"
 	// MetadataFromNamespace is used to specify to extract metadata/labels/annotations from namespace
-	MetadataFromNamespace  = "namespace"
+	MetadataFromNamespace = "namespace"
+	// MetadataFromNode is used to specify to extract metadata/labels/annotations from node
+	MetadataFromNode       = "node"
 	PodIdentifierMaxLength = 4
 
 	ResourceSource   = "resource_attribute"
@@ -91,18 +94,20 @@ var (
 	// TODO: move these to config with default values
 	defaultPodDeleteGracePeriod = time.Second * 120
 	watchSyncPeriod             = time.Minute * 5
+	ownersSyncTimeout           = time.Second * 10
 )
 
 // Client defines the main interface that allows querying pods by metadata.
 type Client interface {
 	GetPod(PodIdentifier) (*Pod, bool)
 	GetNamespace(string) (*Namespace, bool)
+	GetNode(string) (*Node, bool)
 	Start()
 	Stop()
 }
 
 // ClientProvider defines a func type that returns a new Client.
-type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, APIClientsetProvider, InformerProvider, InformerProviderNamespace) (Client, error)
+type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, APIClientsetProvider, InformerProvider, InformerProviderNamespace, InformerProviderNode, InformerProviderReplicaSet, InformerProviderJob) (Client, error)
 
 // APIClientsetProvider defines a func type that initializes and return a new kubernetes
 // Clientset object.
@@ -117,6 +122,7 @@ type Pod struct {
 	StartTime   *metav1.Time
 	Ignore      bool
 	Namespace   string
+	NodeName    string
 	HostNetwork bool
 
 	// Containers is a map of container name to Container struct.
@@ -148,6 +154,41 @@ type Namespace struct {
 	DeletedAt    time.Time
 }
 
+// Node represents a kubernetes node.
+type Node struct {
+	Name       string
+	NodeUID    string
+	Attributes map[string]string
+}
+
+// ReplicaSet represents a kubernetes replicaset, along with the deployment owning it, if any.
+type ReplicaSet struct {
+	Name       string
+	Namespace  string
+	UID        string
+	Deployment Deployment
+}
+
+// Deployment represents a kubernetes deployment.
+type Deployment struct {
+	Name string
+	UID  string
+}
+
+// Job represents a kubernetes job, along with the cronjob owning it, if any.
+type Job struct {
+	Name      string
+	Namespace string
+	UID       string
+	CronJob   CronJob
+}
+
+// CronJob represents a kubernetes cronjob.
+type CronJob struct {
+	Name string
+	UID  string
+}
+
 type deleteRequest struct {
 	// id is identifier (IP address or Pod UID) of pod to remove from pods map
 	id PodIdentifier
@@ -201,6 +242,9 @@ type ExtractionRules struct {
 	ContainerID        bool
 	ContainerImageName bool
 	ContainerImageTag  bool
+	// OwnerLookup watches the replicasets and jobs owning the pods to resolve their deployments and cronjobs,
+	// instead of deriving them from the owner names.
+	OwnerLookup bool
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
@@ -220,9 +264,10 @@ type FieldExtractionRule struct {
 	// Full value is extracted when no regexp is provided.
 	Regex *regexp.Regexp
 	// From determines the kubernetes object the field should be retrieved from.
-	// Currently only two values are supported,
+	// Currently only three values are supported,
 	//  - pod
 	//  - namespace
+	//  - node
 	From string
 }
 
@@ -239,6 +284,12 @@ func (r *FieldExtractionRule) extractFromNamespaceMetadata(metadata map[string]s
 	}
 }
 
+func (r *FieldExtractionRule) extractFromNodeMetadata(metadata map[string]string, tags map[string]string, formatter string) {
+	if r.From == MetadataFromNode {
+		r.extractFromMetadata(metadata, tags, formatter)
+	}
+}
+
 func (r *FieldExtractionRule) extractFromMetadata(metadata map[string]string, tags map[string]string, formatter string) {
 	if r.KeyRegex != nil {
 		for k, v := range metadata {
@@ -67,6 +67,14 @@ func TestWithPassthrough(t *testing.T) {
 	assert.True(t, p.passthroughMode)
 }
 
+func TestWithExtractOwnerLookup(t *testing.T) {
+	p := &kubernetesprocessor{}
+	assert.NoError(t, withExtractMetadata()(p))
+	assert.False(t, p.rules.OwnerLookup)
+	assert.NoError(t, withExtractOwnerLookup(true)(p))
+	assert.True(t, p.rules.OwnerLookup)
+}
+
 func TestWithExtractAnnotations(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -131,6 +139,23 @@ func TestWithExtractAnnotations(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"basic-node-default-tag-name",
+			[]FieldExtractConfig{
+				{
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			[]kube.FieldExtractionRule{
+				{
+					Name: "k8s.node.annotations.key1",
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			"",
+		},
 		{
 			"basic-pod-keyregex",
 			[]FieldExtractConfig{
@@ -248,6 +273,23 @@ func TestWithExtractLabels(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"basic-node-default-tag-name",
+			[]FieldExtractConfig{
+				{
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			[]kube.FieldExtractionRule{
+				{
+					Name: "k8s.node.labels.key1",
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			"",
+		},
 		{
 			"basic-pod-keyregex",
 			[]FieldExtractConfig{
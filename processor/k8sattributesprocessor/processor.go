@@ -51,7 +51,7 @@ func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kub
 		kubeClient = kube.New
 	}
 	if !kp.passthroughMode {
-		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, nil, nil)
+		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, nil, nil, nil, nil, nil)
 		if err != nil {
 			return err
 		}
@@ -121,8 +121,10 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 		return
 	}
 
+	var nodeName string
 	if podIdentifierValue.IsNotEmpty() {
 		if pod, ok := kp.kc.GetPod(podIdentifierValue); ok {
+			nodeName = pod.NodeName
 			kp.logger.Debug("getting the pod", zap.Any("pod", pod))
 
 			for key, val := range pod.Attributes {
@@ -143,6 +145,18 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 			}
 		}
 	}
+
+	if nodeName == "" {
+		nodeName = stringAttributeFromMap(resource.Attributes(), conventions.AttributeK8SNodeName)
+	}
+	if nodeName != "" {
+		attrsToAdd := kp.getAttributesForPodsNode(nodeName)
+		for key, val := range attrsToAdd {
+			if _, found := resource.Attributes().Get(key); !found {
+				resource.Attributes().PutString(key, val)
+			}
+		}
+	}
 }
 
 // addContainerAttributes looks if pod has any container identifiers and adds additional container attributes
@@ -190,6 +204,14 @@ func (kp *kubernetesprocessor) getAttributesForPodsNamespace(namespace string) m
 	return ns.Attributes
 }
 
+func (kp *kubernetesprocessor) getAttributesForPodsNode(nodeName string) map[string]string {
+	node, ok := kp.kc.GetNode(nodeName)
+	if !ok {
+		return nil
+	}
+	return node.Attributes
+}
+
 // intFromAttribute extracts int value from an attribute stored as string or int
 func intFromAttribute(val pcommon.Value) (int, error) {
 	switch val.Type() {
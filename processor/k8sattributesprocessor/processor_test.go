@@ -236,7 +236,7 @@ func TestProcessorBadConfig(t *testing.T) {
 }
 
 func TestProcessorBadClientProvider(t *testing.T) {
-	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace) (kube.Client, error) {
+	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace, _ kube.InformerProviderNode, _ kube.InformerProviderReplicaSet, _ kube.InformerProviderJob) (kube.Client, error) {
 		return nil, fmt.Errorf("bad client error")
 	}
 
@@ -742,6 +742,64 @@ func TestProcessorAddLabels(t *testing.T) {
 	}
 }
 
+func TestProcessorAddNodeAttributes(t *testing.T) {
+	m := newMultiTest(
+		t,
+		NewFactory().CreateDefaultConfig(),
+		nil,
+	)
+
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.podAssociations = []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "connection",
+					},
+				},
+			},
+		}
+		kp.kc.(*fakeClient).Pods[kube.PodIdentifier{kube.PodIdentifierAttributeFromConnection("1.1.1.1")}] = &kube.Pod{
+			NodeName:   "node1",
+			Attributes: map[string]string{"k8s.pod.name": "pod1"},
+		}
+		kp.kc.(*fakeClient).Nodes = map[string]*kube.Node{
+			"node1": {Name: "node1", Attributes: map[string]string{"k8s.node.labels.zone": "us-east1-b"}},
+			"node2": {Name: "node2", Attributes: map[string]string{"k8s.node.labels.zone": "us-east1-c"}},
+		}
+	})
+
+	// The node of the pod is used when the pod is identified.
+	ctx := client.NewContext(context.Background(), client.Info{
+		Addr: &net.IPAddr{
+			IP: net.ParseIP("1.1.1.1"),
+		},
+	})
+	m.testConsume(ctx, generateTraces(), generateMetrics(), generateLogs(), func(err error) {
+		assert.NoError(t, err)
+	})
+
+	// The node name resource attribute is used otherwise.
+	traces := generateTraces()
+	traces.ResourceSpans().At(0).Resource().Attributes().PutString("k8s.node.name", "node2")
+	metrics := generateMetrics()
+	metrics.ResourceMetrics().At(0).Resource().Attributes().PutString("k8s.node.name", "node2")
+	logs := generateLogs()
+	logs.ResourceLogs().At(0).Resource().Attributes().PutString("k8s.node.name", "node2")
+	m.testConsume(context.Background(), traces, metrics, logs, func(err error) {
+		assert.NoError(t, err)
+	})
+
+	m.assertBatchesLen(2)
+	m.assertResource(0, func(res pcommon.Resource) {
+		assertResourceHasStringAttribute(t, res, "k8s.pod.name", "pod1")
+		assertResourceHasStringAttribute(t, res, "k8s.node.labels.zone", "us-east1-b")
+	})
+	m.assertResource(1, func(res pcommon.Resource) {
+		assertResourceHasStringAttribute(t, res, "k8s.node.labels.zone", "us-east1-c")
+	})
+}
+
 func TestProcessorAddContainerAttributes(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -15,14 +15,21 @@
 package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
 
 import (
+	"fmt"
+	"strings"
+
 	"go.opentelemetry.io/collector/config"
 )
 
+// severityLevels are the log severity levels that can be configured in SeveritySamplingPercentage,
+// in the order of the log data model severity number ranges.
+var severityLevels = []string{"trace", "debug", "info", "warn", "error", "fatal"}
+
 // Config has the configuration guiding the trace sampler processor.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
-	// SamplingPercentage is the percentage rate at which traces are going to be sampled. Defaults to zero, i.e.: no sample.
+	// SamplingPercentage is the percentage rate at which traces and logs are going to be sampled. Defaults to zero, i.e.: no sample.
 	// Values greater or equal 100 are treated as "sample all traces".
 	SamplingPercentage float32 `mapstructure:"sampling_percentage"`
 
@@ -30,11 +37,35 @@ type Config struct {
 	// have different sampling rates: if they use the same seed all passing one layer may pass the other even if they have
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
+
+	// FromAttribute is the name of the log record attribute whose value is hashed to sample the log records
+	// without a trace ID. Log records with a trace ID are always sampled by it, so that they get the same
+	// decision as their trace. Log records with neither are sampled randomly.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// SeveritySamplingPercentage overrides SamplingPercentage for the log records of the given severity levels,
+	// one of "trace", "debug", "info", "warn", "error" and "fatal".
+	SeveritySamplingPercentage map[string]float32 `mapstructure:"severity_sampling_percentage"`
 }
 
 var _ config.Processor = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
+	for severity := range cfg.SeveritySamplingPercentage {
+		if severityLevel(severity) < 0 {
+			return fmt.Errorf("invalid severity %q in severity_sampling_percentage, must be one of %s", severity, strings.Join(severityLevels, ", "))
+		}
+	}
 	return nil
 }
+
+// severityLevel returns the index of the given severity level, or -1 if it's unknown.
+func severityLevel(severity string) int {
+	for i, level := range severityLevels {
+		if strings.EqualFold(severity, level) {
+			return i
+		}
+	}
+	return -1
+}
@@ -43,6 +43,18 @@ func TestLoadConfig(t *testing.T) {
 			id:       config.NewComponentIDWithName(typeStr, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "logs"),
+			expected: &Config{
+				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				SamplingPercentage: 15.3,
+				FromAttribute:      "request.id",
+				SeveritySamplingPercentage: map[string]float32{
+					"debug": 1,
+					"error": 100,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -62,3 +74,12 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SeveritySamplingPercentage = map[string]float32{"warn": 10, "Fatal": 100}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.SeveritySamplingPercentage = map[string]float32{"verbose": 10}
+	assert.EqualError(t, cfg.Validate(), `invalid severity "verbose" in severity_sampling_percentage, must be one of trace, debug, info, warn, error, fatal`)
+}
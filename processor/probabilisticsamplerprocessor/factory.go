@@ -34,7 +34,9 @@ func NewFactory() component.ProcessorFactory {
 	return component.NewProcessorFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithTracesProcessor(createTracesProcessor, stability))
+		component.WithTracesProcessor(createTracesProcessor, stability),
+		component.WithMetricsProcessor(createMetricsProcessor, component.StabilityLevelAlpha),
+		component.WithLogsProcessor(createLogsProcessor, component.StabilityLevelAlpha))
 }
 
 func createDefaultConfig() config.Processor {
@@ -52,3 +54,22 @@ func createTracesProcessor(
 ) (component.TracesProcessor, error) {
 	return newTracesProcessor(ctx, set, cfg.(*Config), nextConsumer)
 }
+
+// createMetricsProcessor creates a metrics processor sampling the exemplars based on this config.
+func createMetricsProcessor(
+	ctx context.Context,
+	set component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	return newMetricsProcessor(ctx, set, cfg.(*Config), nextConsumer)
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Logs,
+) (component.LogsProcessor, error) {
+	return newLogsProcessor(ctx, set, cfg.(*Config), nextConsumer)
+}
@@ -36,4 +36,12 @@ func TestCreateProcessor(t *testing.T) {
 	tp, err := createTracesProcessor(context.Background(), set, cfg, consumertest.NewNop())
 	assert.NotNil(t, tp)
 	assert.NoError(t, err, "cannot create trace processor")
+
+	mp, err := createMetricsProcessor(context.Background(), set, cfg, consumertest.NewNop())
+	assert.NotNil(t, mp)
+	assert.NoError(t, err, "cannot create metrics processor")
+
+	lp, err := createLogsProcessor(context.Background(), set, cfg, consumertest.NewNop())
+	assert.NotNil(t, lp)
+	assert.NoError(t, err, "cannot create logs processor")
 }
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+	"math/rand"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type logsamplerprocessor struct {
+	scaledSamplingRate uint32
+	// scaledSeverityRates holds the sampling rate of each severity level, indexed as severityLevels
+	scaledSeverityRates []uint32
+	hashSeed            uint32
+	fromAttribute       string
+	// randomHash is used for the log records without a trace ID or the configured attribute
+	randomHash func() uint32
+}
+
+// newLogsProcessor returns a processor.LogsProcessor that will perform head sampling according to the given
+// configuration.
+func newLogsProcessor(ctx context.Context, set component.ProcessorCreateSettings, cfg *Config, nextConsumer consumer.Logs) (component.LogsProcessor, error) {
+	return processorhelper.NewLogsProcessor(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		newLogSampler(cfg).processLogs,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+func newLogSampler(cfg *Config) *logsamplerprocessor {
+	lsp := &logsamplerprocessor{
+		scaledSamplingRate:  uint32(cfg.SamplingPercentage * percentageScaleFactor),
+		scaledSeverityRates: make([]uint32, len(severityLevels)),
+		hashSeed:            cfg.HashSeed,
+		fromAttribute:       cfg.FromAttribute,
+		randomHash:          rand.Uint32,
+	}
+	for i := range lsp.scaledSeverityRates {
+		lsp.scaledSeverityRates[i] = lsp.scaledSamplingRate
+	}
+	for severity, percentage := range cfg.SeveritySamplingPercentage {
+		lsp.scaledSeverityRates[severityLevel(severity)] = uint32(percentage * percentageScaleFactor)
+	}
+	return lsp
+}
+
+func (lsp *logsamplerprocessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				switch parseSamplingPriority(lr.Attributes()) {
+				case doNotSampleSpan:
+					return true
+				case mustSampleSpan:
+					return false
+				}
+				return lsp.hash(lr)&bitMaskHashBuckets >= lsp.samplingRate(lr.SeverityNumber())
+			})
+			// Filter out empty ScopeLogs
+			return sl.LogRecords().Len() == 0
+		})
+		// Filter out empty ResourceLogs
+		return rl.ScopeLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// hash returns the hash of the trace ID of the log record, so that it gets the same decision as its trace,
+// or the hash of the configured attribute if it has no trace ID. Unlike the spans, whose empty trace IDs are
+// hashed like the others, a log record with an empty trace ID isn't part of a trace.
+func (lsp *logsamplerprocessor) hash(lr plog.LogRecord) uint32 {
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		return hash(traceID[:], lsp.hashSeed)
+	}
+	if lsp.fromAttribute != "" {
+		if value, ok := lr.Attributes().Get(lsp.fromAttribute); ok {
+			return hash([]byte(value.AsString()), lsp.hashSeed)
+		}
+	}
+	return lsp.randomHash()
+}
+
+// samplingRate returns the scaled sampling rate of the given severity, the severity numbers being grouped
+// in ranges of four per level by the log data model.
+func (lsp *logsamplerprocessor) samplingRate(severity plog.SeverityNumber) uint32 {
+	if severity < plog.SeverityNumberTrace || severity > plog.SeverityNumberFatal4 {
+		return lsp.scaledSamplingRate
+	}
+	return lsp.scaledSeverityRates[(severity-plog.SeverityNumberTrace)/4]
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/idutils"
+)
+
+func TestNewLogsProcessor(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		SamplingPercentage: 15.5,
+	}
+	got, err := newLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.True(t, got.Capabilities().MutatesData)
+	assert.NoError(t, got.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, got.Shutdown(context.Background()))
+}
+
+// Test_logsamplerprocessor_SameDecisionAsTraces ensures that the log records get the same decision as the spans
+// of their traces.
+func Test_logsamplerprocessor_SameDecisionAsTraces(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		SamplingPercentage: 30,
+		HashSeed:           7,
+	}
+	sink := new(consumertest.TracesSink)
+	tp, err := newTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	// an empty trace ID is hashed for spans only, so start at 1
+	for i := 1; i <= 1000; i++ {
+		traceID := idutils.UInt64ToTraceID(uint64(i), uint64(i*31))
+		spans.AppendEmpty().SetTraceID(traceID)
+		records.AppendEmpty().SetTraceID(traceID)
+	}
+	require.NoError(t, tp.ConsumeTraces(context.Background(), traces))
+	sampledLogs, err := newLogSampler(cfg).processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	sampledSpans := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	sampledRecords := sampledLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, sampledSpans.Len(), sampledRecords.Len())
+	assert.InDelta(t, 300, sampledRecords.Len(), 60)
+	for i := 0; i < sampledSpans.Len(); i++ {
+		assert.Equal(t, sampledSpans.At(i).TraceID(), sampledRecords.At(i).TraceID())
+	}
+}
+
+func Test_logsamplerprocessor_FromAttribute(t *testing.T) {
+	lsp := newLogSampler(&Config{
+		SamplingPercentage: 50,
+		FromAttribute:      "request.id",
+	})
+	lsp.randomHash = func() uint32 {
+		require.Fail(t, "records with the attribute shouldn't be sampled randomly")
+		return 0
+	}
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 100; i++ {
+		// two records per request
+		for j := 0; j < 2; j++ {
+			records.AppendEmpty().Attributes().PutInt("request.id", int64(i))
+		}
+	}
+	sampledLogs, err := lsp.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	sampledRecords := sampledLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.InDelta(t, 100, sampledRecords.Len(), 30)
+	requests := map[int64]int{}
+	for i := 0; i < sampledRecords.Len(); i++ {
+		requestID, _ := sampledRecords.At(i).Attributes().Get("request.id")
+		requests[requestID.IntVal()]++
+	}
+	for requestID, count := range requests {
+		assert.Equal(t, 2, count, "both records of request %d should have been sampled", requestID)
+	}
+}
+
+func Test_logsamplerprocessor_RandomWithoutKey(t *testing.T) {
+	lsp := newLogSampler(&Config{
+		SamplingPercentage: 50,
+		FromAttribute:      "request.id",
+	})
+	hashes := []uint32{0, numHashBuckets - 1}
+	lsp.randomHash = func() uint32 {
+		h := hashes[0]
+		hashes = hashes[1:]
+		return h
+	}
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStringVal("kept")
+	records.AppendEmpty().Body().SetStringVal("dropped")
+	sampledLogs, err := lsp.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	sampledRecords := sampledLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 1, sampledRecords.Len())
+	assert.Equal(t, "kept", sampledRecords.At(0).Body().StringVal())
+}
+
+func Test_logsamplerprocessor_SeveritySamplingPercentage(t *testing.T) {
+	lsp := newLogSampler(&Config{
+		SamplingPercentage: 50,
+		SeveritySamplingPercentage: map[string]float32{
+			"debug": 0,
+			"ERROR": 100,
+		},
+	})
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	severities := []plog.SeverityNumber{plog.SeverityNumberDebug, plog.SeverityNumberDebug4, plog.SeverityNumberError2, plog.SeverityNumberInfo}
+	for i := 0; i < 400; i++ {
+		record := records.AppendEmpty()
+		record.SetTraceID(idutils.UInt64ToTraceID(uint64(i), uint64(i*7)))
+		record.SetSeverityNumber(severities[i%len(severities)])
+	}
+	sampledLogs, err := lsp.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	counts := map[plog.SeverityNumber]int{}
+	sampledRecords := sampledLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i := 0; i < sampledRecords.Len(); i++ {
+		counts[sampledRecords.At(i).SeverityNumber()]++
+	}
+	assert.Zero(t, counts[plog.SeverityNumberDebug])
+	assert.Zero(t, counts[plog.SeverityNumberDebug4])
+	assert.Equal(t, 100, counts[plog.SeverityNumberError2])
+	assert.InDelta(t, 50, counts[plog.SeverityNumberInfo], 20)
+}
+
+func Test_logsamplerprocessor_SamplingPriority(t *testing.T) {
+	lsp := newLogSampler(&Config{SamplingPercentage: 50})
+	lsp.randomHash = func() uint32 { return 0 }
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Attributes().PutInt("sampling.priority", 0)
+	sampledLogs, err := lsp.processLogs(context.Background(), logs)
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+	assert.Zero(t, sampledLogs.LogRecordCount())
+
+	lsp = newLogSampler(&Config{SamplingPercentage: 0})
+	logs = plog.NewLogs()
+	records = logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Attributes().PutString("sampling.priority", "1")
+	sampledLogs, err = lsp.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sampledLogs.LogRecordCount())
+}
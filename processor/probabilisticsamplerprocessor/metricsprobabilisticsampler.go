@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type metricsamplerprocessor struct {
+	scaledSamplingRate uint32
+	hashSeed           uint32
+}
+
+// newMetricsProcessor returns a processor.MetricsProcessor that will drop the exemplars of the traces
+// that aren't sampled according to the given configuration. The metrics themselves are left untouched.
+func newMetricsProcessor(ctx context.Context, set component.ProcessorCreateSettings, cfg *Config, nextConsumer consumer.Metrics) (component.MetricsProcessor, error) {
+	msp := &metricsamplerprocessor{
+		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
+		hashSeed:           cfg.HashSeed,
+	}
+
+	return processorhelper.NewMetricsProcessor(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		msp.processMetrics,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+func (msp *metricsamplerprocessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				msp.sampleExemplars(ms.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+// sampleExemplars drops the exemplars of the metric whose trace isn't sampled, hashing their trace ID
+// the same way as the spans. Exemplars without a trace ID aren't part of a trace and are kept.
+func (msp *metricsamplerprocessor) sampleExemplars(m pmetric.Metric) {
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dps.At(i).Exemplars().RemoveIf(msp.dropExemplar)
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dps.At(i).Exemplars().RemoveIf(msp.dropExemplar)
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dps.At(i).Exemplars().RemoveIf(msp.dropExemplar)
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dps.At(i).Exemplars().RemoveIf(msp.dropExemplar)
+		}
+	}
+}
+
+func (msp *metricsamplerprocessor) dropExemplar(e pmetric.Exemplar) bool {
+	traceID := e.TraceID()
+	if traceID.IsEmpty() {
+		return false
+	}
+	return hash(traceID[:], msp.hashSeed)&bitMaskHashBuckets >= msp.scaledSamplingRate
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/idutils"
+)
+
+func TestNewMetricsProcessor(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		SamplingPercentage: 15.5,
+	}
+	got, err := newMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.True(t, got.Capabilities().MutatesData)
+	assert.NoError(t, got.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, got.Shutdown(context.Background()))
+}
+
+// Test_metricsamplerprocessor_SameDecisionAsTraces ensures that the exemplars are kept only for the sampled traces,
+// of every metric type, while the data points themselves are kept.
+func Test_metricsamplerprocessor_SameDecisionAsTraces(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		SamplingPercentage: 30,
+		HashSeed:           7,
+	}
+	tracesSink := new(consumertest.TracesSink)
+	tp, err := newTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, tracesSink)
+	require.NoError(t, err)
+	metricsSink := new(consumertest.MetricsSink)
+	mp, err := newMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, metricsSink)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	metrics := pmetric.NewMetrics()
+	ms := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	exemplars := []pmetric.ExemplarSlice{
+		ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().Exemplars(),
+		ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Exemplars(),
+		ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Exemplars(),
+		ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Exemplars(),
+	}
+	for i := 1; i <= 1000; i++ {
+		traceID := idutils.UInt64ToTraceID(uint64(i), uint64(i*31))
+		spans.AppendEmpty().SetTraceID(traceID)
+		for _, es := range exemplars {
+			es.AppendEmpty().SetTraceID(traceID)
+		}
+	}
+	// exemplars without a trace ID are kept
+	for _, es := range exemplars {
+		es.AppendEmpty().SetTraceID(pcommon.NewTraceIDEmpty())
+	}
+	require.NoError(t, tp.ConsumeTraces(context.Background(), traces))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), metrics))
+
+	sampledSpans := tracesSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.InDelta(t, 300, sampledSpans.Len(), 60)
+	sampledMetrics := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 4, sampledMetrics.Len())
+	for _, es := range []pmetric.ExemplarSlice{
+		sampledMetrics.At(0).Gauge().DataPoints().At(0).Exemplars(),
+		sampledMetrics.At(1).Sum().DataPoints().At(0).Exemplars(),
+		sampledMetrics.At(2).Histogram().DataPoints().At(0).Exemplars(),
+		sampledMetrics.At(3).ExponentialHistogram().DataPoints().At(0).Exemplars(),
+	} {
+		require.Equal(t, sampledSpans.Len()+1, es.Len())
+		for i := 0; i < sampledSpans.Len(); i++ {
+			assert.Equal(t, sampledSpans.At(i).TraceID(), es.At(i).TraceID())
+		}
+		assert.True(t, es.At(sampledSpans.Len()).TraceID().IsEmpty())
+	}
+}
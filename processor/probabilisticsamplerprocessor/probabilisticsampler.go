@@ -76,7 +76,7 @@ func (tsp *tracesamplerprocessor) processTraces(_ context.Context, td ptrace.Tra
 	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
 		rs.ScopeSpans().RemoveIf(func(ils ptrace.ScopeSpans) bool {
 			ils.Spans().RemoveIf(func(s ptrace.Span) bool {
-				sp := parseSpanSamplingPriority(s)
+				sp := parseSamplingPriority(s.Attributes())
 				if sp == doNotSampleSpan {
 					// The OpenTelemetry mentions this as a "hint" we take a stronger
 					// approach and do not sample the span since some may use it to
@@ -104,12 +104,11 @@ func (tsp *tracesamplerprocessor) processTraces(_ context.Context, td ptrace.Tra
 	return td, nil
 }
 
-// parseSpanSamplingPriority checks if the span has the "sampling.priority" tag to
-// decide if the span should be sampled or not. The usage of the tag follows the
+// parseSamplingPriority checks if the span or log record has the "sampling.priority" tag to
+// decide if it should be sampled or not. The usage of the tag follows the
 // OpenTracing semantic tags:
 // https://github.com/opentracing/specification/blob/main/semantic_conventions.md#span-tags-table
-func parseSpanSamplingPriority(span ptrace.Span) samplingPriority {
-	attribMap := span.Attributes()
+func parseSamplingPriority(attribMap pcommon.Map) samplingPriority {
 	if attribMap.Len() <= 0 {
 		return deferDecision
 	}
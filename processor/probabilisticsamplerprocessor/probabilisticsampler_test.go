@@ -416,7 +416,7 @@ func Test_parseSpanSamplingPriority(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.want, parseSpanSamplingPriority(tt.span))
+			assert.Equal(t, tt.want, parseSamplingPriority(tt.span.Attributes()))
 		})
 	}
 }
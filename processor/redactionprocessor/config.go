@@ -15,30 +15,75 @@
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	// sha256Hash replaces the blocked values with their SHA-256 hash
+	sha256Hash = "sha256"
+	// hmacSHA256Hash replaces the blocked values with their HMAC-SHA256 hash,
+	// keyed with HashKey
+	hmacSHA256Hash = "hmac-sha256"
+)
+
+var (
+	errMissingHashKey    = errors.New("hash_key is required with the hmac-sha256 hash function")
+	errUnexpectedHashKey = errors.New("hash_key is only used by the hmac-sha256 hash function")
+)
+
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"`
 
-	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
+	// AllowAllKeys is a flag to allow all attribute keys. Setting this
 	// to true disables the AllowedKeys list. The list of BlockedValues is
 	// applied regardless. If you just want to block values, set this to true.
 	AllowAllKeys bool `mapstructure:"allow_all_keys"`
 
-	// AllowedKeys is a list of allowed span attribute keys. Span attributes
+	// AllowedKeys is a list of allowed span, log and metric data point
+	// attribute keys, also applied to the keys of map log bodies. Attributes
 	// not on the list are removed. The list fails closed if it's empty. To
 	// allow all keys, you should explicitly set AllowAllKeys
 	AllowedKeys []string `mapstructure:"allowed_keys"`
 
 	// BlockedValues is a list of regular expressions for blocking values of
-	// allowed span attributes. Values that match are masked
+	// allowed attributes and of log bodies. Values that match are masked, or
+	// hashed if HashFunction is set
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// HashFunction replaces the blocked values with their hash instead of
+	// masking them, so that the redacted values can still be joined on.
+	// Possible values are `sha256` and `hmac-sha256`. The values are masked
+	// if it's empty.
+	HashFunction string `mapstructure:"hash_function"`
+
+	// HashKey is the secret key of the `hmac-sha256` hash function. It's
+	// rejected with any other hash function, as they aren't keyed.
+	HashKey string `mapstructure:"hash_key"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
-	// the processor adds to the spans when it redacts or masks other
-	// attributes. In some contexts a list of redacted attributes leaks
-	// information, while it is valuable when integrating and testing a new
-	// configuration. Possible values are `debug`, `info`, and `silent`.
+	// the processor adds to the spans, log records and metric data points
+	// when it redacts or masks other attributes. In some contexts a list of
+	// redacted attributes leaks information, while it is valuable when
+	// integrating and testing a new configuration. Possible values are `debug`, `info`, and `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.HashFunction {
+	case "", sha256Hash:
+		if cfg.HashKey != "" {
+			return errUnexpectedHashKey
+		}
+	case hmacSHA256Hash:
+		if cfg.HashKey == "" {
+			return errMissingHashKey
+		}
+	default:
+		return fmt.Errorf("unknown hash_function %q, must be one of %s or %s", cfg.HashFunction, sha256Hash, hmacSHA256Hash)
+	}
+	return nil
+}
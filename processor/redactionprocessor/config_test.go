@@ -45,6 +45,16 @@ func TestLoadConfig(t *testing.T) {
 			id:       config.NewComponentIDWithName(typeStr, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "hashed"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				AllowAllKeys:      true,
+				BlockedValues:     []string{"4[0-9]{12}(?:[0-9]{3})?"},
+				HashFunction:      hmacSHA256Hash,
+				HashKey:           "secret",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -64,3 +74,41 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{
+			name: "sha256",
+			cfg:  &Config{HashFunction: sha256Hash},
+		},
+		{
+			name:     "sha256 with key",
+			cfg:      &Config{HashFunction: sha256Hash, HashKey: "secret"},
+			expected: errUnexpectedHashKey.Error(),
+		},
+		{
+			name:     "hmac-sha256 without key",
+			cfg:      &Config{HashFunction: hmacSHA256Hash},
+			expected: errMissingHashKey.Error(),
+		},
+		{
+			name:     "unknown hash function",
+			cfg:      &Config{HashFunction: "md5"},
+			expected: `unknown hash_function "md5", must be one of sha256 or hmac-sha256`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
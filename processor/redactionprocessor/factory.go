@@ -37,6 +37,8 @@ func NewFactory() component.ProcessorFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithTracesProcessor(createTracesProcessor, stability),
+		component.WithLogsProcessor(createLogsProcessor, component.StabilityLevelAlpha),
+		component.WithMetricsProcessor(createMetricsProcessor, component.StabilityLevelAlpha),
 	)
 }
 
@@ -55,7 +57,7 @@ func createTracesProcessor(
 ) (component.TracesProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	redaction, err := newRedaction(ctx, oCfg, set.Logger, next)
+	redaction, err := newRedaction(ctx, oCfg, set.Logger)
 	if err != nil {
 		// TODO: Placeholder for an error metric in the next PR
 		return nil, fmt.Errorf("error creating a redaction processor: %w", err)
@@ -71,3 +73,51 @@ func createTracesProcessor(
 		processorhelper.WithStart(redaction.Start),
 		processorhelper.WithShutdown(redaction.Shutdown))
 }
+
+func createLogsProcessor(
+	ctx context.Context,
+	set component.ProcessorCreateSettings,
+	cfg config.Processor,
+	next consumer.Logs,
+) (component.LogsProcessor, error) {
+	oCfg := cfg.(*Config)
+
+	redaction, err := newRedaction(ctx, oCfg, set.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating a redaction processor: %w", err)
+	}
+
+	return processorhelper.NewLogsProcessor(
+		ctx,
+		set,
+		cfg,
+		next,
+		redaction.processLogs,
+		processorhelper.WithCapabilities(redaction.Capabilities()),
+		processorhelper.WithStart(redaction.Start),
+		processorhelper.WithShutdown(redaction.Shutdown))
+}
+
+func createMetricsProcessor(
+	ctx context.Context,
+	set component.ProcessorCreateSettings,
+	cfg config.Processor,
+	next consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+
+	redaction, err := newRedaction(ctx, oCfg, set.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating a redaction processor: %w", err)
+	}
+
+	return processorhelper.NewMetricsProcessor(
+		ctx,
+		set,
+		cfg,
+		next,
+		redaction.processMetrics,
+		processorhelper.WithCapabilities(redaction.Capabilities()),
+		processorhelper.WithStart(redaction.Start),
+		processorhelper.WithShutdown(redaction.Shutdown))
+}
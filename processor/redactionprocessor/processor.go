@@ -16,7 +16,11 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"regexp"
 	"sort"
 	"strings"
@@ -24,29 +28,36 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
 
-const attrValuesSeparator = ","
-
-var _ component.TracesProcessor = (*redaction)(nil)
+const (
+	attrValuesSeparator = ","
+	// nestedKeySeparator joins the keys of nested maps in the summary
+	nestedKeySeparator = "."
+	// bodyKey identifies the log body in the summary
+	bodyKey = "body"
+)
 
 type redaction struct {
-	// Attribute keys allowed in a span
+	// Attribute keys allowed in a span, log record or data point
 	allowList map[string]string
-	// Attribute values blocked in a span
-	blockRegexList map[string]*regexp.Regexp
+	// Attribute values blocked, in the configuration order
+	blockRegexList []*regexp.Regexp
+	// newHash creates the hash used to replace blocked values, they are
+	// masked if it's nil
+	newHash func() hash.Hash
 	// Redaction processor configuration
 	config *Config
 	// Logger
 	logger *zap.Logger
-	// Next trace consumer in line
-	next consumer.Traces
 }
 
 // newRedaction creates a new instance of the redaction processor
-func newRedaction(ctx context.Context, config *Config, logger *zap.Logger, next consumer.Traces) (*redaction, error) {
+func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
 	allowList := makeAllowList(config)
 	blockRegexList, err := makeBlockRegexList(ctx, config)
 	if err != nil {
@@ -57,13 +68,13 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger, next
 	return &redaction{
 		allowList:      allowList,
 		blockRegexList: blockRegexList,
+		newHash:        makeHash(config),
 		config:         config,
 		logger:         logger,
-		next:           next,
 	}, nil
 }
 
-// processTraces implements ProcessMetricsFunc. It processes the incoming data
+// processTraces implements ProcessTracesFunc. It processes the incoming data
 // and returns the data to be sent to the next component
 func (s *redaction) processTraces(ctx context.Context, batch ptrace.Traces) (ptrace.Traces, error) {
 	for i := 0; i < batch.ResourceSpans().Len(); i++ {
@@ -73,6 +84,26 @@ func (s *redaction) processTraces(ctx context.Context, batch ptrace.Traces) (ptr
 	return batch, nil
 }
 
+// processLogs implements ProcessLogsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		s.processResourceLog(ctx, rl)
+	}
+	return logs, nil
+}
+
+// processMetrics implements ProcessMetricsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processMetrics(ctx context.Context, metrics pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		s.processResourceMetric(ctx, rm)
+	}
+	return metrics, nil
+}
+
 // processResourceSpan processes the RS and all of its spans and then returns the last
 // view metric context. The context can be used for tests
 func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceSpans) {
@@ -93,12 +124,97 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 	}
 }
 
-// processAttrs redacts the attributes of a resource span or a span
+// processResourceLog processes the RL and all of its log records, including
+// their bodies
+func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs) {
+	s.processAttrs(ctx, rl.Resource().Attributes())
+
+	for j := 0; j < rl.ScopeLogs().Len(); j++ {
+		sl := rl.ScopeLogs().At(j)
+		for k := 0; k < sl.LogRecords().Len(); k++ {
+			lr := sl.LogRecords().At(k)
+			s.processAttrs(ctx, lr.Attributes())
+			s.processBody(ctx, lr)
+		}
+	}
+}
+
+// processResourceMetric processes the RM and the data points of all of its
+// metrics
+func (s *redaction) processResourceMetric(ctx context.Context, rm pmetric.ResourceMetrics) {
+	s.processAttrs(ctx, rm.Resource().Attributes())
+
+	for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+		sm := rm.ScopeMetrics().At(j)
+		for k := 0; k < sm.Metrics().Len(); k++ {
+			metric := sm.Metrics().At(k)
+			switch metric.DataType() {
+			case pmetric.MetricDataTypeGauge:
+				s.processNumberDataPoints(ctx, metric.Gauge().DataPoints())
+			case pmetric.MetricDataTypeSum:
+				s.processNumberDataPoints(ctx, metric.Sum().DataPoints())
+			case pmetric.MetricDataTypeHistogram:
+				dps := metric.Histogram().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					s.processAttrs(ctx, dps.At(l).Attributes())
+				}
+			case pmetric.MetricDataTypeExponentialHistogram:
+				dps := metric.ExponentialHistogram().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					s.processAttrs(ctx, dps.At(l).Attributes())
+				}
+			case pmetric.MetricDataTypeSummary:
+				dps := metric.Summary().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					s.processAttrs(ctx, dps.At(l).Attributes())
+				}
+			}
+		}
+	}
+}
+
+func (s *redaction) processNumberDataPoints(ctx context.Context, dps pmetric.NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		s.processAttrs(ctx, dps.At(i).Attributes())
+	}
+}
+
+// processAttrs redacts the attributes of a resource, a span, a log record
+// or a data point
 func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	// TODO: Use the context for recording metrics
-	var toDelete []string
-	var toBlock []string
+	toDelete, toBlock := s.redactAttrs(attributes, "")
+
+	// Add diagnostic information to the span
+	s.addMetaAttrs(toDelete, attributes, redactedKeys, redactedKeyCount)
+	s.addMetaAttrs(toBlock, attributes, maskedValues, maskedValueCount)
+}
+
+// processBody redacts the body of a log record. The values of string bodies
+// are masked, while map bodies are redacted like attributes. The summary is
+// added to the log record attributes
+func (s *redaction) processBody(_ context.Context, lr plog.LogRecord) {
+	var toDelete, toBlock []string
+	body := lr.Body()
+	switch body.Type() {
+	case pcommon.ValueTypeString:
+		if s.maskValue(body) {
+			toBlock = append(toBlock, bodyKey)
+		}
+	case pcommon.ValueTypeMap:
+		toDelete, toBlock = s.redactAttrs(body.MapVal(), bodyKey+nestedKeySeparator)
+	case pcommon.ValueTypeSlice:
+		toBlock = s.maskSlice(body.SliceVal(), bodyKey)
+	}
+
+	s.addMetaAttrs(toDelete, lr.Attributes(), redactedKeys, redactedKeyCount)
+	s.addMetaAttrs(toBlock, lr.Attributes(), maskedValues, maskedValueCount)
+}
 
+// redactAttrs removes the attributes that aren't allowed and masks the
+// blocked values of the other ones, returning the keys of both, prefixed
+// with the given prefix
+func (s *redaction) redactAttrs(attributes pcommon.Map, prefix string) (toDelete []string, toBlock []string) {
 	// Identify attributes to redact and mask in the following sequence
 	// 1. Make a list of attribute keys to redact
 	// 2. Mask any blocked values for the other attributes
@@ -118,16 +234,7 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		strVal := value.StringVal()
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(strVal)
-			if match {
-				toBlock = append(toBlock, k)
-
-				maskedValue := compiledRE.ReplaceAllString(strVal, "****")
-				value.SetStringVal(maskedValue)
-			}
-		}
+		toBlock = append(toBlock, s.maskNested(value, prefix+k)...)
 		return true
 	})
 
@@ -135,20 +242,101 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	for _, k := range toDelete {
 		attributes.Remove(k)
 	}
-	// Add diagnostic information to the span
-	s.addMetaAttrs(toDelete, attributes, redactedKeys, redactedKeyCount)
-	s.addMetaAttrs(toBlock, attributes, maskedValues, maskedValueCount)
+	if prefix != "" {
+		for i, k := range toDelete {
+			toDelete[i] = prefix + k
+		}
+	}
+	return toDelete, toBlock
 }
 
-// ConsumeTraces implements the SpanProcessor interface
-func (s *redaction) ConsumeTraces(ctx context.Context, batch ptrace.Traces) error {
-	batch, err := s.processTraces(ctx, batch)
-	if err != nil {
-		return err
+// maskNested masks the blocked values of the given value and of the values
+// nested in it, returning the keys of the masked ones. The allow list only
+// applies to the top level keys
+func (s *redaction) maskNested(value pcommon.Value, key string) []string {
+	switch value.Type() {
+	case pcommon.ValueTypeString:
+		if s.maskValue(value) {
+			return []string{key}
+		}
+	case pcommon.ValueTypeMap:
+		var masked []string
+		value.MapVal().Range(func(k string, v pcommon.Value) bool {
+			masked = append(masked, s.maskNested(v, key+nestedKeySeparator+k)...)
+			return true
+		})
+		return masked
+	case pcommon.ValueTypeSlice:
+		return s.maskSlice(value.SliceVal(), key)
+	}
+	return nil
+}
+
+// maskSlice masks the blocked values of the elements of the slice, returning
+// the given key if any was masked
+func (s *redaction) maskSlice(slice pcommon.Slice, key string) []string {
+	var masked []string
+	for i := 0; i < slice.Len(); i++ {
+		masked = append(masked, s.maskNested(slice.At(i), key)...)
+	}
+	if len(masked) > 0 {
+		return []string{key}
+	}
+	return nil
+}
+
+// maskValue masks, or hashes, the parts of the string value matching the
+// blocked values, returning whether any did. All the blocked values are
+// matched against the original string, and the overlapping matches are
+// replaced once, so that a hash is never matched and hashed again
+func (s *redaction) maskValue(value pcommon.Value) bool {
+	strVal := value.StringVal()
+	var matches [][]int
+	for _, compiledRE := range s.blockRegexList {
+		matches = append(matches, compiledRE.FindAllStringIndex(strVal, -1)...)
+	}
+	if len(matches) == 0 {
+		return false
 	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i][0] != matches[j][0] {
+			return matches[i][0] < matches[j][0]
+		}
+		return matches[i][1] > matches[j][1]
+	})
 
-	err = s.next.ConsumeTraces(ctx, batch)
-	return err
+	var masked strings.Builder
+	last := 0
+	for i := 0; i < len(matches); {
+		start, end := matches[i][0], matches[i][1]
+		for i++; i < len(matches) && matches[i][0] < end; i++ {
+			if matches[i][1] > end {
+				end = matches[i][1]
+			}
+		}
+		masked.WriteString(strVal[last:start])
+		masked.WriteString(s.mask(strVal[start:end]))
+		last = end
+	}
+	masked.WriteString(strVal[last:])
+	value.SetStringVal(masked.String())
+	return true
+}
+
+// mask returns the replacement of the blocked value, its hash if a hash
+// function is configured
+func (s *redaction) mask(blockedValue string) string {
+	if s.newHash == nil {
+		return "****"
+	}
+	return s.hash(blockedValue)
+}
+
+// hash returns the hex encoded hash of the blocked value
+func (s *redaction) hash(blockedValue string) string {
+	h := s.newHash()
+	h.Write([]byte(blockedValue))
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 // addMetaAttrs adds diagnostic information about redacted or masked attribute keys
@@ -210,19 +398,34 @@ func makeAllowList(c *Config) map[string]string {
 }
 
 // makeBlockRegexList precompiles all the blocked regex patterns
-func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.Regexp, error) {
-	blockRegexList := make(map[string]*regexp.Regexp, len(config.BlockedValues))
+func makeBlockRegexList(_ context.Context, config *Config) ([]*regexp.Regexp, error) {
+	blockRegexList := make([]*regexp.Regexp, 0, len(config.BlockedValues))
 	for _, pattern := range config.BlockedValues {
 		re, err := regexp.Compile(pattern)
 		if err != nil {
 			// TODO: Placeholder for an error metric in the next PR
 			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
 		}
-		blockRegexList[pattern] = re
+		blockRegexList = append(blockRegexList, re)
 	}
 	return blockRegexList, nil
 }
 
+// makeHash returns the constructor of the configured hash function, or nil
+// if the blocked values are masked
+func makeHash(config *Config) func() hash.Hash {
+	switch config.HashFunction {
+	case sha256Hash:
+		return sha256.New
+	case hmacSHA256Hash:
+		key := []byte(config.HashKey)
+		return func() hash.Hash {
+			return hmac.New(sha256.New, key)
+		}
+	}
+	return nil
+}
+
 // Capabilities specifies what this processor does, such as whether it mutates data
 func (s *redaction) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
@@ -25,14 +25,15 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zaptest"
 )
 
 func TestCapabilities(t *testing.T) {
 	config := &Config{}
-	next := new(consumertest.TracesSink)
-	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), next)
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
 	assert.NoError(t, err)
 
 	cap := processor.Capabilities()
@@ -41,8 +42,7 @@ func TestCapabilities(t *testing.T) {
 
 func TestStartShutdown(t *testing.T) {
 	config := &Config{}
-	next := new(consumertest.TracesSink)
-	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), next)
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
 	assert.NoError(t, err)
 
 	ctx := context.Background()
@@ -359,7 +359,7 @@ func TestProcessAttrsAppliedTwice(t *testing.T) {
 		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
 		Summary:       "debug",
 	}
-	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t), consumertest.NewNop())
+	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t))
 	require.NoError(t, err)
 
 	attrs := pcommon.NewMap()
@@ -390,6 +390,187 @@ func TestProcessAttrsAppliedTwice(t *testing.T) {
 	assert.Equal(t, int64(2), val.IntVal())
 }
 
+// TestRedactLogs validates that the processor redacts the attributes of the
+// log records and the values of their string and map bodies
+func TestRedactLogs(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"id", "user", "message"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       "debug",
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutString("id", "resource 4111111111111111")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	stringBody := records.AppendEmpty()
+	stringBody.Attributes().PutInt("id", 5)
+	stringBody.Attributes().PutString("credit_card", "4111111111111111")
+	stringBody.Body().SetStringVal("paid with 4111111111111111")
+	mapBody := records.AppendEmpty()
+	mapBody.Body().SetEmptyMapVal().FromRaw(map[string]interface{}{
+		"message": "paid",
+		"user": map[string]interface{}{
+			"name":  "placeholder",
+			"cards": []interface{}{"4111111111111111"},
+		},
+		"password": "secret",
+	})
+
+	out, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	resourceAttrs := out.ResourceLogs().At(0).Resource().Attributes()
+	val, _ := resourceAttrs.Get("id")
+	assert.Equal(t, "resource ****", val.StringVal())
+
+	outRecords := out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	attrs := outRecords.At(0).Attributes()
+	_, ok := attrs.Get("credit_card")
+	assert.False(t, ok)
+	assert.Equal(t, "paid with ****", outRecords.At(0).Body().StringVal())
+	val, _ = attrs.Get(redactedKeys)
+	assert.Equal(t, "credit_card", val.StringVal())
+	val, _ = attrs.Get(maskedValues)
+	assert.Equal(t, "body", val.StringVal())
+
+	assert.Equal(t, map[string]interface{}{
+		"message": "paid",
+		"user": map[string]interface{}{
+			"name":  "placeholder",
+			"cards": []interface{}{"****"},
+		},
+	}, outRecords.At(1).Body().MapVal().AsRaw())
+	attrs = outRecords.At(1).Attributes()
+	val, _ = attrs.Get(redactedKeys)
+	assert.Equal(t, "body.password", val.StringVal())
+	val, _ = attrs.Get(maskedValues)
+	assert.Equal(t, "body.user.cards", val.StringVal())
+}
+
+// TestRedactMetrics validates that the processor redacts the attributes of
+// the data points of all the metric types
+func TestRedactMetrics(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"id"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       "info",
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	ms := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	var attrs []pcommon.Map
+	gauge := ms.AppendEmpty()
+	attrs = append(attrs, gauge.SetEmptyGauge().DataPoints().AppendEmpty().Attributes())
+	sum := ms.AppendEmpty()
+	attrs = append(attrs, sum.SetEmptySum().DataPoints().AppendEmpty().Attributes())
+	histogram := ms.AppendEmpty()
+	attrs = append(attrs, histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes())
+	expHistogram := ms.AppendEmpty()
+	attrs = append(attrs, expHistogram.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes())
+	summary := ms.AppendEmpty()
+	attrs = append(attrs, summary.SetEmptySummary().DataPoints().AppendEmpty().Attributes())
+	for _, attr := range attrs {
+		attr.PutString("id", "card 4111111111111111")
+		attr.PutString("user", "placeholder")
+	}
+
+	_, err = processor.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+
+	for _, attr := range attrs {
+		assert.Equal(t, map[string]interface{}{
+			"id":             "card ****",
+			redactedKeyCount: int64(1),
+			maskedValueCount: int64(1),
+		}, attr.AsRaw())
+	}
+}
+
+// TestHashBlockedValues validates that the blocked values are replaced by
+// their hash when a hash function is configured
+func TestHashBlockedValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *Config
+		expected string
+	}{
+		{
+			name:     "sha256",
+			config:   &Config{HashFunction: sha256Hash},
+			expected: "card 9bbef19476623ca56c17da75fd57734dbf82530686043a6e491c6d71befe8f6e",
+		},
+		{
+			name:     "hmac-sha256",
+			config:   &Config{HashFunction: hmacSHA256Hash, HashKey: "secret"},
+			expected: "card d6c005134ac50dec0e01cbc4aeaf3fbdb511c4ceeb55f909c29def3b0cffba36",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.AllowAllKeys = true
+			tt.config.BlockedValues = []string{"4[0-9]{12}(?:[0-9]{3})?"}
+			processor, err := newRedaction(context.Background(), tt.config, zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			first := pcommon.NewMap()
+			first.PutString("card", "card 4111111111111111")
+			second := pcommon.NewMap()
+			second.PutString("other", "card 4111111111111111")
+			processor.processAttrs(context.Background(), first)
+			processor.processAttrs(context.Background(), second)
+
+			firstVal, _ := first.Get("card")
+			secondVal, _ := second.Get("other")
+			assert.Equal(t, tt.expected, firstVal.StringVal())
+			assert.Equal(t, firstVal.StringVal(), secondVal.StringVal(), "the same value should get the same hash")
+		})
+	}
+}
+
+// TestHashOverlappingBlockedValues validates that the blocked values are all
+// matched against the original value, and that a hash isn't hashed again by
+// a blocked value matching its hex digits
+func TestHashOverlappingBlockedValues(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?", "[0-9a-f]{8}"},
+		HashFunction:  sha256Hash,
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	attrs := pcommon.NewMap()
+	attrs.PutString("card", "card 4111111111111111 id deadbeef")
+	processor.processAttrs(context.Background(), attrs)
+
+	val, _ := attrs.Get("card")
+	assert.Equal(t, "card 9bbef19476623ca56c17da75fd57734dbf82530686043a6e491c6d71befe8f6e"+
+		" id "+processor.hash("deadbeef"), val.StringVal())
+}
+
+// TestMaskOverlappingBlockedValues validates that overlapping blocked values
+// are masked once
+func TestMaskOverlappingBlockedValues(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"secret-[0-9]+", "[0-9]+-token"},
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	attrs := pcommon.NewMap()
+	attrs.PutString("value", "a secret-123-token and 456-token")
+	processor.processAttrs(context.Background(), attrs)
+
+	val, _ := attrs.Get("value")
+	assert.Equal(t, "a **** and ****", val.StringVal())
+}
+
 // runTest transforms the test input data and passes it through the processor
 func runTest(
 	t *testing.T,
@@ -426,9 +607,11 @@ func runTest(
 	// test
 	ctx := context.Background()
 	next := new(consumertest.TracesSink)
-	processor, err := newRedaction(ctx, config, zaptest.NewLogger(t), next)
+	processor, err := newRedaction(ctx, config, zaptest.NewLogger(t))
+	assert.NoError(t, err)
+	outBatch, err := processor.processTraces(ctx, inBatch)
 	assert.NoError(t, err)
-	err = processor.ConsumeTraces(ctx, inBatch)
+	err = next.ConsumeTraces(ctx, outBatch)
 
 	// verify
 	assert.NoError(t, err)
@@ -456,9 +639,7 @@ func BenchmarkRedactSummaryDebug(b *testing.B) {
 	redacted := map[string]pcommon.Value{
 		"credit_card": pcommon.NewValueString("would be nice"),
 	}
-	ctx := context.Background()
-	next := new(consumertest.TracesSink)
-	processor, _ := newRedaction(ctx, config, zaptest.NewLogger(b), next)
+	processor, _ := newRedaction(context.Background(), config, zaptest.NewLogger(b))
 
 	for i := 0; i < b.N; i++ {
 		runBenchmark(allowed, redacted, masked, processor)
@@ -483,9 +664,7 @@ func BenchmarkMaskSummaryDebug(b *testing.B) {
 		"name": pcommon.NewValueString("placeholder 4111111111111111"),
 		"url":  pcommon.NewValueString("https://www.this_is_testing_url.com"),
 	}
-	ctx := context.Background()
-	next := new(consumertest.TracesSink)
-	processor, _ := newRedaction(ctx, config, zaptest.NewLogger(b), next)
+	processor, _ := newRedaction(context.Background(), config, zaptest.NewLogger(b))
 
 	for i := 0; i < b.N; i++ {
 		runBenchmark(allowed, nil, masked, processor)
@@ -519,5 +698,5 @@ func runBenchmark(
 		v.CopyTo(span.Attributes().PutEmpty(k))
 	}
 
-	_ = processor.ConsumeTraces(context.Background(), inBatch)
+	_, _ = processor.processTraces(context.Background(), inBatch)
 }
@@ -23,10 +23,12 @@ import (
 )
 
 var (
-	errEmptyRoute             = errors.New("empty routing attribute provided")
-	errNoExporters            = errors.New("no exporters defined for the route")
-	errNoTableItems           = errors.New("the routing table is empty")
-	errNoMissingFromAttribute = errors.New("the FromAttribute property is empty")
+	errEmptyRoute              = errors.New("empty routing attribute provided")
+	errNoExporters             = errors.New("no exporters defined for the route")
+	errNoTableItems            = errors.New("the routing table is empty")
+	errNoMissingFromAttribute  = errors.New("the FromAttribute property is empty")
+	errRecordWithoutExpression = errors.New("routing at the record granularity requires an expression")
+	errRecordWithContext       = errors.New("routing at the record granularity requires the resource attribute source")
 )
 
 // Config defines configuration for the Routing processor.
@@ -84,6 +86,19 @@ func (c *Config) Validate() error {
 		if len(item.Exporters) == 0 {
 			return fmt.Errorf("invalid route %s: %w", item.Value, errNoExporters)
 		}
+
+		switch item.Granularity {
+		case "", resourceGranularity:
+		case recordGranularity:
+			if len(item.Expression) == 0 {
+				return fmt.Errorf("invalid route %s: %w", item.Value, errRecordWithoutExpression)
+			}
+			if c.AttributeSource != resourceAttributeSource {
+				return fmt.Errorf("invalid route %s: %w", key(item), errRecordWithContext)
+			}
+		default:
+			return fmt.Errorf("invalid route %s: unknown granularity %q", key(item), item.Granularity)
+		}
 	}
 
 	// we also need a "FromAttribute" value
@@ -110,6 +125,13 @@ const (
 	defaultAttributeSource = contextAttributeSource
 )
 
+type Granularity string
+
+const (
+	resourceGranularity = Granularity("resource")
+	recordGranularity   = Granularity("record")
+)
+
 // RoutingTableItem specifies how data should be routed to the different exporters
 type RoutingTableItem struct {
 	// Value represents a possible value for the field specified under FromAttribute.
@@ -120,6 +142,14 @@ type RoutingTableItem struct {
 	// Required when 'Value' isn't provided.
 	Expression string `mapstructure:"expression"`
 
+	// Granularity defines what the expression is evaluated against. The allowed values are:
+	// - "resource" - the expression is evaluated once per resource, routing the whole resource
+	// - "record" - the expression is evaluated for each span, log record or metric data point,
+	//   routing only the matching ones and splitting the resources accordingly
+	// The default value is "resource".
+	// Optional.
+	Granularity Granularity `mapstructure:"granularity"`
+
 	// Exporters contains the list of exporters to use when the value from the FromAttribute field matches this table item.
 	// When no exporters are specified, the ones specified under DefaultExporters are used, if any.
 	// The routing processor will fail upon the first failure from these exporters.
@@ -86,6 +86,26 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configPath: "config_logs_records.yaml",
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				DefaultExporters:  []string{"logging/default"},
+				AttributeSource:   "resource",
+				FromAttribute:     "X-Tenant",
+				Table: []RoutingTableItem{
+					{
+						Value:     "acme",
+						Exporters: []string{"logging/acme"},
+					},
+					{
+						Expression:  `route() where attributes["log.type"] == "audit"`,
+						Granularity: "record",
+						Exporters:   []string{"logging/audit"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range testcases {
@@ -155,6 +175,51 @@ func TestValidateConfig(t *testing.T) {
 			},
 			error: "using a different attribute source than 'attribute' and drop_resource_routing_attribute is set to true",
 		},
+		{
+			name: "record granularity without expression",
+			config: &Config{
+				FromAttribute:   "attr",
+				AttributeSource: resourceAttributeSource,
+				Table: []RoutingTableItem{
+					{
+						Exporters:   []string{"otlp"},
+						Value:       "acme",
+						Granularity: recordGranularity,
+					},
+				},
+			},
+			error: "invalid route acme: routing at the record granularity requires an expression",
+		},
+		{
+			name: "record granularity with context as routing attribute source",
+			config: &Config{
+				FromAttribute:   "attr",
+				AttributeSource: contextAttributeSource,
+				Table: []RoutingTableItem{
+					{
+						Exporters:   []string{"otlp"},
+						Expression:  `route() where attributes["audit"] == true`,
+						Granularity: recordGranularity,
+					},
+				},
+			},
+			error: "invalid route route() where attributes[\"audit\"] == true: routing at the record granularity requires the resource attribute source",
+		},
+		{
+			name: "unknown granularity",
+			config: &Config{
+				FromAttribute:   "attr",
+				AttributeSource: resourceAttributeSource,
+				Table: []RoutingTableItem{
+					{
+						Exporters:   []string{"otlp"},
+						Value:       "acme",
+						Granularity: "scope",
+					},
+				},
+			},
+			error: "invalid route acme: unknown granularity \"scope\"",
+		},
 	}
 
 	for _, tt := range tests {
@@ -279,6 +344,11 @@ func TestRewriteLegacyConfigToOTTL(t *testing.T) {
 						Exporters:  []string{"otlp/2"},
 						Expression: `route() where resource.attributes["attr"] == "ecorp"`,
 					},
+					{
+						Exporters:   []string{"otlp/3"},
+						Expression:  `route() where attributes["audit"] == true`,
+						Granularity: recordGranularity,
+					},
 				},
 			},
 			want: Config{
@@ -291,6 +361,11 @@ func TestRewriteLegacyConfigToOTTL(t *testing.T) {
 						Exporters:  []string{"otlp/2"},
 						Expression: `route() where resource.attributes["attr"] == "ecorp"`,
 					},
+					{
+						Exporters:   []string{"otlp/3"},
+						Expression:  `route() where attributes["audit"] == true`,
+						Granularity: recordGranularity,
+					},
 				},
 			},
 		},
@@ -48,6 +48,8 @@ func newLogProcessor(logger *zap.Logger, config config.Processor) *logProcessor
 			cfg.Table,
 			cfg.DefaultExporters,
 			logger,
+			ottllogs.ParsePath,
+			ottllogs.ParseEnum,
 		),
 		extractor: newExtractor(cfg.FromAttribute, logger),
 	}
@@ -87,6 +89,9 @@ func (p *logProcessor) route(ctx context.Context, l plog.Logs) error {
 	// This way we're not ending up with all the logs split up which would cause
 	// higher CPU usage.
 	groups := map[string]logsGroup{}
+	// recordGroups is used to group the log records routed by record level
+	// routes, kept apart as their keys may be the same as resource level ones.
+	recordGroups := map[string]logsGroup{}
 	var errs error
 
 	for i := 0; i < l.ResourceLogs().Len(); i++ {
@@ -107,23 +112,92 @@ func (p *logProcessor) route(ctx context.Context, l plog.Logs) error {
 			p.group(key, groups, route.exporters, rlogs)
 		}
 
+		if len(p.router.recordRoutes) > 0 {
+			p.routeLogRecords(rlogs, matchCount > 0, groups, recordGroups)
+			continue
+		}
+
 		if matchCount == 0 {
 			// no route conditions are matched, add resource logs to default exporters group
 			p.group("", groups, p.router.defaultExporters, rlogs)
 		}
 	}
-	for _, g := range groups {
-		l := plog.NewLogs()
-		l.ResourceLogs().EnsureCapacity(g.resLogs.Len())
-		g.resLogs.MoveAndAppendTo(l.ResourceLogs())
-
-		for _, e := range g.exporters {
-			errs = multierr.Append(errs, e.ConsumeLogs(ctx, l))
+	for _, gs := range []map[string]logsGroup{groups, recordGroups} {
+		for _, g := range gs {
+			l := plog.NewLogs()
+			l.ResourceLogs().EnsureCapacity(g.resLogs.Len())
+			g.resLogs.MoveAndAppendTo(l.ResourceLogs())
+
+			for _, e := range g.exporters {
+				errs = multierr.Append(errs, e.ConsumeLogs(ctx, l))
+			}
 		}
 	}
 	return errs
 }
 
+// routeLogRecords evaluates the record level route conditions against each log record
+// of the resource logs, copying the matching log records to the group of the route.
+// The log records matching no condition are added to the default exporters group,
+// unless the resource logs already matched a resource level condition.
+func (p *logProcessor) routeLogRecords(
+	rlogs plog.ResourceLogs,
+	resourceMatched bool,
+	groups map[string]logsGroup,
+	recordGroups map[string]logsGroup,
+) {
+	// the resource logs and scope logs created in each group for the log records of this resource
+	resources := map[string]plog.ResourceLogs{}
+	for i := 0; i < rlogs.ScopeLogs().Len(); i++ {
+		slogs := rlogs.ScopeLogs().At(i)
+		scopes := map[string]plog.ScopeLogs{}
+
+		appendRecord := func(key string, groups map[string]logsGroup, exporters []component.LogsExporter, log plog.LogRecord) {
+			dest, ok := scopes[key]
+			if !ok {
+				res, ok := resources[key]
+				if !ok {
+					group, ok := groups[key]
+					if !ok {
+						group.resLogs = plog.NewResourceLogsSlice()
+						group.exporters = exporters
+					}
+					res = group.resLogs.AppendEmpty()
+					rlogs.Resource().CopyTo(res.Resource())
+					res.SetSchemaUrl(rlogs.SchemaUrl())
+					groups[key] = group
+					resources[key] = res
+				}
+				dest = res.ScopeLogs().AppendEmpty()
+				slogs.Scope().CopyTo(dest.Scope())
+				dest.SetSchemaUrl(slogs.SchemaUrl())
+				scopes[key] = dest
+			}
+			log.CopyTo(dest.LogRecords().AppendEmpty())
+		}
+
+		for j := 0; j < slogs.LogRecords().Len(); j++ {
+			log := slogs.LogRecords().At(j)
+			ltx := ottllogs.NewTransformContext(log, slogs.Scope(), rlogs.Resource())
+
+			matched := resourceMatched
+			for key, route := range p.router.recordRoutes {
+				if !route.expression.Condition(ltx) {
+					continue
+				}
+				route.expression.Function(ltx)
+				appendRecord(key, recordGroups, route.exporters, log)
+				matched = true
+			}
+
+			if !matched {
+				// no route conditions are matched, add the log record to default exporters group
+				appendRecord("", groups, p.router.defaultExporters, log)
+			}
+		}
+	}
+}
+
 func (p *logProcessor) group(
 	key string,
 	groups map[string]logsGroup,
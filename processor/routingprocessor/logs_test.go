@@ -402,3 +402,96 @@ type mockLogsExporter struct {
 	mockComponent
 	consumertest.LogsSink
 }
+
+func TestLogsAreCorrectlySplitPerRecordWithOTTL(t *testing.T) {
+	defaultExp := &mockLogsExporter{}
+	tenantExp := &mockLogsExporter{}
+	auditExp := &mockLogsExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.LogsDataType: {
+					config.NewComponentID("otlp"):                  defaultExp,
+					config.NewComponentIDWithName("otlp", "acme"):  tenantExp,
+					config.NewComponentIDWithName("otlp", "audit"): auditExp,
+				},
+			}
+		},
+	}
+
+	exp := newLogProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Expression: `route() where resource.attributes["X-Tenant"] == "acme"`,
+				Exporters:  []string{"otlp/acme"},
+			},
+			{
+				Expression:  `route() where attributes["log.type"] == "audit"`,
+				Exporters:   []string{"otlp/audit"},
+				Granularity: recordGranularity,
+			},
+		},
+	})
+
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	t.Run("records split between the record route and the default exporters", func(t *testing.T) {
+		defaultExp.Reset()
+		tenantExp.Reset()
+		auditExp.Reset()
+
+		l := plog.NewLogs()
+		rl := l.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutString("k8s.pod.name", "app-1")
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName("audit-library")
+		for _, logType := range []string{"audit", "app", "audit"} {
+			lr := sl.LogRecords().AppendEmpty()
+			lr.Attributes().PutString("log.type", logType)
+			lr.Body().SetStringVal(logType)
+		}
+
+		require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+		assert.Len(t, tenantExp.AllLogs(), 0)
+
+		require.Len(t, auditExp.AllLogs(), 1)
+		audit := auditExp.AllLogs()[0]
+		require.Equal(t, 1, audit.ResourceLogs().Len())
+		require.Equal(t, 1, audit.ResourceLogs().At(0).ScopeLogs().Len())
+		assert.Equal(t, 2, audit.LogRecordCount())
+		pod, ok := audit.ResourceLogs().At(0).Resource().Attributes().Get("k8s.pod.name")
+		assert.True(t, ok)
+		assert.Equal(t, "app-1", pod.AsString())
+		assert.Equal(t, "audit-library", audit.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+
+		require.Len(t, defaultExp.AllLogs(), 1)
+		assert.Equal(t, 1, defaultExp.AllLogs()[0].LogRecordCount())
+		body := defaultExp.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body()
+		assert.Equal(t, "app", body.AsString())
+	})
+
+	t.Run("records of a resource matched by a resource route", func(t *testing.T) {
+		defaultExp.Reset()
+		tenantExp.Reset()
+		auditExp.Reset()
+
+		l := plog.NewLogs()
+		rl := l.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutString("X-Tenant", "acme")
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.LogRecords().AppendEmpty().Attributes().PutString("log.type", "audit")
+		sl.LogRecords().AppendEmpty().Attributes().PutString("log.type", "app")
+
+		require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+		assert.Len(t, defaultExp.AllLogs(), 0)
+		require.Len(t, tenantExp.AllLogs(), 1)
+		assert.Equal(t, 2, tenantExp.AllLogs()[0].LogRecordCount())
+		require.Len(t, auditExp.AllLogs(), 1)
+		assert.Equal(t, 1, auditExp.AllLogs()[0].LogRecordCount())
+	})
+}
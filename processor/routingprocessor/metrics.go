@@ -48,6 +48,8 @@ func newMetricProcessor(logger *zap.Logger, config config.Processor) *metricsPro
 			cfg.Table,
 			cfg.DefaultExporters,
 			logger,
+			ottlmetrics.ParsePath,
+			ottlmetrics.ParseEnum,
 		),
 		extractor: newExtractor(cfg.FromAttribute, logger),
 	}
@@ -86,6 +88,9 @@ func (p *metricsProcessor) route(ctx context.Context, tm pmetric.Metrics) error
 	// the same set of exporters. This way we're not ending up with all the
 	// metrics split up which would cause higher CPU usage.
 	groups := map[string]metricsGroup{}
+	// recordGroups is used to group the data points routed by record level
+	// routes, kept apart as their keys may be the same as resource level ones.
+	recordGroups := map[string]metricsGroup{}
 
 	var errs error
 
@@ -109,24 +114,163 @@ func (p *metricsProcessor) route(ctx context.Context, tm pmetric.Metrics) error
 			p.group(key, groups, route.exporters, rmetrics)
 		}
 
+		if len(p.router.recordRoutes) > 0 {
+			p.routeDataPoints(rmetrics, matchCount > 0, groups, recordGroups)
+			continue
+		}
+
 		if matchCount == 0 {
 			// no route conditions are matched, add resource metrics to default exporters group
 			p.group("", groups, p.router.defaultExporters, rmetrics)
 		}
 	}
 
-	for _, g := range groups {
-		m := pmetric.NewMetrics()
-		m.ResourceMetrics().EnsureCapacity(g.resMetrics.Len())
-		g.resMetrics.MoveAndAppendTo(m.ResourceMetrics())
+	for _, gs := range []map[string]metricsGroup{groups, recordGroups} {
+		for _, g := range gs {
+			m := pmetric.NewMetrics()
+			m.ResourceMetrics().EnsureCapacity(g.resMetrics.Len())
+			g.resMetrics.MoveAndAppendTo(m.ResourceMetrics())
 
-		for _, e := range g.exporters {
-			errs = multierr.Append(errs, e.ConsumeMetrics(ctx, m))
+			for _, e := range g.exporters {
+				errs = multierr.Append(errs, e.ConsumeMetrics(ctx, m))
+			}
 		}
 	}
 	return errs
 }
 
+// routeDataPoints evaluates the record level route conditions against each data point
+// of the resource metrics, copying the matching data points to the group of the route.
+// The data points matching no condition are added to the default exporters group,
+// unless the resource metrics already matched a resource level condition.
+func (p *metricsProcessor) routeDataPoints(
+	rmetrics pmetric.ResourceMetrics,
+	resourceMatched bool,
+	groups map[string]metricsGroup,
+	recordGroups map[string]metricsGroup,
+) {
+	// the resource metrics and scope metrics created in each group for the data points of this resource
+	resources := map[string]pmetric.ResourceMetrics{}
+	for i := 0; i < rmetrics.ScopeMetrics().Len(); i++ {
+		smetrics := rmetrics.ScopeMetrics().At(i)
+		scopes := map[string]pmetric.ScopeMetrics{}
+
+		for j := 0; j < smetrics.Metrics().Len(); j++ {
+			metric := smetrics.Metrics().At(j)
+			metrics := map[string]pmetric.Metric{}
+
+			destination := func(key string, groups map[string]metricsGroup, exporters []component.MetricsExporter) pmetric.Metric {
+				if dest, ok := metrics[key]; ok {
+					return dest
+				}
+				scope, ok := scopes[key]
+				if !ok {
+					res, ok := resources[key]
+					if !ok {
+						group, ok := groups[key]
+						if !ok {
+							group.resMetrics = pmetric.NewResourceMetricsSlice()
+							group.exporters = exporters
+						}
+						res = group.resMetrics.AppendEmpty()
+						rmetrics.Resource().CopyTo(res.Resource())
+						res.SetSchemaUrl(rmetrics.SchemaUrl())
+						groups[key] = group
+						resources[key] = res
+					}
+					scope = res.ScopeMetrics().AppendEmpty()
+					smetrics.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(smetrics.SchemaUrl())
+					scopes[key] = scope
+				}
+				dest := scope.Metrics().AppendEmpty()
+				copyMetricDescriptor(metric, dest)
+				metrics[key] = dest
+				return dest
+			}
+
+			// destinations returns the metrics of the groups the data point is routed to
+			destinations := func(dataPoint interface{}) []pmetric.Metric {
+				mtx := ottlmetrics.NewTransformContext(dataPoint, metric, smetrics.Metrics(), smetrics.Scope(), rmetrics.Resource())
+
+				var dests []pmetric.Metric
+				for key, route := range p.router.recordRoutes {
+					if !route.expression.Condition(mtx) {
+						continue
+					}
+					route.expression.Function(mtx)
+					dests = append(dests, destination(key, recordGroups, route.exporters))
+				}
+
+				if len(dests) == 0 && !resourceMatched {
+					// no route conditions are matched, add the data point to default exporters group
+					dests = append(dests, destination("", groups, p.router.defaultExporters))
+				}
+				return dests
+			}
+
+			switch metric.DataType() {
+			case pmetric.MetricDataTypeGauge:
+				dps := metric.Gauge().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					for _, dest := range destinations(dps.At(k)) {
+						dps.At(k).CopyTo(dest.Gauge().DataPoints().AppendEmpty())
+					}
+				}
+			case pmetric.MetricDataTypeSum:
+				dps := metric.Sum().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					for _, dest := range destinations(dps.At(k)) {
+						dps.At(k).CopyTo(dest.Sum().DataPoints().AppendEmpty())
+					}
+				}
+			case pmetric.MetricDataTypeHistogram:
+				dps := metric.Histogram().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					for _, dest := range destinations(dps.At(k)) {
+						dps.At(k).CopyTo(dest.Histogram().DataPoints().AppendEmpty())
+					}
+				}
+			case pmetric.MetricDataTypeExponentialHistogram:
+				dps := metric.ExponentialHistogram().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					for _, dest := range destinations(dps.At(k)) {
+						dps.At(k).CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty())
+					}
+				}
+			case pmetric.MetricDataTypeSummary:
+				dps := metric.Summary().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					for _, dest := range destinations(dps.At(k)) {
+						dps.At(k).CopyTo(dest.Summary().DataPoints().AppendEmpty())
+					}
+				}
+			}
+		}
+	}
+}
+
+// copyMetricDescriptor copies the metric properties but the data points to the destination metric.
+func copyMetricDescriptor(src pmetric.Metric, dest pmetric.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+
+	switch src.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricDataTypeSum:
+		dest.SetEmptySum().SetAggregationTemporality(src.Sum().AggregationTemporality())
+		dest.Sum().SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricDataTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricDataTypeSummary:
+		dest.SetEmptySummary()
+	}
+}
+
 func (p *metricsProcessor) group(
 	key string,
 	groups map[string]metricsGroup,
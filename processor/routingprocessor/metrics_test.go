@@ -468,3 +468,66 @@ func TestMetricsAreCorrectlySplitPerResourceAttributeRoutingWithOTTL(t *testing.
 		assert.Equal(t, attr.DoubleVal(), float64(-1.0))
 	})
 }
+
+func TestMetricsAreCorrectlySplitPerDataPointWithOTTL(t *testing.T) {
+	defaultExp := &mockMetricsExporter{}
+	prodExp := &mockMetricsExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.MetricsDataType: {
+					config.NewComponentID("otlp"):                 defaultExp,
+					config.NewComponentIDWithName("otlp", "prod"): prodExp,
+				},
+			}
+		},
+	}
+
+	exp := newMetricProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Expression:  `route() where attributes["env"] == "prod"`,
+				Exporters:   []string{"otlp/prod"},
+				Granularity: recordGranularity,
+			},
+		},
+	})
+
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	m := pmetric.NewMetrics()
+	rm := m.ResourceMetrics().AppendEmpty()
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	for _, env := range []string{"prod", "dev", "prod"} {
+		sum.Sum().DataPoints().AppendEmpty().Attributes().PutString("env", env)
+	}
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutString("env", "dev")
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), m))
+
+	require.Len(t, prodExp.AllMetrics(), 1)
+	prod := prodExp.AllMetrics()[0]
+	assert.Equal(t, 1, prod.MetricCount())
+	assert.Equal(t, 2, prod.DataPointCount())
+	prodSum := prod.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests", prodSum.Name())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, prodSum.Sum().AggregationTemporality())
+	assert.True(t, prodSum.Sum().IsMonotonic())
+
+	require.Len(t, defaultExp.AllMetrics(), 1)
+	dev := defaultExp.AllMetrics()[0]
+	assert.Equal(t, 2, dev.MetricCount())
+	assert.Equal(t, 2, dev.DataPointCount())
+	assert.Equal(t, pmetric.MetricDataTypeHistogram, dev.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).DataType())
+}
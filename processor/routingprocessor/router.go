@@ -33,22 +33,29 @@ var errExporterNotFound = errors.New("exporter not found")
 // be instantiated with component.TracesExporter, component.MetricsExporter, and
 // component.LogsExporter type arguments.
 type router[E component.Exporter] struct {
-	logger *zap.Logger
-	parser ottl.Parser
+	logger       *zap.Logger
+	parser       ottl.Parser
+	recordParser ottl.Parser
 
 	defaultExporterIDs []string
 	table              []RoutingTableItem
 
 	defaultExporters []E
 	routes           map[string]routingItem[E]
+	// recordRoutes are the routes evaluated against each span, log record
+	// or metric data point.
+	recordRoutes map[string]routingItem[E]
 }
 
 // newRouter creates a new router instance with its type parameter constrained
-// to component.Exporter.
+// to component.Exporter. The record level routing expressions are parsed using
+// the provided path and enum parsers of the signal's OTTL context.
 func newRouter[E component.Exporter](
 	table []RoutingTableItem,
 	defaultExporterIDs []string,
 	logger *zap.Logger,
+	pathParser ottl.PathExpressionParser,
+	enumParser ottl.EnumParser,
 ) router[E] {
 	return router[E]{
 		logger: logger,
@@ -58,11 +65,18 @@ func newRouter[E component.Exporter](
 			ottllogs.ParseEnum,
 			common.NewOTTLLogger(logger),
 		),
+		recordParser: ottl.NewParser(
+			common.Functions(),
+			pathParser,
+			enumParser,
+			common.NewOTTLLogger(logger),
+		),
 
 		table:              table,
 		defaultExporterIDs: defaultExporterIDs,
 
-		routes: make(map[string]routingItem[E]),
+		routes:       make(map[string]routingItem[E]),
+		recordRoutes: make(map[string]routingItem[E]),
 	}
 }
 
@@ -108,12 +122,17 @@ func (r *router[E]) registerDefaultExporters(available map[config.ComponentID]co
 // available exporters map to check if they were available.
 func (r *router[E]) registerRouteExporters(available map[config.ComponentID]component.Exporter) error {
 	for _, item := range r.table {
-		e, err := r.routingExpression(item)
+		parser, routes := r.parser, r.routes
+		if item.Granularity == recordGranularity {
+			parser, routes = r.recordParser, r.recordRoutes
+		}
+
+		e, err := r.routingExpression(parser, item)
 		if err != nil {
 			return err
 		}
 
-		route, ok := r.routes[key(item)]
+		route, ok := routes[key(item)]
 		if !ok {
 			route.expression = e
 		}
@@ -128,7 +147,7 @@ func (r *router[E]) registerRouteExporters(available map[config.ComponentID]comp
 			}
 			route.exporters = append(route.exporters, e)
 		}
-		r.routes[key(item)] = route
+		routes[key(item)] = route
 	}
 	return nil
 }
@@ -136,10 +155,10 @@ func (r *router[E]) registerRouteExporters(available map[config.ComponentID]comp
 // routingExpression builds a routing OTTL expressions from provided
 // routing table entry configuration. If routing table entry configuration
 // does not contain a OTTL expressions then nil is returned.
-func (r *router[E]) routingExpression(item RoutingTableItem) (ottl.Query, error) {
+func (r *router[E]) routingExpression(parser ottl.Parser, item RoutingTableItem) (ottl.Query, error) {
 	var e ottl.Query
 	if item.Expression != "" {
-		queries, err := r.parser.ParseQueries([]string{item.Expression})
+		queries, err := parser.ParseQueries([]string{item.Expression})
 		if err != nil {
 			return e, err
 		}
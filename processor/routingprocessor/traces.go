@@ -48,6 +48,8 @@ func newTracesProcessor(logger *zap.Logger, config config.Processor) *tracesProc
 			cfg.Table,
 			cfg.DefaultExporters,
 			logger,
+			ottltraces.ParsePath,
+			ottltraces.ParseEnum,
 		),
 		extractor: newExtractor(cfg.FromAttribute, logger),
 	}
@@ -87,6 +89,9 @@ func (p *tracesProcessor) route(ctx context.Context, t ptrace.Traces) error {
 	// the same set of exporters. This way we're not ending up with all the
 	// logs split up which would cause higher CPU usage.
 	groups := map[string]spanGroup{}
+	// recordGroups is used to group the spans routed by record level
+	// routes, kept apart as their keys may be the same as resource level ones.
+	recordGroups := map[string]spanGroup{}
 
 	var errs error
 	for i := 0; i < t.ResourceSpans().Len(); i++ {
@@ -107,24 +112,93 @@ func (p *tracesProcessor) route(ctx context.Context, t ptrace.Traces) error {
 			p.group(key, groups, route.exporters, rspans)
 		}
 
+		if len(p.router.recordRoutes) > 0 {
+			p.routeSpans(rspans, matchCount > 0, groups, recordGroups)
+			continue
+		}
+
 		if matchCount == 0 {
 			// no route conditions are matched, add resource spans to default exporters group
 			p.group("", groups, p.router.defaultExporters, rspans)
 		}
 	}
 
-	for _, g := range groups {
-		t := ptrace.NewTraces()
-		t.ResourceSpans().EnsureCapacity(g.resSpans.Len())
-		g.resSpans.MoveAndAppendTo(t.ResourceSpans())
+	for _, gs := range []map[string]spanGroup{groups, recordGroups} {
+		for _, g := range gs {
+			t := ptrace.NewTraces()
+			t.ResourceSpans().EnsureCapacity(g.resSpans.Len())
+			g.resSpans.MoveAndAppendTo(t.ResourceSpans())
 
-		for _, e := range g.exporters {
-			errs = multierr.Append(errs, e.ConsumeTraces(ctx, t))
+			for _, e := range g.exporters {
+				errs = multierr.Append(errs, e.ConsumeTraces(ctx, t))
+			}
 		}
 	}
 	return errs
 }
 
+// routeSpans evaluates the record level route conditions against each span of the
+// resource spans, copying the matching spans to the group of the route.
+// The spans matching no condition are added to the default exporters group,
+// unless the resource spans already matched a resource level condition.
+func (p *tracesProcessor) routeSpans(
+	rspans ptrace.ResourceSpans,
+	resourceMatched bool,
+	groups map[string]spanGroup,
+	recordGroups map[string]spanGroup,
+) {
+	// the resource spans and scope spans created in each group for the spans of this resource
+	resources := map[string]ptrace.ResourceSpans{}
+	for i := 0; i < rspans.ScopeSpans().Len(); i++ {
+		sspans := rspans.ScopeSpans().At(i)
+		scopes := map[string]ptrace.ScopeSpans{}
+
+		appendSpan := func(key string, groups map[string]spanGroup, exporters []component.TracesExporter, span ptrace.Span) {
+			dest, ok := scopes[key]
+			if !ok {
+				res, ok := resources[key]
+				if !ok {
+					group, ok := groups[key]
+					if !ok {
+						group.resSpans = ptrace.NewResourceSpansSlice()
+						group.exporters = exporters
+					}
+					res = group.resSpans.AppendEmpty()
+					rspans.Resource().CopyTo(res.Resource())
+					res.SetSchemaUrl(rspans.SchemaUrl())
+					groups[key] = group
+					resources[key] = res
+				}
+				dest = res.ScopeSpans().AppendEmpty()
+				sspans.Scope().CopyTo(dest.Scope())
+				dest.SetSchemaUrl(sspans.SchemaUrl())
+				scopes[key] = dest
+			}
+			span.CopyTo(dest.Spans().AppendEmpty())
+		}
+
+		for j := 0; j < sspans.Spans().Len(); j++ {
+			span := sspans.Spans().At(j)
+			stx := ottltraces.NewTransformContext(span, sspans.Scope(), rspans.Resource())
+
+			matched := resourceMatched
+			for key, route := range p.router.recordRoutes {
+				if !route.expression.Condition(stx) {
+					continue
+				}
+				route.expression.Function(stx)
+				appendSpan(key, recordGroups, route.exporters, span)
+				matched = true
+			}
+
+			if !matched {
+				// no route conditions are matched, add the span to default exporters group
+				appendSpan("", groups, p.router.defaultExporters, span)
+			}
+		}
+	}
+}
+
 func (p *tracesProcessor) group(key string, groups map[string]spanGroup, exporters []component.TracesExporter, spans ptrace.ResourceSpans) {
 	group, ok := groups[key]
 	if !ok {
@@ -483,3 +483,69 @@ type mockTracesExporter struct {
 	mockComponent
 	consumertest.TracesSink
 }
+
+func TestTracesAreCorrectlySplitPerSpanWithOTTL(t *testing.T) {
+	defaultExp := &mockTracesExporter{}
+	checkoutExp := &mockTracesExporter{}
+	errorExp := &mockTracesExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.TracesDataType: {
+					config.NewComponentID("otlp"):                     defaultExp,
+					config.NewComponentIDWithName("otlp", "checkout"): checkoutExp,
+					config.NewComponentIDWithName("otlp", "errors"):   errorExp,
+				},
+			}
+		},
+	}
+
+	exp := newTracesProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Expression:  `route() where name == "checkout"`,
+				Exporters:   []string{"otlp/checkout"},
+				Granularity: recordGranularity,
+			},
+			{
+				Expression:  `route() where attributes["error"] == true`,
+				Exporters:   []string{"otlp/errors"},
+				Granularity: recordGranularity,
+			},
+		},
+	})
+
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	tr := ptrace.NewTraces()
+	rs := tr.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutString("service.name", "shop")
+	for _, ss := range []ptrace.ScopeSpans{rs.ScopeSpans().AppendEmpty(), rs.ScopeSpans().AppendEmpty()} {
+		ss.Spans().AppendEmpty().SetName("checkout")
+		span := ss.Spans().AppendEmpty()
+		span.SetName("cart")
+		span.Attributes().PutBool("error", true)
+		ss.Spans().AppendEmpty().SetName("browse")
+	}
+	checkout := rs.ScopeSpans().At(1).Spans().At(0)
+	checkout.Attributes().PutBool("error", true)
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), tr))
+
+	require.Len(t, checkoutExp.AllTraces(), 1)
+	assert.Equal(t, 2, checkoutExp.AllTraces()[0].SpanCount())
+	assert.Equal(t, 1, checkoutExp.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, 2, checkoutExp.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().Len())
+
+	require.Len(t, errorExp.AllTraces(), 1)
+	assert.Equal(t, 3, errorExp.AllTraces()[0].SpanCount())
+
+	require.Len(t, defaultExp.AllTraces(), 1)
+	assert.Equal(t, 2, defaultExp.AllTraces()[0].SpanCount())
+	service, ok := defaultExp.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get("service.name")
+	assert.True(t, ok)
+	assert.Equal(t, "shop", service.AsString())
+}
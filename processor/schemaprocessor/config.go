@@ -27,6 +27,7 @@ import (
 var (
 	errRequiresTargets  = errors.New("requires schema targets")
 	errDuplicateTargets = errors.New("duplicate targets detected")
+	errEmptySchemaFile  = errors.New("empty schema file path")
 )
 
 // Config defines the user provided values for the Schema Processor
@@ -44,6 +45,12 @@ type Config struct {
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
 	Targets []string `mapstructure:"targets"`
+
+	// SchemaFiles maps schema URLs to local schema files
+	// that are used instead of fetching the schema URL,
+	// allowing the processor to be used without network access
+	// to the schema families. (Optional field)
+	SchemaFiles map[string]string `mapstructure:"schema_files"`
 }
 
 func (c *Config) Validate() error {
@@ -53,6 +60,15 @@ func (c *Config) Validate() error {
 			return err
 		}
 	}
+	for schemaURL, path := range c.SchemaFiles {
+		_, _, err := translation.GetFamilyAndVersion(schemaURL)
+		if err != nil {
+			return err
+		}
+		if path == "" {
+			return fmt.Errorf("schema file for %q: %w", schemaURL, errEmptySchemaFile)
+		}
+	}
 	// Not strictly needed since it would just pass on
 	// any data that doesn't match targets, however defining
 	// this processor with no targets is wasteful.
@@ -50,6 +50,9 @@ func TestLoadConfig(t *testing.T) {
 			"https://opentelemetry.io/schemas/1.4.2",
 			"https://example.com/otel/schemas/1.2.0",
 		},
+		SchemaFiles: map[string]string{
+			"https://example.com/otel/schemas/1.2.0": "/etc/otel/schemas/1.2.0.yml",
+		},
 	}, cfg)
 }
 
@@ -59,6 +62,7 @@ func TestConfigurationValidation(t *testing.T) {
 	tests := []struct {
 		scenario    string
 		target      []string
+		schemaFiles map[string]string
 		expectError error
 	}{
 		{scenario: "No targets", target: nil, expectError: errRequiresTargets},
@@ -87,11 +91,32 @@ func TestConfigurationValidation(t *testing.T) {
 			},
 			expectError: errDuplicateTargets,
 		},
+		{
+			scenario: "Schema file with invalid schema url",
+			target: []string{
+				"https://opentelemetry.io/schemas/1.9.0",
+			},
+			schemaFiles: map[string]string{
+				"opentelemetry.io/schemas/1.9.0": "schema.yml",
+			},
+			expectError: translation.ErrInvalidFamily,
+		},
+		{
+			scenario: "Schema file without path",
+			target: []string{
+				"https://opentelemetry.io/schemas/1.9.0",
+			},
+			schemaFiles: map[string]string{
+				"https://opentelemetry.io/schemas/1.9.0": "",
+			},
+			expectError: errEmptySchemaFile,
+		},
 	}
 
 	for _, tc := range tests {
 		cfg := &Config{
-			Targets: tc.target,
+			Targets:     tc.target,
+			SchemaFiles: tc.schemaFiles,
 		}
 
 		assert.ErrorIs(t, cfg.Validate(), tc.expectError, tc.scenario)
@@ -0,0 +1,139 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// minRetryBackoff is how long a failed schema url is not looked up again,
+	// doubled on each consecutive failure up to maxRetryBackoff.
+	minRetryBackoff = 5 * time.Second
+	maxRetryBackoff = 5 * time.Minute
+)
+
+// ErrRetryBackoff is returned when a schema url failed to be looked up
+// recently and is not looked up again until its retry backoff elapses.
+var ErrRetryBackoff = errors.New("schema url lookup failed recently, not retrying yet")
+
+// failure records the last failed lookup of a schema url.
+type failure struct {
+	err     error
+	backoff time.Duration
+	retryAt time.Time
+}
+
+// Manager looks up and caches the translations of schema urls.
+// Failed lookups are cached as well, and retried with an exponential backoff
+// so that an unavailable schema url isn't fetched for every signal.
+type Manager struct {
+	log       *zap.Logger
+	providers []Provider
+	now       func() time.Time
+
+	mu           sync.RWMutex
+	translations map[string]*Translation
+	failures     map[string]*failure
+}
+
+// NewManager creates a Manager that consults the providers in order
+// until one of them knows the requested schema url.
+func NewManager(log *zap.Logger, providers ...Provider) *Manager {
+	return &Manager{
+		log:          log,
+		providers:    providers,
+		now:          time.Now,
+		translations: make(map[string]*Translation),
+		failures:     make(map[string]*failure),
+	}
+}
+
+// RequestTranslation returns the translation defined by the
+// schema file published at schemaURL, fetching it if not yet cached.
+// An error wrapping ErrRetryBackoff is returned without any lookup
+// while the schema url is backing off from a previous failure.
+func (m *Manager) RequestTranslation(ctx context.Context, schemaURL string) (*Translation, error) {
+	m.mu.RLock()
+	t, ok := m.translations[schemaURL]
+	f := m.failures[schemaURL]
+	m.mu.RUnlock()
+	if ok {
+		return t, nil
+	}
+	if f != nil && m.now().Before(f.retryAt) {
+		return nil, fmt.Errorf("%s: %w: %v", schemaURL, ErrRetryBackoff, f.err)
+	}
+
+	t, err := m.lookup(ctx, schemaURL)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cached, ok := m.translations[schemaURL]; ok {
+		return cached, nil
+	}
+	if err != nil {
+		// A cancelled request says nothing about the schema url.
+		if ctx.Err() == nil {
+			m.recordFailure(schemaURL, err)
+		}
+		return nil, err
+	}
+	delete(m.failures, schemaURL)
+	m.translations[schemaURL] = t
+	return t, nil
+}
+
+// recordFailure caches the failed lookup of schemaURL,
+// doubling its retry backoff if it already failed before.
+func (m *Manager) recordFailure(schemaURL string, err error) {
+	backoff := minRetryBackoff
+	if prev, ok := m.failures[schemaURL]; ok {
+		if m.now().Before(prev.retryAt) {
+			// A concurrent lookup already recorded this failure.
+			return
+		}
+		backoff = prev.backoff * 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+	m.failures[schemaURL] = &failure{
+		err:     err,
+		backoff: backoff,
+		retryAt: m.now().Add(backoff),
+	}
+}
+
+func (m *Manager) lookup(ctx context.Context, schemaURL string) (*Translation, error) {
+	for _, p := range m.providers {
+		content, err := p.Lookup(ctx, schemaURL)
+		if errors.Is(err, ErrSchemaNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		m.log.Debug("Loaded schema translation", zap.String("schema-url", schemaURL))
+		return NewTranslation(content)
+	}
+	return nil, fmt.Errorf("%s: %w", schemaURL, ErrSchemaNotFound)
+}
@@ -0,0 +1,134 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestManagerRequestTranslation(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "schema.yml"))
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		requests.Inc()
+		if r.URL.Path != "/schemas/1.2.0" {
+			wr.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := wr.Write(content)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	local := "https://example.com/schemas/1.2.0"
+	m := NewManager(
+		zaptest.NewLogger(t),
+		NewFileProvider(map[string]string{local: filepath.Join("testdata", "schema.yml")}),
+		NewHTTPProvider(srv.Client()),
+	)
+
+	tr, err := m.RequestTranslation(context.Background(), local)
+	require.NoError(t, err, "Must load the schema from the local file")
+	assert.Equal(t, "https://example.com/schemas", tr.Family())
+	assert.Equal(t, int32(0), requests.Load(), "Must not fetch schemas that are available locally")
+
+	remote := srv.URL + "/schemas/1.2.0"
+	tr, err = m.RequestTranslation(context.Background(), remote)
+	require.NoError(t, err, "Must fetch the schema over http")
+	assert.True(t, tr.SupportedVersion(&Version{Major: 1, Minor: 1}))
+
+	cached, err := m.RequestTranslation(context.Background(), remote)
+	require.NoError(t, err)
+	assert.Same(t, tr, cached, "Must return the cached translation")
+	assert.Equal(t, int32(1), requests.Load())
+
+	_, err = m.RequestTranslation(context.Background(), srv.URL+"/schemas/1.3.0")
+	assert.Error(t, err, "Must error when the schema is not available")
+}
+
+func TestManagerNoProvider(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(zaptest.NewLogger(t), NewFileProvider(nil))
+	_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+}
+
+func TestManagerRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests  atomic.Int32
+		available atomic.Bool
+	)
+	content, err := os.ReadFile(filepath.Join("testdata", "schema.yml"))
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		requests.Inc()
+		if !available.Load() {
+			wr.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, err := wr.Write(content)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	now := time.Unix(1000, 0)
+	m := NewManager(zaptest.NewLogger(t), NewHTTPProvider(srv.Client()))
+	m.now = func() time.Time { return now }
+
+	schemaURL := srv.URL + "/schemas/1.2.0"
+	_, err = m.RequestTranslation(context.Background(), schemaURL)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrRetryBackoff)
+	assert.Equal(t, int32(1), requests.Load())
+
+	_, err = m.RequestTranslation(context.Background(), schemaURL)
+	assert.ErrorIs(t, err, ErrRetryBackoff, "Must not look up a failed schema url before its backoff elapses")
+	assert.Equal(t, int32(1), requests.Load())
+
+	now = now.Add(minRetryBackoff)
+	_, err = m.RequestTranslation(context.Background(), schemaURL)
+	assert.NotErrorIs(t, err, ErrRetryBackoff)
+	assert.Equal(t, int32(2), requests.Load())
+
+	now = now.Add(minRetryBackoff)
+	_, err = m.RequestTranslation(context.Background(), schemaURL)
+	assert.ErrorIs(t, err, ErrRetryBackoff, "Must double the backoff on consecutive failures")
+	assert.Equal(t, int32(2), requests.Load())
+
+	available.Store(true)
+	now = now.Add(minRetryBackoff)
+	tr, err := m.RequestTranslation(context.Background(), schemaURL)
+	require.NoError(t, err, "Must retry once the backoff elapsed")
+	assert.Equal(t, "https://example.com/schemas", tr.Family())
+	assert.Equal(t, int32(3), requests.Load())
+}
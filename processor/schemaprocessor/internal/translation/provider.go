@@ -0,0 +1,85 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// ErrSchemaNotFound is returned by a Provider that does not know the requested schema url.
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// Provider looks up the content of the schema file published at a schema url.
+type Provider interface {
+	Lookup(ctx context.Context, schemaURL string) (io.Reader, error)
+}
+
+type fileProvider struct {
+	files map[string]string
+}
+
+// NewFileProvider returns a Provider that reads schema files from the local
+// file system, files maps each schema url to the path of its schema file.
+func NewFileProvider(files map[string]string) Provider {
+	return fileProvider{files: files}
+}
+
+func (fp fileProvider) Lookup(_ context.Context, schemaURL string) (io.Reader, error) {
+	path, ok := fp.files[schemaURL]
+	if !ok {
+		return nil, ErrSchemaNotFound
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(content), nil
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+// NewHTTPProvider returns a Provider that downloads schema files from their schema url.
+func NewHTTPProvider(client *http.Client) Provider {
+	return httpProvider{client: client}
+}
+
+func (hp httpProvider) Lookup(ctx context.Context, schemaURL string) (io.Reader, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching %s", resp.StatusCode, schemaURL)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(content), nil
+}
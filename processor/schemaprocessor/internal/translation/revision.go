@@ -0,0 +1,335 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	ast "go.opentelemetry.io/otel/schema/v1.0/ast"
+)
+
+// renames maps a name used in the previous version to the
+// name used starting from the revision's version.
+type renames map[string]string
+
+// inverse returns the renames required to go back to the previous version.
+func (r renames) inverse() renames {
+	inv := make(renames, len(r))
+	for from, to := range r {
+		inv[to] = from
+	}
+	return inv
+}
+
+func (r renames) direction(forward bool) renames {
+	if forward {
+		return r
+	}
+	return r.inverse()
+}
+
+// apply renames the matching attributes, an attribute is left untouched
+// if the new name is already in use so that no data is ever overwritten.
+func (r renames) applyAttributes(attrs pcommon.Map) {
+	for from, to := range r {
+		v, ok := attrs.Get(from)
+		if !ok {
+			continue
+		}
+		if _, exist := attrs.Get(to); exist {
+			continue
+		}
+		v.CopyTo(attrs.PutEmpty(to))
+		attrs.Remove(from)
+	}
+}
+
+// rename returns the new name if the provided one needs changing.
+func (r renames) rename(name string) (string, bool) {
+	to, ok := r[name]
+	return to, ok
+}
+
+// matcher is a set of names a change is restricted to,
+// an empty matcher matches every name.
+type matcher map[string]struct{}
+
+func newMatcher[T ~string](names []T) matcher {
+	m := make(matcher, len(names))
+	for _, name := range names {
+		m[string(name)] = struct{}{}
+	}
+	return m
+}
+
+func (m matcher) matches(name string) bool {
+	if len(m) == 0 {
+		return true
+	}
+	_, ok := m[name]
+	return ok
+}
+
+type spanChange struct {
+	applyToSpans matcher
+	attributes   renames
+}
+
+type spanEventChange struct {
+	names         renames
+	applyToSpans  matcher
+	applyToEvents matcher
+	attributes    renames
+}
+
+type metricChange struct {
+	names          renames
+	applyToMetrics matcher
+	attributes     renames
+}
+
+// Revision holds all the changes that were introduced
+// with a single version of a schema family.
+type Revision struct {
+	ver *Version
+
+	all        []renames
+	resources  []renames
+	spans      []spanChange
+	spanEvents []spanEventChange
+	metrics    []metricChange
+	logs       []renames
+}
+
+// NewRevision converts the schema definitions of a version into a Revision.
+func NewRevision(ver *Version, def ast.VersionDef) *Revision {
+	r := &Revision{ver: ver}
+	for _, change := range def.All.Changes {
+		if change.RenameAttributes != nil {
+			r.all = append(r.all, renames(*change.RenameAttributes))
+		}
+	}
+	for _, change := range def.Resources.Changes {
+		if change.RenameAttributes != nil {
+			r.resources = append(r.resources, renames(*change.RenameAttributes))
+		}
+	}
+	for _, change := range def.Spans.Changes {
+		if change.RenameAttributes != nil {
+			r.spans = append(r.spans, spanChange{
+				applyToSpans: newMatcher(change.RenameAttributes.ApplyToSpans),
+				attributes:   renames(change.RenameAttributes.AttributeMap),
+			})
+		}
+	}
+	for _, change := range def.SpanEvents.Changes {
+		if change.RenameEvents != nil {
+			r.spanEvents = append(r.spanEvents, spanEventChange{
+				names: renames(change.RenameEvents.EventNameMap),
+			})
+		}
+		if change.RenameAttributes != nil {
+			r.spanEvents = append(r.spanEvents, spanEventChange{
+				applyToSpans:  newMatcher(change.RenameAttributes.ApplyToSpans),
+				applyToEvents: newMatcher(change.RenameAttributes.ApplyToEvents),
+				attributes:    renames(change.RenameAttributes.AttributeMap),
+			})
+		}
+	}
+	for _, change := range def.Metrics.Changes {
+		if len(change.RenameMetrics) > 0 {
+			names := make(renames, len(change.RenameMetrics))
+			for from, to := range change.RenameMetrics {
+				names[string(from)] = string(to)
+			}
+			r.metrics = append(r.metrics, metricChange{names: names})
+		}
+		if change.RenameAttributes != nil {
+			r.metrics = append(r.metrics, metricChange{
+				applyToMetrics: newMatcher(change.RenameAttributes.ApplyToMetrics),
+				attributes:     renames(change.RenameAttributes.AttributeMap),
+			})
+		}
+	}
+	for _, change := range def.Logs.Changes {
+		if change.RenameAttributes != nil {
+			r.logs = append(r.logs, renames(change.RenameAttributes.AttributeMap))
+		}
+	}
+	return r
+}
+
+// Version returns the version the revision introduces.
+func (r *Revision) Version() *Version {
+	return r.ver
+}
+
+// applyAll applies the changes that are common to every data type.
+// Upgrading applies them before the type specific changes,
+// downgrading undoes them after the type specific changes.
+func (r *Revision) applyAll(attrs pcommon.Map, forward bool) {
+	for _, i := range order(len(r.all), forward) {
+		r.all[i].direction(forward).applyAttributes(attrs)
+	}
+}
+
+func (r *Revision) applyResource(res pcommon.Resource, forward bool) {
+	if forward {
+		r.applyAll(res.Attributes(), forward)
+	}
+	for _, i := range order(len(r.resources), forward) {
+		r.resources[i].direction(forward).applyAttributes(res.Attributes())
+	}
+	if !forward {
+		r.applyAll(res.Attributes(), forward)
+	}
+}
+
+func (r *Revision) applySpans(spans ptrace.SpanSlice, forward bool) {
+	for i := 0; i < spans.Len(); i++ {
+		span := spans.At(i)
+		if forward {
+			r.applyAll(span.Attributes(), forward)
+			r.applyAllEvents(span.Events(), forward)
+		}
+		for _, c := range order(len(r.spans), forward) {
+			change := r.spans[c]
+			if change.applyToSpans.matches(span.Name()) {
+				change.attributes.direction(forward).applyAttributes(span.Attributes())
+			}
+		}
+		for _, c := range order(len(r.spanEvents), forward) {
+			r.spanEvents[c].apply(span, forward)
+		}
+		if !forward {
+			r.applyAll(span.Attributes(), forward)
+			r.applyAllEvents(span.Events(), forward)
+		}
+	}
+}
+
+func (r *Revision) applyAllEvents(events ptrace.SpanEventSlice, forward bool) {
+	for i := 0; i < events.Len(); i++ {
+		r.applyAll(events.At(i).Attributes(), forward)
+	}
+}
+
+func (c spanEventChange) apply(span ptrace.Span, forward bool) {
+	if !c.applyToSpans.matches(span.Name()) {
+		return
+	}
+	names, attributes := c.names.direction(forward), c.attributes.direction(forward)
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		if to, ok := names.rename(event.Name()); ok {
+			event.SetName(to)
+		}
+		if len(attributes) > 0 && c.applyToEvents.matches(event.Name()) {
+			attributes.applyAttributes(event.Attributes())
+		}
+	}
+}
+
+func (r *Revision) applyMetrics(metrics pmetric.MetricSlice, forward bool) {
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if forward {
+			r.applyAllDataPoints(metric, forward)
+		}
+		for _, c := range order(len(r.metrics), forward) {
+			r.metrics[c].apply(metric, forward)
+		}
+		if !forward {
+			r.applyAllDataPoints(metric, forward)
+		}
+	}
+}
+
+func (r *Revision) applyAllDataPoints(metric pmetric.Metric, forward bool) {
+	if len(r.all) == 0 {
+		return
+	}
+	rangeDataPointAttributes(metric, func(attrs pcommon.Map) {
+		r.applyAll(attrs, forward)
+	})
+}
+
+func (c metricChange) apply(metric pmetric.Metric, forward bool) {
+	if to, ok := c.names.direction(forward).rename(metric.Name()); ok {
+		metric.SetName(to)
+	}
+	if len(c.attributes) == 0 || !c.applyToMetrics.matches(metric.Name()) {
+		return
+	}
+	attributes := c.attributes.direction(forward)
+	rangeDataPointAttributes(metric, attributes.applyAttributes)
+}
+
+func (r *Revision) applyLogs(logs plog.LogRecordSlice, forward bool) {
+	for i := 0; i < logs.Len(); i++ {
+		attrs := logs.At(i).Attributes()
+		if forward {
+			r.applyAll(attrs, forward)
+		}
+		for _, c := range order(len(r.logs), forward) {
+			r.logs[c].direction(forward).applyAttributes(attrs)
+		}
+		if !forward {
+			r.applyAll(attrs, forward)
+		}
+	}
+}
+
+// rangeDataPointAttributes calls fn with the attributes of every data point of the metric.
+func rangeDataPointAttributes(metric pmetric.Metric, fn func(attrs pcommon.Map)) {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			fn(metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			fn(metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			fn(metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			fn(metric.Summary().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeNone:
+	}
+}
+
+// order returns the indexes of n changes in the order they must be applied.
+func order(n int, forward bool) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		if forward {
+			idx[i] = i
+		} else {
+			idx[i] = n - 1 - i
+		}
+	}
+	return idx
+}
@@ -0,0 +1,165 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	schema "go.opentelemetry.io/otel/schema/v1.0"
+)
+
+// ErrUnsupportedVersion is returned when a version is not defined by a schema file.
+var ErrUnsupportedVersion = errors.New("unsupported schema version")
+
+// Translation holds the revisions of a schema family as defined by a single
+// schema file, see https://opentelemetry.io/docs/reference/specification/schemas/file_format_v1.0.0/
+// It is able to convert signals between any two versions that the file defines.
+type Translation struct {
+	schemaURL string
+	family    string
+	latest    *Version
+
+	// revisions are sorted by ascending version.
+	revisions []*Revision
+}
+
+// NewTranslation parses the schema file content into a Translation.
+func NewTranslation(content io.Reader) (*Translation, error) {
+	sch, err := schema.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	family, latest, err := GetFamilyAndVersion(sch.SchemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Translation{
+		schemaURL: sch.SchemaURL,
+		family:    family,
+		latest:    latest,
+		revisions: make([]*Revision, 0, len(sch.Versions)),
+	}
+	for raw, def := range sch.Versions {
+		ver, err := NewVersion(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("schema version %q: %w", raw, err)
+		}
+		if ver.GreaterThan(latest) {
+			return nil, fmt.Errorf("schema version %s is greater than the schema url version %s: %w", ver, latest, ErrInvalidVersion)
+		}
+		t.revisions = append(t.revisions, NewRevision(ver, def))
+	}
+	sort.Slice(t.revisions, func(i, j int) bool {
+		return t.revisions[i].ver.LessThan(t.revisions[j].ver)
+	})
+	return t, nil
+}
+
+// SchemaURL returns the schema url the translation was published at.
+func (t *Translation) SchemaURL() string {
+	return t.schemaURL
+}
+
+// Family returns the schema family of the translation.
+func (t *Translation) Family() string {
+	return t.family
+}
+
+// SupportedVersion checks that the version is defined by the schema file.
+func (t *Translation) SupportedVersion(v *Version) bool {
+	return t.index(v) != -1
+}
+
+func (t *Translation) index(v *Version) int {
+	i := sort.Search(len(t.revisions), func(i int) bool {
+		return !t.revisions[i].ver.LessThan(v)
+	})
+	if i < len(t.revisions) && t.revisions[i].ver.Equal(v) {
+		return i
+	}
+	return -1
+}
+
+// step is a single revision to apply along with the direction to apply it in.
+type step struct {
+	rev     *Revision
+	forward bool
+}
+
+// steps returns the revisions to apply in order to convert from one version to another.
+// Upgrading applies every revision after from up to and including to, downgrading
+// undoes every revision down to, but not including, to in reverse order.
+func (t *Translation) steps(from, to *Version) ([]step, error) {
+	fi, ti := t.index(from), t.index(to)
+	if fi == -1 {
+		return nil, fmt.Errorf("%s: %w", from, ErrUnsupportedVersion)
+	}
+	if ti == -1 {
+		return nil, fmt.Errorf("%s: %w", to, ErrUnsupportedVersion)
+	}
+
+	var steps []step
+	for i := fi + 1; i <= ti; i++ {
+		steps = append(steps, step{rev: t.revisions[i], forward: true})
+	}
+	for i := fi; i > ti; i-- {
+		steps = append(steps, step{rev: t.revisions[i], forward: false})
+	}
+	return steps, nil
+}
+
+// ApplyResourceChanges converts the resource attributes from one version to another.
+func (t *Translation) ApplyResourceChanges(res pcommon.Resource, from, to *Version) error {
+	steps, err := t.steps(from, to)
+	for _, s := range steps {
+		s.rev.applyResource(res, s.forward)
+	}
+	return err
+}
+
+// ApplySpanChanges converts the spans and their events from one version to another.
+func (t *Translation) ApplySpanChanges(spans ptrace.SpanSlice, from, to *Version) error {
+	steps, err := t.steps(from, to)
+	for _, s := range steps {
+		s.rev.applySpans(spans, s.forward)
+	}
+	return err
+}
+
+// ApplyMetricChanges converts the metrics and their data points from one version to another.
+func (t *Translation) ApplyMetricChanges(metrics pmetric.MetricSlice, from, to *Version) error {
+	steps, err := t.steps(from, to)
+	for _, s := range steps {
+		s.rev.applyMetrics(metrics, s.forward)
+	}
+	return err
+}
+
+// ApplyLogChanges converts the log records from one version to another.
+func (t *Translation) ApplyLogChanges(logs plog.LogRecordSlice, from, to *Version) error {
+	steps, err := t.steps(from, to)
+	for _, s := range steps {
+		s.rev.applyLogs(logs, s.forward)
+	}
+	return err
+}
@@ -0,0 +1,193 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestTranslation(t *testing.T) *Translation {
+	f, err := os.Open(filepath.Join("testdata", "schema.yml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	tr, err := NewTranslation(f)
+	require.NoError(t, err, "Must not error when parsing the schema file")
+	return tr
+}
+
+func mustVersion(t *testing.T, s string) *Version {
+	v, err := NewVersion(s)
+	require.NoError(t, err)
+	return v
+}
+
+func TestNewTranslation(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	assert.Equal(t, "https://example.com/schemas/1.2.0", tr.SchemaURL())
+	assert.Equal(t, "https://example.com/schemas", tr.Family())
+	for _, v := range []string{"1.0.0", "1.1.0", "1.2.0"} {
+		assert.True(t, tr.SupportedVersion(mustVersion(t, v)), "Must support version %s", v)
+	}
+	assert.False(t, tr.SupportedVersion(mustVersion(t, "1.0.1")))
+	assert.False(t, tr.SupportedVersion(mustVersion(t, "1.3.0")))
+}
+
+func TestSteps(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	tests := []struct {
+		scenario string
+		from, to string
+		expect   []string
+		forward  bool
+	}{
+		{scenario: "no changes", from: "1.1.0", to: "1.1.0", expect: nil},
+		{scenario: "upgrade", from: "1.0.0", to: "1.2.0", expect: []string{"1.1.0", "1.2.0"}, forward: true},
+		{scenario: "downgrade", from: "1.2.0", to: "1.0.0", expect: []string{"1.2.0", "1.1.0"}, forward: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.scenario, func(t *testing.T) {
+			steps, err := tr.steps(mustVersion(t, tc.from), mustVersion(t, tc.to))
+			require.NoError(t, err)
+			var versions []string
+			for _, s := range steps {
+				versions = append(versions, s.rev.Version().String())
+				assert.Equal(t, tc.forward, s.forward)
+			}
+			assert.Equal(t, tc.expect, versions)
+		})
+	}
+
+	_, err := tr.steps(mustVersion(t, "0.9.0"), mustVersion(t, "1.2.0"))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestResourceChanges(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	res := pcommon.NewResource()
+	res.Attributes().PutString("k8s.pod", "checkout-1")
+	res.Attributes().PutString("net.peer.ip", "10.0.0.1")
+
+	require.NoError(t, tr.ApplyResourceChanges(res, mustVersion(t, "1.0.0"), mustVersion(t, "1.2.0")))
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod.name":       "checkout-1",
+		"net.sock.peer.addr": "10.0.0.1",
+	}, res.Attributes().AsRaw())
+
+	require.NoError(t, tr.ApplyResourceChanges(res, mustVersion(t, "1.2.0"), mustVersion(t, "1.0.0")))
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod":     "checkout-1",
+		"net.peer.ip": "10.0.0.1",
+	}, res.Attributes().AsRaw())
+}
+
+func TestRenameDoesNotOverwrite(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	res := pcommon.NewResource()
+	res.Attributes().PutString("k8s.pod", "old")
+	res.Attributes().PutString("k8s.pod.name", "new")
+
+	require.NoError(t, tr.ApplyResourceChanges(res, mustVersion(t, "1.0.0"), mustVersion(t, "1.1.0")))
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod":      "old",
+		"k8s.pod.name": "new",
+	}, res.Attributes().AsRaw())
+}
+
+func TestSpanChanges(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	spans := ptrace.NewSpanSlice()
+	query := spans.AppendEmpty()
+	query.SetName("query")
+	query.Attributes().PutString("db.type", "redis")
+	query.Attributes().PutString("net.peer.ip", "10.0.0.1")
+	event := query.Events().AppendEmpty()
+	event.SetName("stacktrace")
+	event.Attributes().PutString("trace", "main.go:10")
+	other := spans.AppendEmpty()
+	other.SetName("other")
+	other.Attributes().PutString("db.type", "redis")
+
+	require.NoError(t, tr.ApplySpanChanges(spans, mustVersion(t, "1.1.0"), mustVersion(t, "1.2.0")))
+	assert.Equal(t, map[string]interface{}{
+		"db.system":          "redis",
+		"net.sock.peer.addr": "10.0.0.1",
+	}, query.Attributes().AsRaw())
+	assert.Equal(t, "stack_trace", event.Name())
+	assert.Equal(t, map[string]interface{}{"stack": "main.go:10"}, event.Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"db.type": "redis"}, other.Attributes().AsRaw(), "Must only apply to matching spans")
+
+	require.NoError(t, tr.ApplySpanChanges(spans, mustVersion(t, "1.2.0"), mustVersion(t, "1.1.0")))
+	assert.Equal(t, map[string]interface{}{
+		"db.type":     "redis",
+		"net.peer.ip": "10.0.0.1",
+	}, query.Attributes().AsRaw())
+	assert.Equal(t, "stacktrace", event.Name())
+	assert.Equal(t, map[string]interface{}{"trace": "main.go:10"}, event.Attributes().AsRaw())
+}
+
+func TestMetricChanges(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	metrics := pmetric.NewMetricSlice()
+	m := metrics.AppendEmpty()
+	m.SetName("cpu.total")
+	dp := m.SetEmptySum().DataPoints().AppendEmpty()
+	dp.Attributes().PutString("status", "idle")
+
+	require.NoError(t, tr.ApplyMetricChanges(metrics, mustVersion(t, "1.0.0"), mustVersion(t, "1.1.0")))
+	assert.Equal(t, "container.cpu.usage.total", m.Name())
+	assert.Equal(t, map[string]interface{}{"status": "idle"}, dp.Attributes().AsRaw())
+
+	require.NoError(t, tr.ApplyMetricChanges(metrics, mustVersion(t, "1.1.0"), mustVersion(t, "1.2.0")))
+	assert.Equal(t, "cpu.usage.total", m.Name())
+	assert.Equal(t, map[string]interface{}{"state": "idle"}, dp.Attributes().AsRaw())
+
+	require.NoError(t, tr.ApplyMetricChanges(metrics, mustVersion(t, "1.2.0"), mustVersion(t, "1.0.0")))
+	assert.Equal(t, "cpu.total", m.Name())
+	assert.Equal(t, map[string]interface{}{"status": "idle"}, dp.Attributes().AsRaw())
+}
+
+func TestLogChanges(t *testing.T) {
+	t.Parallel()
+
+	tr := newTestTranslation(t)
+	logs := plog.NewLogRecordSlice()
+	lr := logs.AppendEmpty()
+	lr.Attributes().PutString("process.executable_name", "otelcol")
+
+	require.NoError(t, tr.ApplyLogChanges(logs, mustVersion(t, "1.0.0"), mustVersion(t, "1.2.0")))
+	assert.Equal(t, map[string]interface{}{"process.executable.name": "otelcol"}, lr.Attributes().AsRaw())
+}
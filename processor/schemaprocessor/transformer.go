@@ -20,15 +20,30 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
+// target is the schema version signals of a schema family are translated to.
+type target struct {
+	schemaURL string
+	version   *translation.Version
+}
+
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	targets     map[string]target
+	prefetch    []string
+	schemaFiles map[string]string
+	httpClient  confighttp.HTTPClientSettings
+	telemetry   component.TelemetrySettings
+	log         *zap.Logger
+
+	manager *translation.Manager
 }
 
 func newTransformer(
@@ -40,29 +55,196 @@ func newTransformer(
 	if !ok {
 		return nil, errors.New("invalid configuration provided")
 	}
+	targets := make(map[string]target, len(cfg.Targets))
+	for _, schemaURL := range cfg.Targets {
+		family, version, err := translation.GetFamilyAndVersion(schemaURL)
+		if err != nil {
+			return nil, err
+		}
+		targets[family] = target{schemaURL: schemaURL, version: version}
+	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		log:         set.Logger,
+		telemetry:   set.TelemetrySettings,
+		targets:     targets,
+		prefetch:    cfg.Prefetch,
+		schemaFiles: cfg.SchemaFiles,
+		httpClient:  cfg.HTTPClientSettings,
 	}, nil
 }
 
-func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+func (t *transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for rl := 0; rl < ld.ResourceLogs().Len(); rl++ {
+		rLog := ld.ResourceLogs().At(rl)
+		resourceSchemaURL := rLog.SchemaUrl()
+		if conv, ok := t.conversion(ctx, resourceSchemaURL); ok {
+			t.logConversionError(conv, conv.translation.ApplyResourceChanges(rLog.Resource(), conv.from, conv.to))
+			rLog.SetSchemaUrl(conv.target)
+		}
+		for sl := 0; sl < rLog.ScopeLogs().Len(); sl++ {
+			logs := rLog.ScopeLogs().At(sl)
+			conv, ok := t.conversion(ctx, scopeSchemaURL(logs.SchemaUrl(), resourceSchemaURL))
+			if !ok {
+				continue
+			}
+			t.logConversionError(conv, conv.translation.ApplyLogChanges(logs.LogRecords(), conv.from, conv.to))
+			if logs.SchemaUrl() != "" {
+				logs.SetSchemaUrl(conv.target)
+			}
+		}
+	}
 	return ld, nil
 }
 
-func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (t *transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for rm := 0; rm < md.ResourceMetrics().Len(); rm++ {
+		rMetric := md.ResourceMetrics().At(rm)
+		resourceSchemaURL := rMetric.SchemaUrl()
+		if conv, ok := t.conversion(ctx, resourceSchemaURL); ok {
+			t.logConversionError(conv, conv.translation.ApplyResourceChanges(rMetric.Resource(), conv.from, conv.to))
+			rMetric.SetSchemaUrl(conv.target)
+		}
+		for sm := 0; sm < rMetric.ScopeMetrics().Len(); sm++ {
+			metrics := rMetric.ScopeMetrics().At(sm)
+			conv, ok := t.conversion(ctx, scopeSchemaURL(metrics.SchemaUrl(), resourceSchemaURL))
+			if !ok {
+				continue
+			}
+			t.logConversionError(conv, conv.translation.ApplyMetricChanges(metrics.Metrics(), conv.from, conv.to))
+			if metrics.SchemaUrl() != "" {
+				metrics.SetSchemaUrl(conv.target)
+			}
+		}
+	}
 	return md, nil
 }
 
-func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (t *transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for rt := 0; rt < td.ResourceSpans().Len(); rt++ {
+		rTrace := td.ResourceSpans().At(rt)
+		resourceSchemaURL := rTrace.SchemaUrl()
+		if conv, ok := t.conversion(ctx, resourceSchemaURL); ok {
+			t.logConversionError(conv, conv.translation.ApplyResourceChanges(rTrace.Resource(), conv.from, conv.to))
+			rTrace.SetSchemaUrl(conv.target)
+		}
+		for ss := 0; ss < rTrace.ScopeSpans().Len(); ss++ {
+			spans := rTrace.ScopeSpans().At(ss)
+			conv, ok := t.conversion(ctx, scopeSchemaURL(spans.SchemaUrl(), resourceSchemaURL))
+			if !ok {
+				continue
+			}
+			t.logConversionError(conv, conv.translation.ApplySpanChanges(spans.Spans(), conv.from, conv.to))
+			if spans.SchemaUrl() != "" {
+				spans.SetSchemaUrl(conv.target)
+			}
+		}
+	}
 	return td, nil
 }
 
+// scopeSchemaURL returns the schema url that applies to the data of a scope,
+// falling back to the resource schema url when the scope does not define one.
+func scopeSchemaURL(scopeURL, resourceURL string) string {
+	if scopeURL != "" {
+		return scopeURL
+	}
+	return resourceURL
+}
+
+// conversion describes how to translate signals published
+// with one schema version to the target version of its family.
+type conversion struct {
+	translation *translation.Translation
+	from, to    *translation.Version
+	source      string
+	target      string
+}
+
+// conversion resolves the translation required for signals published with
+// schemaURL, it returns false when no translation needs to, or can, be applied.
+func (t *transformer) conversion(ctx context.Context, schemaURL string) (conversion, bool) {
+	if schemaURL == "" {
+		return conversion{}, false
+	}
+	family, version, err := translation.GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		t.log.Debug("Ignoring invalid schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		return conversion{}, false
+	}
+	tgt, ok := t.targets[family]
+	if !ok || tgt.version.Equal(version) {
+		return conversion{}, false
+	}
+
+	// A schema file defines every version of the family up to its own version,
+	// so upgrades are described by the target schema file and
+	// downgrades by the schema file of the incoming signal.
+	lookup := tgt.schemaURL
+	if version.GreaterThan(tgt.version) {
+		lookup = schemaURL
+	}
+	tr, err := t.manager.RequestTranslation(ctx, lookup)
+	if errors.Is(err, translation.ErrRetryBackoff) {
+		t.log.Debug("Schema translation recently failed to load, passing signals through unchanged",
+			zap.String("schema-url", lookup),
+			zap.Error(err),
+		)
+		return conversion{}, false
+	}
+	if err != nil {
+		t.log.Error("Unable to load schema translation, passing signals through unchanged",
+			zap.String("schema-url", lookup),
+			zap.Error(err),
+		)
+		return conversion{}, false
+	}
+	if !tr.SupportedVersion(version) {
+		t.log.Warn("Schema version is not defined by its schema family, passing signals through unchanged",
+			zap.String("schema-url", schemaURL),
+		)
+		return conversion{}, false
+	}
+	return conversion{
+		translation: tr,
+		from:        version,
+		to:          tgt.version,
+		source:      schemaURL,
+		target:      tgt.schemaURL,
+	}, true
+}
+
+func (t *transformer) logConversionError(conv conversion, err error) {
+	if err != nil {
+		t.log.Error("Failed to translate signals",
+			zap.String("schema-url", conv.source),
+			zap.String("target", conv.target),
+			zap.Error(err),
+		)
+	}
+}
+
 // start will load the remote file definition if it isn't already cached
 // and resolve the schema translation file
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	client, err := t.httpClient.ToClient(host, t.telemetry)
+	if err != nil {
+		return err
+	}
+	t.manager = translation.NewManager(
+		t.log,
+		translation.NewFileProvider(t.schemaFiles),
+		translation.NewHTTPProvider(client),
+	)
+
+	urls := append([]string{}, t.prefetch...)
+	for _, tgt := range t.targets {
+		urls = append(urls, tgt.schemaURL)
+	}
+	for _, schemaURL := range urls {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		if _, err := t.manager.RequestTranslation(ctx, schemaURL); err != nil {
+			t.log.Warn("Unable to prefetch schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		}
 	}
 	return nil
 }
@@ -18,11 +18,13 @@ import (
 	"context"
 	_ "embed"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -108,3 +110,86 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func TestTransformerTranslation(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(SchemaHandler(t)))
+	t.Cleanup(srv.Close)
+
+	var (
+		source = srv.URL + "/schemas/1.0.0"
+		target = srv.URL + "/schemas/1.1.0"
+	)
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{target}
+	trans, err := newTransformer(context.Background(), cfg, component.ProcessorCreateSettings{
+		TelemetrySettings: componenttest.NewNopTelemetrySettings(),
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+
+	t.Run("metrics", func(t *testing.T) {
+		in := pmetric.NewMetrics()
+		rMetric := in.ResourceMetrics().AppendEmpty()
+		rMetric.SetSchemaUrl(source)
+		rMetric.Resource().Attributes().PutString("k8s.pod.name", "checkout-1")
+		m := rMetric.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("container.cpu.usage.total")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutString("k8s.node.name", "node-1")
+		cpu := rMetric.ScopeMetrics().At(0).Metrics().AppendEmpty()
+		cpu.SetName("system.cpu.utilization")
+		cpu.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutString("status", "idle")
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing metrics")
+
+		rMetric = out.ResourceMetrics().At(0)
+		assert.Equal(t, target, rMetric.SchemaUrl())
+		assert.Equal(t, map[string]interface{}{"kubernetes.pod.name": "checkout-1"}, rMetric.Resource().Attributes().AsRaw())
+		metrics := rMetric.ScopeMetrics().At(0).Metrics()
+		assert.Equal(t, "cpu.usage.total", metrics.At(0).Name())
+		assert.Equal(t, map[string]interface{}{"kubernetes.node.name": "node-1"}, metrics.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+		assert.Equal(t, map[string]interface{}{"state": "idle"}, metrics.At(1).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		in := ptrace.NewTraces()
+		rTrace := in.ResourceSpans().AppendEmpty()
+		rTrace.SetSchemaUrl(source)
+		rTrace.Resource().Attributes().PutString("telemetry.auto.version", "1.0")
+		spans := rTrace.ScopeSpans().AppendEmpty()
+		s := spans.Spans().AppendEmpty()
+		s.SetName("HTTP GET")
+		s.Attributes().PutString("peer.service", "cart")
+		e := s.Events().AppendEmpty()
+		e.SetName("stacktrace")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing traces")
+
+		rTrace = out.ResourceSpans().At(0)
+		assert.Equal(t, target, rTrace.SchemaUrl())
+		assert.Equal(t, map[string]interface{}{"telemetry.auto_instr.version": "1.0"}, rTrace.Resource().Attributes().AsRaw())
+		s = rTrace.ScopeSpans().At(0).Spans().At(0)
+		assert.Equal(t, map[string]interface{}{"peer.service.name": "cart"}, s.Attributes().AsRaw())
+		assert.Equal(t, "stack_trace", s.Events().At(0).Name())
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		in := plog.NewLogs()
+		rLog := in.ResourceLogs().AppendEmpty()
+		rLog.SetSchemaUrl(srv.URL + "/schemas/0.9.0")
+		scopeLogs := rLog.ScopeLogs().AppendEmpty()
+		scopeLogs.SetSchemaUrl(source)
+		scopeLogs.LogRecords().AppendEmpty().Attributes().PutString("process.executable_name", "otelcol")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing logs")
+
+		rLog = out.ResourceLogs().At(0)
+		assert.Equal(t, srv.URL+"/schemas/0.9.0", rLog.SchemaUrl(), "Must not change unknown versions")
+		assert.Equal(t, target, rLog.ScopeLogs().At(0).SchemaUrl())
+		assert.Equal(t, map[string]interface{}{"process.executable.name": "otelcol"}, rLog.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+	})
+}
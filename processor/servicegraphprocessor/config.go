@@ -40,6 +40,12 @@ type Config struct {
 	// https://github.com/open-telemetry/opentelemetry-collector/blob/main/model/semconv/opentelemetry.go.
 	Dimensions []string `mapstructure:"dimensions"`
 
+	// VirtualNodePeerAttributes is the list of client span attributes, in order of precedence, naming the peer
+	// called by the client. Requests whose server span never arrives, e.g. to databases, caches or third-party
+	// APIs, are recorded with that peer as a virtual server node when they expire.
+	// See defaultPeerAttributes in processor.go for the default value.
+	VirtualNodePeerAttributes []string `mapstructure:"virtual_node_peer_attributes"`
+
 	// Store contains the config for the in-memory store used to find requests between services by pairing spans.
 	Store StoreConfig `mapstructure:"store"`
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	semconv "go.opentelemetry.io/collector/semconv/v1.9.0"
 	"go.opentelemetry.io/collector/service/servicetest"
 )
 
@@ -45,6 +46,10 @@ func TestLoadConfig(t *testing.T) {
 			MetricsExporter:         "metrics",
 			LatencyHistogramBuckets: []time.Duration{1, 2, 3, 4, 5},
 			Dimensions:              []string{"dimension-1", "dimension-2"},
+			VirtualNodePeerAttributes: []string{
+				semconv.AttributePeerService,
+				semconv.AttributeDBName,
+			},
 			Store: StoreConfig{
 				TTL:      time.Second,
 				MaxItems: 10,
@@ -26,6 +26,7 @@ const (
 	Unknown         ConnectionType = ""
 	MessagingSystem ConnectionType = "messaging_system"
 	Database        ConnectionType = "database"
+	VirtualNode     ConnectionType = "virtual_node"
 )
 
 // Edge is an Edge between two nodes in the graph
@@ -41,6 +42,10 @@ type Edge struct {
 	// the Edge will be considered as failed.
 	Failed bool
 
+	// PeerService is the name of the uninstrumented peer called by the client, used as a virtual
+	// server node if the server span never arrives.
+	PeerService string
+
 	// Additional dimension to add to the metrics
 	Dimensions map[string]string
 
@@ -62,6 +67,11 @@ func (e *Edge) isComplete() bool {
 	return len(e.ClientService) != 0 && len(e.ServerService) != 0
 }
 
+// IsVirtual returns whether the edge misses its server span but knows the peer called by the client.
+func (e *Edge) IsVirtual() bool {
+	return len(e.ClientService) != 0 && len(e.ServerService) == 0 && len(e.PeerService) != 0
+}
+
 func (e *Edge) isExpired() bool {
 	return time.Now().After(e.expiration)
 }
@@ -44,6 +44,9 @@ var (
 	defaultLatencyHistogramBucketsMs = []float64{
 		2, 4, 6, 8, 10, 50, 100, 200, 400, 800, 1000, 1400, 2000, 5000, 10_000, 15_000,
 	}
+	defaultPeerAttributes = []string{
+		semconv.AttributePeerService, semconv.AttributeDBName, semconv.AttributeDBSystem, semconv.AttributeNetPeerName,
+	}
 )
 
 type metricSeries struct {
@@ -71,6 +74,8 @@ type processor struct {
 	reqDurationBounds              []float64
 	reqDurationSecondsBucketCounts map[string][]uint64
 
+	peerAttributes []string
+
 	keyToMetric map[string]metricSeries
 
 	shutdownCh chan interface{}
@@ -84,6 +89,11 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		bounds = mapDurationsToMillis(pConfig.LatencyHistogramBuckets)
 	}
 
+	peerAttributes := defaultPeerAttributes
+	if pConfig.VirtualNodePeerAttributes != nil {
+		peerAttributes = pConfig.VirtualNodePeerAttributes
+	}
+
 	p := &processor{
 		config:                         pConfig,
 		logger:                         logger,
@@ -95,6 +105,7 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		reqDurationSecondsCount:        make(map[string]uint64),
 		reqDurationBounds:              bounds,
 		reqDurationSecondsBucketCounts: make(map[string][]uint64),
+		peerAttributes:                 peerAttributes,
 		keyToMetric:                    make(map[string]metricSeries),
 		shutdownCh:                     make(chan interface{}),
 	}
@@ -205,6 +216,7 @@ func (p *processor) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err
 						e.ClientLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(e.Dimensions, rAttributes, span.Attributes())
+						e.PeerService = p.findPeerService(span.Attributes())
 
 						// A database request will only have one span, we don't wait for the server
 						// span but just copy details from the client span
@@ -219,8 +231,13 @@ func (p *processor) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err
 					connectionType = store.MessagingSystem
 					fallthrough
 				case ptrace.SpanKindServer:
-					traceID := span.TraceID()
-					key := buildEdgeKey(traceID.HexString(), span.ParentSpanID().HexString())
+					traceID, parentSpanID := span.TraceID(), span.ParentSpanID()
+					// Consumers processing messages in batches often link to the producer spans instead of
+					// being their children.
+					if connectionType == store.MessagingSystem && parentSpanID.IsEmpty() && span.Links().Len() > 0 {
+						traceID, parentSpanID = span.Links().At(0).TraceID(), span.Links().At(0).SpanID()
+					}
+					key := buildEdgeKey(traceID.HexString(), parentSpanID.HexString())
 					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
@@ -262,6 +279,16 @@ func (p *processor) upsertDimensions(m map[string]string, resourceAttr pcommon.M
 	}
 }
 
+// findPeerService returns the value of the first configured peer attribute found in the client span attributes.
+func (p *processor) findPeerService(spanAttr pcommon.Map) string {
+	for _, attr := range p.peerAttributes {
+		if v, ok := findAttributeValue(attr, spanAttr); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 func (p *processor) onComplete(e *store.Edge) {
 	p.logger.Debug(
 		"edge completed",
@@ -274,6 +301,24 @@ func (p *processor) onComplete(e *store.Edge) {
 }
 
 func (p *processor) onExpire(e *store.Edge) {
+	if e.IsVirtual() {
+		// The server span will never arrive, so the request is recorded against the peer called by the client.
+		e.ServerService = e.PeerService
+		e.ServerLatencySec = e.ClientLatencySec
+		if e.ConnectionType == store.Unknown {
+			e.ConnectionType = store.VirtualNode
+		}
+		p.logger.Debug(
+			"virtual edge completed",
+			zap.String("client_service", e.ClientService),
+			zap.String("server_service", e.ServerService),
+			zap.String("connection_type", string(e.ConnectionType)),
+			zap.String("trace_id", e.TraceID.HexString()),
+		)
+		p.aggregateMetricsForEdge(e)
+		return
+	}
+
 	p.logger.Debug(
 		"edge expired",
 		zap.String("client_service", e.ClientService),
@@ -33,6 +33,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	semconv "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor/internal/store"
 )
 
 func TestProcessorStart(t *testing.T) {
@@ -133,6 +135,105 @@ func TestProcessorConsume(t *testing.T) {
 	assert.NoError(t, processor.Shutdown(context.Background()))
 }
 
+func TestProcessorVirtualNodes(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		peerAttributes []string
+		spanAttributes map[string]interface{}
+		wantServer     string
+	}{
+		{
+			name:           "peer service",
+			spanAttributes: map[string]interface{}{semconv.AttributePeerService: "payments-api", semconv.AttributeNetPeerName: "payments.example.com"},
+			wantServer:     "payments-api",
+		},
+		{
+			name:           "database system",
+			spanAttributes: map[string]interface{}{semconv.AttributeDBSystem: "redis"},
+			wantServer:     "redis",
+		},
+		{
+			name:           "network peer",
+			spanAttributes: map[string]interface{}{semconv.AttributeNetPeerName: "payments.example.com"},
+			wantServer:     "payments.example.com",
+		},
+		{
+			name:           "configured attributes",
+			peerAttributes: []string{"rpc.service"},
+			spanAttributes: map[string]interface{}{semconv.AttributePeerService: "payments-api", "rpc.service": "Payments"},
+			wantServer:     "Payments",
+		},
+		{
+			name:           "no peer",
+			spanAttributes: map[string]interface{}{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{VirtualNodePeerAttributes: tc.peerAttributes}
+			p := newProcessor(zaptest.NewLogger(t), cfg, consumertest.NewNop())
+			// Edges expire immediately, as if the server span never arrived.
+			p.store = store.NewStore(-time.Second, 10, p.onComplete, p.onExpire)
+
+			td := sampleTraces()
+			spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+			spans.RemoveIf(func(span ptrace.Span) bool { return span.Kind() == ptrace.SpanKindServer })
+			spans.At(0).Attributes().FromRaw(tc.spanAttributes)
+
+			require.NoError(t, p.aggregateMetrics(context.Background(), td))
+			p.store.Expire()
+
+			md, err := p.buildMetrics()
+			require.NoError(t, err)
+			if tc.wantServer == "" {
+				assert.Equal(t, 0, md.MetricCount())
+				return
+			}
+
+			require.Equal(t, 2, md.MetricCount())
+			ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			for i := 0; i < ms.Len(); i++ {
+				m := ms.At(i)
+				var attributes pcommon.Map
+				switch m.Name() {
+				case "request_total":
+					attributes = m.Sum().DataPoints().At(0).Attributes()
+				case "request_duration_seconds":
+					dp := m.Histogram().DataPoints().At(0)
+					assert.Equal(t, float64(1000), dp.Sum()) // Client latency: 1sec
+					attributes = dp.Attributes()
+				}
+				verifyAttr(t, attributes, "client", "some-service")
+				verifyAttr(t, attributes, "server", tc.wantServer)
+				verifyAttr(t, attributes, "connection_type", string(store.VirtualNode))
+			}
+		})
+	}
+}
+
+func TestProcessorMessagingLinks(t *testing.T) {
+	p := newProcessor(zaptest.NewLogger(t), &Config{}, consumertest.NewNop())
+	p.store = store.NewStore(time.Hour, 10, p.onComplete, p.onExpire)
+
+	td := sampleTraces()
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	producer, consumer := spans.At(0), spans.At(1)
+	producer.SetKind(ptrace.SpanKindProducer)
+	consumer.SetKind(ptrace.SpanKindConsumer)
+	consumer.SetParentSpanID(pcommon.EmptySpanID)
+	consumer.SetTraceID(pcommon.TraceID([16]byte{0xFF}))
+	link := consumer.Links().AppendEmpty()
+	link.SetTraceID(producer.TraceID())
+	link.SetSpanID(producer.SpanID())
+
+	require.NoError(t, p.aggregateMetrics(context.Background(), td))
+
+	md, err := p.buildMetrics()
+	require.NoError(t, err)
+	require.Equal(t, 2, md.MetricCount())
+	attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes()
+	verifyAttr(t, attributes, "connection_type", string(store.MessagingSystem))
+}
+
 func verifyMetrics(t *testing.T, md pmetric.Metrics) error {
 	assert.Equal(t, 2, md.MetricCount())
 
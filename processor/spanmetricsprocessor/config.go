@@ -33,6 +33,23 @@ type Dimension struct {
 	Default *string `mapstructure:"default"`
 }
 
+// ExponentialHistogramConfig defines the configuration of the exponential latency histograms.
+type ExponentialHistogramConfig struct {
+	// MaxSize is the maximum number of buckets of each histogram, its scale being lowered as needed to fit the latencies.
+	// It must be at least 2.
+	// Optional. See defaultExponentialHistogramMaxSize in processor.go for the default value.
+	MaxSize int32 `mapstructure:"max_size"`
+}
+
+// EventsConfig defines the configuration of the span events counter.
+type EventsConfig struct {
+	// Enabled counts the span events by name, with the same dimensions as the span they belong to.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Names restricts the counted events to the given names, e.g. "exception". All events are counted when empty.
+	Names []string `mapstructure:"names"`
+}
+
 // Config defines the configuration options for spanmetricsprocessor.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -44,6 +61,10 @@ type Config struct {
 	// See defaultLatencyHistogramBucketsMs in processor.go for the default value.
 	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
 
+	// ExponentialHistogram produces the latency metrics as exponential histograms instead of explicit bucket
+	// histograms when set, removing the need to tune the buckets. It can't be used with LatencyHistogramBuckets.
+	ExponentialHistogram *ExponentialHistogramConfig `mapstructure:"exponential_histogram"`
+
 	// Dimensions defines the list of additional dimensions on top of the provided:
 	// - service.name
 	// - operation
@@ -60,6 +81,9 @@ type Config struct {
 
 	AggregationTemporality string `mapstructure:"aggregation_temporality"`
 
+	// Events configures the optional counter of span events.
+	Events EventsConfig `mapstructure:"events"`
+
 	// skipSanitizeLabel if enabled, labels that start with _ are not sanitized
 	skipSanitizeLabel bool
 }
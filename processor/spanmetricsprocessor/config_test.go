@@ -43,6 +43,8 @@ func TestLoadConfig(t *testing.T) {
 		wantDimensions              []Dimension
 		wantDimensionsCacheSize     int
 		wantAggregationTemporality  string
+		wantExponentialHistogram    *ExponentialHistogramConfig
+		wantEvents                  EventsConfig
 	}{
 		{
 			configFile:                 "config-2-pipelines.yaml",
@@ -75,6 +77,14 @@ func TestLoadConfig(t *testing.T) {
 			wantDimensionsCacheSize:    1500,
 			wantAggregationTemporality: delta,
 		},
+		{
+			configFile:                 "config-exponential-histogram.yaml",
+			wantMetricsExporter:        "prometheus",
+			wantAggregationTemporality: cumulative,
+			wantDimensionsCacheSize:    defaultDimensionsCacheSize,
+			wantExponentialHistogram:   &ExponentialHistogramConfig{MaxSize: 80},
+			wantEvents:                 EventsConfig{Enabled: true, Names: []string{"exception"}},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.configFile, func(t *testing.T) {
@@ -106,6 +116,8 @@ func TestLoadConfig(t *testing.T) {
 					Dimensions:              tc.wantDimensions,
 					DimensionsCacheSize:     tc.wantDimensionsCacheSize,
 					AggregationTemporality:  tc.wantAggregationTemporality,
+					ExponentialHistogram:    tc.wantExponentialHistogram,
+					Events:                  tc.wantEvents,
 				},
 				cfg.Processors[config.NewComponentID(typeStr)],
 			)
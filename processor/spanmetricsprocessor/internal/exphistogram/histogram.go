@@ -0,0 +1,185 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exphistogram // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor/internal/exphistogram"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// MaxScale is the scale a histogram starts with, before being downscaled to fit its values
+// in the maximum number of buckets.
+const MaxScale int32 = 20
+
+// MinScale is the lowest scale of a histogram, at which all the float64 values fit in a few buckets.
+const MinScale int32 = -10
+
+// MinSize is the minimum number of buckets of a histogram, values on both sides of 1 always
+// falling in different buckets.
+const MinSize int32 = 2
+
+// Histogram is a base-2 exponential histogram of positive values. It starts with the
+// maximum scale, and lowers it as needed to keep the number of buckets within its size.
+// Values equal to zero are counted separately, and negative values are ignored.
+type Histogram struct {
+	maxSize int32
+	scale   int32
+
+	count     uint64
+	sum       float64
+	min       float64
+	max       float64
+	zeroCount uint64
+
+	// offset is the index of the first bucket
+	offset int32
+	counts []uint64
+}
+
+// New creates a Histogram holding at most maxSize buckets.
+func New(maxSize int32) *Histogram {
+	return &Histogram{
+		maxSize: maxSize,
+		scale:   MaxScale,
+	}
+}
+
+// Scale returns the current scale of the histogram.
+func (h *Histogram) Scale() int32 {
+	return h.scale
+}
+
+// Update records the given value.
+func (h *Histogram) Update(value float64) {
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+
+	if h.count == 0 || value < h.min {
+		h.min = value
+	}
+	if h.count == 0 || value > h.max {
+		h.max = value
+	}
+	h.count++
+	h.sum += value
+	if value == 0 {
+		h.zeroCount++
+		return
+	}
+
+	index := mapToIndex(value, h.scale)
+	if len(h.counts) == 0 {
+		h.offset = index
+		h.counts = []uint64{1}
+		return
+	}
+
+	low, high := h.offset, h.offset+int32(len(h.counts))-1
+	if index < low {
+		low = index
+	}
+	if index > high {
+		high = index
+	}
+	if change := scaleChange(low, high, h.maxSize, h.scale); change > 0 {
+		h.downscale(change)
+		index = mapToIndex(value, h.scale)
+		low, high = h.offset, h.offset+int32(len(h.counts))-1
+		if index < low {
+			low = index
+		}
+		if index > high {
+			high = index
+		}
+	}
+
+	h.grow(low, high)
+	h.counts[index-h.offset]++
+}
+
+// CopyTo writes the histogram to the given data point, leaving its attributes, timestamps
+// and exemplars unchanged.
+func (h *Histogram) CopyTo(dp pmetric.ExponentialHistogramDataPoint) {
+	dp.SetCount(h.count)
+	dp.SetSum(h.sum)
+	if h.count > 0 {
+		dp.SetMin(h.min)
+		dp.SetMax(h.max)
+	}
+	dp.SetScale(h.scale)
+	dp.SetZeroCount(h.zeroCount)
+	dp.Positive().SetOffset(h.offset)
+	dp.Positive().BucketCounts().FromRaw(h.counts)
+}
+
+// grow extends the buckets to cover the indexes from low to high.
+func (h *Histogram) grow(low, high int32) {
+	if low == h.offset && high == h.offset+int32(len(h.counts))-1 {
+		return
+	}
+	counts := make([]uint64, high-low+1)
+	copy(counts[h.offset-low:], h.counts)
+	h.offset = low
+	h.counts = counts
+}
+
+// downscale lowers the scale by the given change, merging the buckets accordingly.
+func (h *Histogram) downscale(change int32) {
+	low := h.offset >> change
+	high := (h.offset + int32(len(h.counts)) - 1) >> change
+	counts := make([]uint64, high-low+1)
+	for i, count := range h.counts {
+		counts[((h.offset+int32(i))>>change)-low] += count
+	}
+	h.scale -= change
+	h.offset = low
+	h.counts = counts
+}
+
+// scaleChange returns the scale reduction needed for the indexes from low to high
+// to fit in maxSize buckets, without lowering the scale below MinScale.
+func scaleChange(low, high, maxSize, scale int32) int32 {
+	var change int32
+	for high-low+1 > maxSize && scale-change > MinScale {
+		low >>= 1
+		high >>= 1
+		change++
+	}
+	return change
+}
+
+// mapToIndex returns the index of the bucket holding the given positive value at the given
+// scale. Buckets are upper-inclusive, the bucket of index i holding the values in
+// (base^i, base^(i+1)], with base = 2^(2^-scale).
+func mapToIndex(value float64, scale int32) int32 {
+	frac, exp := math.Frexp(value)
+	// exact powers of two are the upper bound of their bucket
+	powerOfTwo := frac == 0.5
+
+	if scale <= 0 {
+		exponent := int32(exp - 1)
+		if powerOfTwo {
+			exponent--
+		}
+		return exponent >> -scale
+	}
+	if powerOfTwo {
+		return int32(exp-1)<<scale - 1
+	}
+	scaleFactor := math.Ldexp(math.Log2E, int(scale))
+	return int32(math.Ceil(math.Log(value)*scaleFactor)) - 1
+}
@@ -0,0 +1,122 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exphistogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMapToIndex(t *testing.T) {
+	tests := []struct {
+		value    float64
+		scale    int32
+		expected int32
+	}{
+		{value: 1, scale: 0, expected: -1},
+		{value: 2, scale: 0, expected: 0},
+		{value: 3, scale: 0, expected: 1},
+		{value: 4, scale: 0, expected: 1},
+		{value: 0.3, scale: 0, expected: -2},
+		{value: 3, scale: -1, expected: 0},
+		{value: 4, scale: -1, expected: 0},
+		{value: 5, scale: -1, expected: 1},
+		{value: 0.3, scale: -1, expected: -1},
+		{value: 2, scale: 1, expected: 1},
+		{value: 1.5, scale: 1, expected: 1},
+		{value: 1.4, scale: 1, expected: 0},
+		{value: 3, scale: 2, expected: 6},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, mapToIndex(tt.value, tt.scale), "value %v at scale %d", tt.value, tt.scale)
+	}
+}
+
+func TestHistogramDownscales(t *testing.T) {
+	h := New(4)
+	for _, v := range []float64{0, 1, 2, 3, 4, 8, 16} {
+		h.Update(v)
+	}
+	// ignored
+	h.Update(-1)
+	h.Update(math.NaN())
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+
+	assert.Equal(t, uint64(7), dp.Count())
+	assert.Equal(t, float64(34), dp.Sum())
+	assert.Equal(t, float64(0), dp.Min())
+	assert.Equal(t, float64(16), dp.Max())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	// at scale 0, the buckets (0.5, 1], (1, 2], (2, 4], (4, 8] and (8, 16] don't fit, while at scale -1
+	// the buckets (0.25, 1], (1, 4], (4, 16] do
+	assert.Equal(t, int32(-1), dp.Scale())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 3, 2}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestHistogramMinSize(t *testing.T) {
+	h := New(MinSize)
+	for _, v := range []float64{0.3, 0.9, 1.5, 7} {
+		h.Update(v)
+	}
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+	assert.Equal(t, uint64(4), dp.Count())
+	// the values on both sides of 1 fit in the buckets (0, 1] and (1, +Inf) of the lowest scales
+	assert.Equal(t, int32(-2), dp.Scale())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{2, 2}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestScaleChangeStopsAtMinScale(t *testing.T) {
+	// the buckets on both sides of 1 never merge, whatever the scale
+	assert.Equal(t, int32(5), scaleChange(-1, 0, 1, MinScale+5))
+	assert.Equal(t, int32(0), scaleChange(-1, 0, 1, MinScale))
+}
+
+func TestHistogramBucketsHoldValues(t *testing.T) {
+	h := New(160)
+	values := []float64{0.5, 1.25, 7, 12.5, 48, 250, 999.9, 1000, 25_000}
+	for _, v := range values {
+		h.Update(v)
+	}
+	require.LessOrEqual(t, len(h.counts), 160)
+
+	base := math.Pow(2, math.Pow(2, float64(-h.Scale())))
+	for _, v := range values {
+		index := mapToIndex(v, h.Scale())
+		require.GreaterOrEqual(t, index, h.offset)
+		require.Less(t, index, h.offset+int32(len(h.counts)))
+		assert.Greater(t, h.counts[index-h.offset], uint64(0))
+		assert.Greater(t, v, math.Pow(base, float64(index))*(1-1e-9), "value %v below its bucket", v)
+		assert.LessOrEqual(t, v, math.Pow(base, float64(index+1))*(1+1e-9), "value %v above its bucket", v)
+	}
+}
+
+func TestEmptyHistogram(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	New(10).CopyTo(dp)
+	assert.Equal(t, uint64(0), dp.Count())
+	assert.False(t, dp.HasMin())
+	assert.Equal(t, MaxScale, dp.Scale())
+	assert.Equal(t, 0, dp.Positive().BucketCounts().Len())
+}
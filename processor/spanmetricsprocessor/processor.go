@@ -16,6 +16,7 @@ package spanmetricsprocessor // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -34,6 +35,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor/internal/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor/internal/exphistogram"
 )
 
 const (
@@ -41,10 +43,13 @@ const (
 	operationKey       = "operation"   // OpenTelemetry non-standard constant.
 	spanKindKey        = "span.kind"   // OpenTelemetry non-standard constant.
 	statusCodeKey      = "status.code" // OpenTelemetry non-standard constant.
+	eventNameKey       = "event.name"  // OpenTelemetry non-standard constant.
 	metricKeySeparator = string(byte(0))
 	traceIDKey         = "trace_id"
 
 	defaultDimensionsCacheSize = 1000
+
+	defaultExponentialHistogramMaxSize = 160
 )
 
 var (
@@ -84,6 +89,15 @@ type processorImp struct {
 	latencyBounds        []float64
 	latencyExemplarsData map[metricKey][]exemplarData
 
+	// Exponential latency histograms, replacing the explicit bucket counts when the maximum size is positive.
+	latencyExpHistograms map[metricKey]*exphistogram.Histogram
+	expHistogramMaxSize  int32
+
+	// Span event counts, keyed by the span metric key and the event name.
+	eventSum map[metricKey]int64
+	// The counted event names, all events being counted when empty.
+	eventNames map[string]struct{}
+
 	// An LRU cache of dimension key-value maps keyed by a unique identifier formed by a concatenation of its values:
 	// e.g. { "foo/barOK": { "serviceName": "foo", "operation": "/bar", "status_code": "OK" }}
 	metricKeyToDimensions *cache.Cache
@@ -102,6 +116,28 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		return nil, err
 	}
 
+	var expHistogramMaxSize int32
+	if pConfig.ExponentialHistogram != nil {
+		if pConfig.LatencyHistogramBuckets != nil {
+			return nil, errors.New("latency_histogram_buckets can't be used with exponential_histogram")
+		}
+		expHistogramMaxSize = pConfig.ExponentialHistogram.MaxSize
+		if expHistogramMaxSize == 0 {
+			expHistogramMaxSize = defaultExponentialHistogramMaxSize
+		}
+		if expHistogramMaxSize < exphistogram.MinSize {
+			return nil, fmt.Errorf(
+				"invalid exponential histogram max size: %v, the maximum number of buckets should be at least %v",
+				expHistogramMaxSize, exphistogram.MinSize,
+			)
+		}
+	}
+
+	eventNames := make(map[string]struct{}, len(pConfig.Events.Names))
+	for _, name := range pConfig.Events.Names {
+		eventNames[name] = struct{}{}
+	}
+
 	if pConfig.DimensionsCacheSize <= 0 {
 		return nil, fmt.Errorf(
 			"invalid cache size: %v, the maximum number of the items in the cache should be positive",
@@ -123,6 +159,10 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		latencyCount:          make(map[metricKey]uint64),
 		latencyBucketCounts:   make(map[metricKey][]uint64),
 		latencyExemplarsData:  make(map[metricKey][]exemplarData),
+		latencyExpHistograms:  make(map[metricKey]*exphistogram.Histogram),
+		expHistogramMaxSize:   expHistogramMaxSize,
+		eventSum:              make(map[metricKey]int64),
+		eventNames:            eventNames,
 		nextConsumer:          nextConsumer,
 		dimensions:            pConfig.Dimensions,
 		metricKeyToDimensions: metricKeyToDimensionsCache,
@@ -147,7 +187,7 @@ func mapDurationsToMillis(vs []time.Duration) []float64 {
 // the usage of Prometheus related exporters, we also validate the dimensions after sanitization.
 func validateDimensions(dimensions []Dimension, skipSanitizeLabel bool) error {
 	labelNames := make(map[string]struct{})
-	for _, key := range []string{serviceNameKey, spanKindKey, statusCodeKey} {
+	for _, key := range []string{serviceNameKey, spanKindKey, statusCodeKey, eventNameKey} {
 		labelNames[key] = struct{}{}
 		labelNames[sanitize(key, skipSanitizeLabel)] = struct{}{}
 	}
@@ -264,6 +304,10 @@ func (p *processorImp) buildMetrics() (pmetric.Metrics, error) {
 		return pmetric.Metrics{}, err
 	}
 
+	if err := p.collectEventMetrics(ilm); err != nil {
+		return pmetric.Metrics{}, err
+	}
+
 	p.metricKeyToDimensions.RemoveEvictedItems()
 
 	// If delta metrics, reset accumulated data
@@ -278,6 +322,10 @@ func (p *processorImp) buildMetrics() (pmetric.Metrics, error) {
 // collectLatencyMetrics collects the raw latency metrics, writing the data
 // into the given instrumentation library metrics.
 func (p *processorImp) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+	if p.expHistogramMaxSize > 0 {
+		return p.collectExponentialLatencyMetrics(ilm)
+	}
+
 	for key := range p.latencyCount {
 		mLatency := ilm.Metrics().AppendEmpty()
 		mLatency.SetName("latency")
@@ -307,6 +355,59 @@ func (p *processorImp) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
 	return nil
 }
 
+// collectExponentialLatencyMetrics collects the raw latency metrics as exponential histograms, writing the data
+// into the given instrumentation library metrics.
+func (p *processorImp) collectExponentialLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+	for key, histogram := range p.latencyExpHistograms {
+		mLatency := ilm.Metrics().AppendEmpty()
+		mLatency.SetName("latency")
+		mLatency.SetUnit("ms")
+		mLatency.SetEmptyExponentialHistogram().SetAggregationTemporality(p.config.GetAggregationTemporality())
+
+		timestamp := pcommon.NewTimestampFromTime(time.Now())
+
+		dpLatency := mLatency.ExponentialHistogram().DataPoints().AppendEmpty()
+		dpLatency.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpLatency.SetTimestamp(timestamp)
+		histogram.CopyTo(dpLatency)
+
+		setLatencyExemplars(p.latencyExemplarsData[key], timestamp, dpLatency.Exemplars())
+
+		dimensions, err := p.getDimensionsByMetricKey(key)
+		if err != nil {
+			p.logger.Error(err.Error())
+			return err
+		}
+
+		dimensions.CopyTo(dpLatency.Attributes())
+	}
+	return nil
+}
+
+// collectEventMetrics collects the raw span event count metrics, writing the data
+// into the given instrumentation library metrics.
+func (p *processorImp) collectEventMetrics(ilm pmetric.ScopeMetrics) error {
+	for key := range p.eventSum {
+		mEvents := ilm.Metrics().AppendEmpty()
+		mEvents.SetName("events_total")
+		mEvents.SetEmptySum().SetIsMonotonic(true)
+		mEvents.Sum().SetAggregationTemporality(p.config.GetAggregationTemporality())
+
+		dpEvents := mEvents.Sum().DataPoints().AppendEmpty()
+		dpEvents.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpEvents.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		dpEvents.SetIntVal(p.eventSum[key])
+
+		dimensions, err := p.getDimensionsByMetricKey(key)
+		if err != nil {
+			return err
+		}
+
+		dimensions.CopyTo(dpEvents.Attributes())
+	}
+	return nil
+}
+
 // collectCallMetrics collects the raw call count metrics, writing the data
 // into the given instrumentation library metrics.
 func (p *processorImp) collectCallMetrics(ilm pmetric.ScopeMetrics) error {
@@ -391,6 +492,34 @@ func (p *processorImp) aggregateMetricsForSpan(serviceName string, span ptrace.S
 	p.updateCallMetrics(key)
 	p.updateLatencyMetrics(key, latencyInMilliseconds, index)
 	p.updateLatencyExemplars(key, latencyInMilliseconds, span.TraceID())
+
+	if p.config.Events.Enabled {
+		p.aggregateEventMetrics(serviceName, span, key, resourceAttr)
+	}
+}
+
+// aggregateEventMetrics counts the events of the given span by name, keyed by the span metric key and the event name.
+func (p *processorImp) aggregateEventMetrics(serviceName string, span ptrace.Span, spanKey metricKey, resourceAttr pcommon.Map) {
+	for i := 0; i < span.Events().Len(); i++ {
+		name := span.Events().At(i).Name()
+		if len(p.eventNames) > 0 {
+			if _, ok := p.eventNames[name]; !ok {
+				continue
+			}
+		}
+
+		var metricKeyBuilder strings.Builder
+		concatDimensionValue(&metricKeyBuilder, string(spanKey), false)
+		concatDimensionValue(&metricKeyBuilder, name, true)
+		key := metricKey(metricKeyBuilder.String())
+
+		if _, has := p.metricKeyToDimensions.Get(key); !has {
+			dims := p.buildDimensionKVs(serviceName, span, p.dimensions, resourceAttr)
+			dims.PutString(eventNameKey, name)
+			p.metricKeyToDimensions.Add(key, dims)
+		}
+		p.eventSum[key]++
+	}
 }
 
 // updateCallMetrics increments the call count for the given metric key.
@@ -405,6 +534,8 @@ func (p *processorImp) resetAccumulatedMetrics() {
 	p.latencyCount = make(map[metricKey]uint64)
 	p.latencySum = make(map[metricKey]float64)
 	p.latencyBucketCounts = make(map[metricKey][]uint64)
+	p.latencyExpHistograms = make(map[metricKey]*exphistogram.Histogram)
+	p.eventSum = make(map[metricKey]int64)
 	p.metricKeyToDimensions.Purge()
 }
 
@@ -430,6 +561,16 @@ func (p *processorImp) resetExemplarData() {
 
 // updateLatencyMetrics increments the histogram counts for the given metric key and bucket index.
 func (p *processorImp) updateLatencyMetrics(key metricKey, latency float64, index int) {
+	if p.expHistogramMaxSize > 0 {
+		histogram, ok := p.latencyExpHistograms[key]
+		if !ok {
+			histogram = exphistogram.New(p.expHistogramMaxSize)
+			p.latencyExpHistograms[key] = histogram
+		}
+		histogram.Update(latency)
+		return
+	}
+
 	if _, ok := p.latencyBucketCounts[key]; !ok {
 		p.latencyBucketCounts[key] = make([]uint64, len(p.latencyBounds)+1)
 	}
@@ -896,3 +896,124 @@ func TestProcessorResetExemplarData(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, p.latencyExemplarsData[key])
 }
+
+func TestProcessorInvalidExponentialHistogram(t *testing.T) {
+	factory := NewFactory()
+	next := new(consumertest.TracesSink)
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{}
+	cfg.LatencyHistogramBuckets = []time.Duration{time.Millisecond}
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, next)
+	assert.Error(t, err)
+	assert.Nil(t, p)
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: -1}
+	p, err = newProcessor(zaptest.NewLogger(t), cfg, next)
+	assert.Error(t, err)
+	assert.Nil(t, p)
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: 1}
+	p, err = newProcessor(zaptest.NewLogger(t), cfg, next)
+	assert.EqualError(t, err, "invalid exponential histogram max size: 1, the maximum number of buckets should be at least 2")
+	assert.Nil(t, p)
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{}
+	p, err = newProcessor(zaptest.NewLogger(t), cfg, next)
+	require.NoError(t, err)
+	assert.Equal(t, int32(defaultExponentialHistogramMaxSize), p.expHistogramMaxSize)
+}
+
+func TestProcessorExponentialHistogramLatency(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: 10}
+	next := new(consumertest.TracesSink)
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, next)
+	require.NoError(t, err)
+
+	p.aggregateMetrics(buildSampleTrace())
+	m, err := p.buildMetrics()
+	require.NoError(t, err)
+
+	var latencyMetrics int
+	ms := m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		metric := ms.At(i)
+		if metric.Name() != "latency" {
+			continue
+		}
+		latencyMetrics++
+		require.Equal(t, pmetric.MetricDataTypeExponentialHistogram, metric.DataType())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, metric.ExponentialHistogram().AggregationTemporality())
+
+		dp := metric.ExponentialHistogram().DataPoints().At(0)
+		assert.Equal(t, uint64(1), dp.Count())
+		assert.Equal(t, sampleLatency, dp.Sum())
+		assert.Equal(t, sampleLatency, dp.Min())
+		assert.Equal(t, sampleLatency, dp.Max())
+		assert.Equal(t, 1, dp.Positive().BucketCounts().Len())
+		assert.Equal(t, 1, dp.Exemplars().Len())
+		_, ok := dp.Attributes().Get(serviceNameKey)
+		assert.True(t, ok)
+	}
+	// One latency histogram per service, operation, kind and status code combination of the sample trace.
+	assert.Equal(t, 3, latencyMetrics)
+}
+
+func TestProcessorEventMetrics(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Events = EventsConfig{Enabled: true, Names: []string{"exception"}}
+	next := new(consumertest.TracesSink)
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, next)
+	require.NoError(t, err)
+
+	traces := buildSampleTrace()
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	span.Events().AppendEmpty().SetName("exception")
+	span.Events().AppendEmpty().SetName("exception")
+	span.Events().AppendEmpty().SetName("message")
+
+	p.aggregateMetrics(traces)
+	m, err := p.buildMetrics()
+	require.NoError(t, err)
+
+	var eventMetrics []pmetric.Metric
+	ms := m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() == "events_total" {
+			eventMetrics = append(eventMetrics, ms.At(i))
+		}
+	}
+	require.Len(t, eventMetrics, 1)
+
+	sum := eventMetrics[0].Sum()
+	assert.True(t, sum.IsMonotonic())
+	dp := sum.DataPoints().At(0)
+	assert.Equal(t, int64(2), dp.IntVal())
+
+	attrs := dp.Attributes().AsRaw()
+	assert.Equal(t, "exception", attrs[eventNameKey])
+	assert.Equal(t, "service-a", attrs[serviceNameKey])
+	assert.Equal(t, "/ping", attrs[operationKey])
+	assert.Equal(t, "SPAN_KIND_SERVER", attrs[spanKindKey])
+	assert.Equal(t, "STATUS_CODE_OK", attrs[statusCodeKey])
+}
+
+func TestProcessorEventMetricsDisabled(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	next := new(consumertest.TracesSink)
+	p, err := newProcessor(zaptest.NewLogger(t), cfg, next)
+	require.NoError(t, err)
+
+	traces := buildSampleTrace()
+	traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().AppendEmpty().SetName("exception")
+
+	p.aggregateMetrics(traces)
+	assert.Empty(t, p.eventSum)
+}
@@ -15,6 +15,7 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -48,6 +49,8 @@ const (
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
 	TraceState PolicyType = "trace_state"
+	// OTTLCondition sample traces with a span or a span event matching any of the given OTTL conditions.
+	OTTLCondition PolicyType = "ottl_condition"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -73,6 +76,8 @@ type sharedPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for OTTL condition filter sampling policy evaluator.
+	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -194,6 +199,28 @@ type SpanCountCfg struct {
 	MinSpans int32 `mapstructure:"min_spans"`
 }
 
+// OTTLConditionCfg holds the configurable settings to create an OTTL condition filter
+// sampling policy evaluator.
+type OTTLConditionCfg struct {
+	// SpanConditions is a list of OTTL conditions evaluated against each span of the trace,
+	// using the ottltraces context.
+	SpanConditions []string `mapstructure:"span"`
+	// SpanEventConditions is a list of OTTL conditions evaluated against each span event of
+	// the trace, using the ottlspanevents context.
+	SpanEventConditions []string `mapstructure:"spanevent"`
+}
+
+// DecisionCacheCfg holds the configurable settings of the cache keeping the sampling decisions of
+// the traces already released from memory.
+type DecisionCacheCfg struct {
+	// Size is the maximum number of decisions kept in the cache. The cache is disabled when zero.
+	Size int `mapstructure:"size"`
+	// TTL is the time a decision is kept in the cache. Decisions don't expire when zero.
+	TTL time.Duration `mapstructure:"ttl"`
+	// Storage is the ID of the storage extension used to persist the cache across restarts.
+	Storage *config.ComponentID `mapstructure:"storage"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -209,4 +236,26 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache configures the cache used to apply the sampling decision of a trace
+	// to its spans arriving after the trace was released from memory.
+	DecisionCache DecisionCacheCfg `mapstructure:"decision_cache"`
+}
+
+var (
+	errNegativeDecisionCacheSize = errors.New("decision_cache.size can't be negative")
+	errNegativeDecisionCacheTTL  = errors.New("decision_cache.ttl can't be negative")
+	errDecisionCacheStorage      = errors.New("decision_cache.storage requires a positive decision_cache.size")
+)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	switch {
+	case cfg.DecisionCache.Size < 0:
+		return errNegativeDecisionCacheSize
+	case cfg.DecisionCache.TTL < 0:
+		return errNegativeDecisionCacheTTL
+	case cfg.DecisionCache.Storage != nil && cfg.DecisionCache.Size == 0:
+		return errDecisionCacheStorage
+	}
+	return nil
 }
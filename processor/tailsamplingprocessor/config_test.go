@@ -45,6 +45,7 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache:           DecisionCacheCfg{Size: 1000, TTL: time.Hour},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -108,6 +109,16 @@ func TestLoadConfig(t *testing.T) {
 						TraceStateCfg: TraceStateCfg{Key: "key3", Values: []string{"value1", "value2"}},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-10",
+						Type: OTTLCondition,
+						OTTLConditionCfg: OTTLConditionCfg{
+							SpanConditions:      []string{`attributes["db.system"] == "redis" and end_time_unix_nano - start_time_unix_nano > 50000000`},
+							SpanEventConditions: []string{`name == "exception"`},
+						},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -177,3 +188,43 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestValidateConfig(t *testing.T) {
+	storageID := config.NewComponentID("file_storage")
+	tests := []struct {
+		name          string
+		decisionCache DecisionCacheCfg
+		expected      error
+	}{
+		{
+			name:          "disabled",
+			decisionCache: DecisionCacheCfg{},
+		},
+		{
+			name:          "persisted",
+			decisionCache: DecisionCacheCfg{Size: 100, TTL: time.Minute, Storage: &storageID},
+		},
+		{
+			name:          "negative size",
+			decisionCache: DecisionCacheCfg{Size: -1},
+			expected:      errNegativeDecisionCacheSize,
+		},
+		{
+			name:          "negative ttl",
+			decisionCache: DecisionCacheCfg{Size: 100, TTL: -time.Minute},
+			expected:      errNegativeDecisionCacheTTL,
+		},
+		{
+			name:          "storage without size",
+			decisionCache: DecisionCacheCfg{Storage: &storageID},
+			expected:      errDecisionCacheStorage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.DecisionCache = tt.decisionCache
+			assert.Equal(t, tt.expected, cfg.Validate())
+		})
+	}
+}
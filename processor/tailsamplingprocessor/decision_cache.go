@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+// entrySize is the size of a serialized cache entry: the trace ID, the decision and the expiration
+// time in nanoseconds since the epoch.
+const entrySize = 16 + 1 + 8
+
+var errInvalidDecisionCache = errors.New("the stored decision cache is corrupted")
+
+type cachedDecision struct {
+	traceID   pcommon.TraceID
+	decision  sampling.Decision
+	expiresAt time.Time
+}
+
+// decisionCache is a bounded LRU cache of the final sampling decisions, used to apply the original
+// decision to spans arriving after their trace has been dropped from memory.
+type decisionCache struct {
+	sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[pcommon.TraceID]*list.Element
+	lru     *list.List
+	now     func() time.Time
+	// changed tells whether decisions were recorded since the cache was last marshaled by marshalChanged
+	changed bool
+}
+
+func newDecisionCache(size int, ttl time.Duration) *decisionCache {
+	return &decisionCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[pcommon.TraceID]*list.Element, size),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// put records the decision for the given trace, evicting the least recently used entry if the cache is full.
+func (c *decisionCache) put(traceID pcommon.TraceID, decision sampling.Decision) {
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = c.now().Add(c.ttl)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.add(cachedDecision{traceID: traceID, decision: decision, expiresAt: expiresAt})
+	c.changed = true
+}
+
+// get returns the decision recorded for the given trace, if it's present and hasn't expired.
+func (c *decisionCache) get(traceID pcommon.TraceID) (sampling.Decision, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	elem, ok := c.entries[traceID]
+	if !ok {
+		return sampling.Unspecified, false
+	}
+	entry := elem.Value.(cachedDecision)
+	if !entry.expiresAt.IsZero() && c.now().After(entry.expiresAt) {
+		c.remove(elem)
+		return sampling.Unspecified, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.decision, true
+}
+
+func (c *decisionCache) len() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.lru.Len()
+}
+
+// marshal serializes the entries that haven't expired yet, from the least to the most recently used one.
+func (c *decisionCache) marshal() []byte {
+	c.Lock()
+	defer c.Unlock()
+	return c.marshalLocked()
+}
+
+// marshalChanged serializes the entries like marshal, but only if decisions were recorded since its last call.
+func (c *decisionCache) marshalChanged() ([]byte, bool) {
+	c.Lock()
+	defer c.Unlock()
+	if !c.changed {
+		return nil, false
+	}
+	c.changed = false
+	return c.marshalLocked(), true
+}
+
+// markChanged flags the cache as changed again, used when the output of marshalChanged couldn't be persisted.
+func (c *decisionCache) markChanged() {
+	c.Lock()
+	defer c.Unlock()
+	c.changed = true
+}
+
+// marshalLocked must be called with the lock held.
+func (c *decisionCache) marshalLocked() []byte {
+	now := c.now()
+	buf := make([]byte, 0, c.lru.Len()*entrySize)
+	for elem := c.lru.Back(); elem != nil; elem = elem.Prev() {
+		entry := elem.Value.(cachedDecision)
+		if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			continue
+		}
+		var expiresAt int64
+		if !entry.expiresAt.IsZero() {
+			expiresAt = entry.expiresAt.UnixNano()
+		}
+		var serialized [entrySize]byte
+		copy(serialized[:16], entry.traceID[:])
+		serialized[16] = byte(entry.decision)
+		binary.BigEndian.PutUint64(serialized[17:], uint64(expiresAt))
+		buf = append(buf, serialized[:]...)
+	}
+	return buf
+}
+
+// unmarshal adds the entries serialized by marshal to the cache, skipping the ones that have expired since.
+func (c *decisionCache) unmarshal(buf []byte) error {
+	if len(buf)%entrySize != 0 {
+		return errInvalidDecisionCache
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	now := c.now()
+	for i := 0; i < len(buf); i += entrySize {
+		var entry cachedDecision
+		copy(entry.traceID[:], buf[i:i+16])
+		entry.decision = sampling.Decision(buf[i+16])
+		if expiresAt := int64(binary.BigEndian.Uint64(buf[i+17 : i+entrySize])); expiresAt != 0 {
+			entry.expiresAt = time.Unix(0, expiresAt)
+			if now.After(entry.expiresAt) {
+				continue
+			}
+		}
+		c.add(entry)
+	}
+	return nil
+}
+
+// add must be called with the lock held.
+func (c *decisionCache) add(entry cachedDecision) {
+	if elem, ok := c.entries[entry.traceID]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[entry.traceID] = c.lru.PushFront(entry)
+	if c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove must be called with the lock held.
+func (c *decisionCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(cachedDecision).traceID)
+}
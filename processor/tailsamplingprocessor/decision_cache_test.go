@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestDecisionCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newDecisionCache(2, 0)
+	first := pcommon.TraceID([16]byte{1})
+	second := pcommon.TraceID([16]byte{2})
+	third := pcommon.TraceID([16]byte{3})
+
+	c.put(first, sampling.Sampled)
+	c.put(second, sampling.NotSampled)
+	// touch the first trace, so that the second one is evicted
+	_, ok := c.get(first)
+	require.True(t, ok)
+	c.put(third, sampling.Sampled)
+
+	assert.Equal(t, 2, c.len())
+	decision, ok := c.get(first)
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+	_, ok = c.get(second)
+	assert.False(t, ok)
+	decision, ok = c.get(third)
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+}
+
+func TestDecisionCacheExpiresDecisions(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := newDecisionCache(10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	traceID := pcommon.TraceID([16]byte{1})
+	c.put(traceID, sampling.NotSampled)
+
+	now = now.Add(30 * time.Second)
+	decision, ok := c.get(traceID)
+	assert.True(t, ok)
+	assert.Equal(t, sampling.NotSampled, decision)
+
+	now = now.Add(time.Minute)
+	_, ok = c.get(traceID)
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.len())
+}
+
+func TestDecisionCacheMarshalRoundTrip(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := newDecisionCache(10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	sampled := pcommon.TraceID([16]byte{1})
+	notSampled := pcommon.TraceID([16]byte{2})
+	expired := pcommon.TraceID([16]byte{3})
+	c.put(expired, sampling.Sampled)
+	now = now.Add(45 * time.Second)
+	c.put(sampled, sampling.Sampled)
+	c.put(notSampled, sampling.NotSampled)
+	buf := c.marshal()
+
+	// the restored cache loses the entries expired in between
+	now = now.Add(30 * time.Second)
+	restored := newDecisionCache(10, time.Minute)
+	restored.now = func() time.Time { return now }
+	require.NoError(t, restored.unmarshal(buf))
+
+	assert.Equal(t, 2, restored.len())
+	decision, ok := restored.get(sampled)
+	assert.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+	decision, ok = restored.get(notSampled)
+	assert.True(t, ok)
+	assert.Equal(t, sampling.NotSampled, decision)
+	_, ok = restored.get(expired)
+	assert.False(t, ok)
+}
+
+func TestDecisionCacheMarshalChanged(t *testing.T) {
+	c := newDecisionCache(10, 0)
+	_, changed := c.marshalChanged()
+	assert.False(t, changed)
+
+	c.put(pcommon.TraceID([16]byte{1}), sampling.Sampled)
+	buf, changed := c.marshalChanged()
+	assert.True(t, changed)
+	assert.Equal(t, c.marshal(), buf)
+	_, changed = c.marshalChanged()
+	assert.False(t, changed)
+
+	// a failed write flags the cache to be persisted again
+	c.markChanged()
+	_, changed = c.marshalChanged()
+	assert.True(t, changed)
+}
+
+func TestDecisionCacheUnmarshalInvalid(t *testing.T) {
+	c := newDecisionCache(10, 0)
+	assert.ErrorIs(t, c.unmarshal([]byte{1, 2, 3}), errInvalidDecisionCache)
+	assert.NoError(t, c.unmarshal(nil))
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottlspanevents"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/contexts/ottltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/functions/ottlotel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/oteltransformationlanguage/ottl"
+)
+
+type ottlConditionFilter struct {
+	spanConditions      []ottl.Condition
+	spanEventConditions []ottl.Condition
+	logger              *zap.Logger
+}
+
+var _ PolicyEvaluator = (*ottlConditionFilter)(nil)
+
+// NewOTTLConditionFilter creates a policy evaluator that samples all traces with a span or a
+// span event matching any of the given OTTL conditions.
+func NewOTTLConditionFilter(logger *zap.Logger, spanConditions, spanEventConditions []string) (PolicyEvaluator, error) {
+	// a policy must not change the trace it evaluates
+	functions := ottlotel.ReadOnlyFunctions()
+	spanParser := ottl.NewParser(functions, ottltraces.ParsePath, ottltraces.ParseEnum, ottl.NoOpLogger{})
+	spanEventParser := ottl.NewParser(functions, ottlspanevents.ParsePath, ottlspanevents.ParseEnum, ottl.NoOpLogger{})
+
+	parsedSpanConditions, spanErr := spanParser.ParseConditions(spanConditions)
+	parsedSpanEventConditions, spanEventErr := spanEventParser.ParseConditions(spanEventConditions)
+	if err := multierr.Append(spanErr, spanEventErr); err != nil {
+		return nil, err
+	}
+
+	return &ottlConditionFilter{
+		spanConditions:      parsedSpanConditions,
+		spanEventConditions: parsedSpanEventConditions,
+		logger:              logger,
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (ocf *ottlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	for _, batch := range batches {
+		rspans := batch.ResourceSpans()
+		for i := 0; i < rspans.Len(); i++ {
+			rs := rspans.At(i)
+			ilss := rs.ScopeSpans()
+			for j := 0; j < ilss.Len(); j++ {
+				ils := ilss.At(j)
+				for k := 0; k < ils.Spans().Len(); k++ {
+					if ocf.matchesSpan(ils.Spans().At(k), ils.Scope(), rs.Resource()) {
+						return Sampled, nil
+					}
+				}
+			}
+		}
+	}
+	return NotSampled, nil
+}
+
+// matchesSpan returns whether the span, or any of its events, matches any of the conditions.
+func (ocf *ottlConditionFilter) matchesSpan(span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	if len(ocf.spanConditions) > 0 {
+		ctx := ottltraces.NewTransformContext(span, scope, resource)
+		for _, condition := range ocf.spanConditions {
+			if condition(ctx) {
+				return true
+			}
+		}
+	}
+
+	if len(ocf.spanEventConditions) > 0 {
+		for i := 0; i < span.Events().Len(); i++ {
+			ctx := ottlspanevents.NewTransformContext(span.Events().At(i), span, scope, resource)
+			for _, condition := range ocf.spanEventConditions {
+				if condition(ctx) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestOTTLConditionFilter(t *testing.T) {
+	cases := []struct {
+		Desc                string
+		SpanConditions      []string
+		SpanEventConditions []string
+		Decision            Decision
+	}{
+		{
+			Desc:           "matching span attribute and duration",
+			SpanConditions: []string{`attributes["db.system"] == "redis" and end_time_unix_nano - start_time_unix_nano > 50000000`},
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "nonmatching span duration",
+			SpanConditions: []string{`attributes["db.system"] == "redis" and end_time_unix_nano - start_time_unix_nano > 500000000`},
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "matching any of the span conditions",
+			SpanConditions: []string{`name == "unknown"`, `IsMatch(resource.attributes["service.name"], "cache-.*")`},
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "matching span kind enum",
+			SpanConditions: []string{`kind == SPAN_KIND_CLIENT`},
+			Decision:       Sampled,
+		},
+		{
+			Desc:                "matching span event",
+			SpanEventConditions: []string{`name == "exception" and span.name == "GET"`},
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "nonmatching span event",
+			SpanEventConditions: []string{`name == "exception" and attributes["exception.type"] == "timeout"`},
+			Decision:            NotSampled,
+		},
+		{
+			Desc:     "no conditions",
+			Decision: NotSampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewOTTLConditionFilter(zap.NewNop(), c.SpanConditions, c.SpanEventConditions)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), newOTTLConditionTrace())
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestOTTLConditionFilterInvalid(t *testing.T) {
+	_, err := NewOTTLConditionFilter(zap.NewNop(), []string{`unknown == "redis"`}, []string{`name ==`})
+	assert.Error(t, err)
+}
+
+func newOTTLConditionTrace() *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutString("service.name", "cache-api")
+	ils := rs.ScopeSpans().AppendEmpty()
+
+	now := time.Now()
+	span := ils.Spans().AppendEmpty()
+	span.SetName("GET")
+	span.SetKind(ptrace.SpanKindClient)
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(now))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(now.Add(100 * time.Millisecond)))
+	span.Attributes().PutString("db.system", "redis")
+
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutString("exception.type", "connection refused")
+
+	return &TraceData{
+		ReceivedBatches: []ptrace.Traces{traces},
+	}
+}
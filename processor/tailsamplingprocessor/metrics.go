@@ -39,6 +39,7 @@ var (
 	statCountTracesSampled = stats.Int64("count_traces_sampled", "Count of traces that were sampled or not", stats.UnitDimensionless)
 
 	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
+	statDecisionCacheHitCount   = stats.Int64("sampling_decision_cache_hit", "Count of late spans whose trace decision was found in the decision cache", stats.UnitDimensionless)
 	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
 	statTracesOnMemoryGauge     = stats.Int64("sampling_traces_on_memory", "Tracks the number of traces current on memory", stats.UnitDimensionless)
 )
@@ -103,6 +104,13 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Description: statDroppedTooEarlyCount.Description(),
 		Aggregation: view.Sum(),
 	}
+	countDecisionCacheHitView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionCacheHitCount.Name()),
+		Measure:     statDecisionCacheHitCount,
+		Description: statDecisionCacheHitCount.Description(),
+		TagKeys:     []tag.Key{tagSampledKey},
+		Aggregation: view.Sum(),
+	}
 	countTraceIDArrivalView := &view.View{
 		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statNewTraceIDReceivedCount.Name()),
 		Measure:     statNewTraceIDReceivedCount,
@@ -128,6 +136,7 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		countTracesSampledView,
 
 		countTraceDroppedTooEarlyView,
+		countDecisionCacheHitView,
 		countTraceIDArrivalView,
 		trackTracesOnMemorylView,
 	}
@@ -16,18 +16,23 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
+	"strconv"
 	"sync"
 	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	storageext "go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
@@ -60,10 +65,27 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+
+	// decisionCache keeps the decisions of the traces already released from memory, it's nil when disabled
+	decisionCache *decisionCache
+	// the storage extension used to persist the decision cache, if any
+	storageID   *config.ComponentID
+	componentID config.ComponentID
+	// storageLock guards storageClient, which is written from the policy ticker and closed on shutdown
+	storageLock   sync.Mutex
+	storageClient storageext.Client
 }
 
 const (
 	sourceFormat = "tail_sampling"
+
+	// decisionCacheKey is the storage key holding the serialized decision cache.
+	decisionCacheKey = "decision_cache"
+)
+
+var (
+	errStorageNotFound = errors.New("storage extension not found")
+	errNotStorage      = errors.New("the extension isn't a storage extension")
 )
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform tail sampling according to the given
@@ -108,6 +130,11 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		policies:        policies,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  atomic.NewUint64(0),
+		storageID:       cfg.DecisionCache.Storage,
+		componentID:     cfg.ID(),
+	}
+	if cfg.DecisionCache.Size > 0 {
+		tsp.decisionCache = newDecisionCache(cfg.DecisionCache.Size, cfg.DecisionCache.TTL)
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -155,6 +182,9 @@ func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (samplin
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case OTTLCondition:
+		ocfCfg := cfg.OTTLConditionCfg
+		return sampling.NewOTTLConditionFilter(logger, ocfCfg.SpanConditions, ocfCfg.SpanEventConditions)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -181,6 +211,9 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.DecisionTime = time.Now()
 
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
+		if tsp.decisionCache != nil {
+			tsp.decisionCache.put(id, decision)
+		}
 
 		// Sampled or not, remove the batches
 		trace.Lock()
@@ -215,6 +248,27 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		zap.Int64("droppedPriorToEvaluation", metrics.idNotFoundOnMapCount),
 		zap.Int64("policyEvaluationErrors", metrics.evaluateErrorCount),
 	)
+
+	tsp.persistDecisionCache()
+}
+
+// persistDecisionCache writes the decision cache to the storage extension when it changed, so that
+// the decisions survive a crash of the collector and not only a clean shutdown.
+func (tsp *tailSamplingSpanProcessor) persistDecisionCache() {
+	tsp.storageLock.Lock()
+	defer tsp.storageLock.Unlock()
+	if tsp.storageClient == nil {
+		return
+	}
+
+	buf, changed := tsp.decisionCache.marshalChanged()
+	if !changed {
+		return
+	}
+	if err := tsp.storageClient.Set(tsp.ctx, decisionCacheKey, buf); err != nil {
+		tsp.decisionCache.markChanged()
+		tsp.logger.Warn("Couldn't persist the decision cache", zap.Error(err))
+	}
 }
 
 func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sampling.TraceData, metrics *policyMetrics) (sampling.Decision, *policy) {
@@ -330,6 +384,10 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	idToSpans := tsp.groupSpansByTraceKey(resourceSpans)
 	var newTraceIDs int64
 	for id, spans := range idToSpans {
+		if tsp.processCachedDecision(id, resourceSpans, spans) {
+			continue
+		}
+
 		lenSpans := int64(len(spans))
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
@@ -408,20 +466,97 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
 }
 
+// processCachedDecision applies the cached decision to the spans of a trace already released from memory,
+// returning whether a decision was found.
+func (tsp *tailSamplingSpanProcessor) processCachedDecision(id pcommon.TraceID, resourceSpans ptrace.ResourceSpans, spans []*ptrace.Span) bool {
+	if tsp.decisionCache == nil {
+		return false
+	}
+	// traces still in memory carry their own decisions
+	if _, ok := tsp.idToTrace.Load(id); ok {
+		return false
+	}
+	decision, ok := tsp.decisionCache.get(id)
+	if !ok {
+		return false
+	}
+
+	sampled := decision == sampling.Sampled
+	_ = stats.RecordWithTags(
+		tsp.ctx,
+		[]tag.Mutator{tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
+		statDecisionCacheHitCount.M(int64(1)),
+	)
+	if sampled {
+		if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, prepareTraceBatch(resourceSpans, spans)); err != nil {
+			tsp.logger.Warn("Error sending late arrived spans to destination", zap.Error(err))
+		}
+	}
+	return true
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.decisionCache != nil && tsp.storageID != nil {
+		if err := tsp.loadDecisionCache(ctx, host); err != nil {
+			return err
+		}
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+
+	tsp.storageLock.Lock()
+	defer tsp.storageLock.Unlock()
+	if tsp.storageClient == nil {
+		return nil
+	}
+	var errs error
+	if err := tsp.storageClient.Set(ctx, decisionCacheKey, tsp.decisionCache.marshal()); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("couldn't persist the decision cache: %w", err))
+	}
+	errs = multierr.Append(errs, tsp.storageClient.Close(ctx))
+	tsp.storageClient = nil
+	return errs
+}
+
+// loadDecisionCache gets a client from the configured storage extension and restores the decision
+// cache persisted by a previous run.
+func (tsp *tailSamplingSpanProcessor) loadDecisionCache(ctx context.Context, host component.Host) error {
+	ext, found := host.GetExtensions()[*tsp.storageID]
+	if !found {
+		return fmt.Errorf("%w: %s", errStorageNotFound, tsp.storageID)
+	}
+	storageExt, ok := ext.(storageext.Extension)
+	if !ok {
+		return fmt.Errorf("%w: %s", errNotStorage, tsp.storageID)
+	}
+
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, tsp.componentID, "")
+	if err != nil {
+		return fmt.Errorf("couldn't get the storage client: %w", err)
+	}
+	tsp.storageClient = client
+
+	buf, err := client.Get(ctx, decisionCacheKey)
+	if err != nil {
+		return fmt.Errorf("couldn't read the decision cache: %w", err)
+	}
+	if err = tsp.decisionCache.unmarshal(buf); err != nil {
+		return err
+	}
+	if n := tsp.decisionCache.len(); n > 0 {
+		tsp.logger.Info("recovered sampling decisions from the storage", zap.Int("decisions", n))
+	}
 	return nil
 }
 
@@ -25,12 +25,14 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
@@ -704,3 +706,148 @@ func simpleTracesWithID(traceID pcommon.TraceID) ptrace.Traces {
 	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetTraceID(traceID)
 	return traces
 }
+
+func TestLateSpansFollowCachedDecision(t *testing.T) {
+	for _, decision := range []sampling.Decision{sampling.Sampled, sampling.NotSampled} {
+		msp := new(consumertest.TracesSink)
+		mpe := &mockPolicyEvaluator{NextDecision: decision}
+		tsp := &tailSamplingSpanProcessor{
+			ctx:             context.Background(),
+			nextConsumer:    msp,
+			maxNumTraces:    10,
+			logger:          zap.NewNop(),
+			decisionBatcher: newSyncIDBatcher(1),
+			policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+			deleteChan:      make(chan pcommon.TraceID, 10),
+			policyTicker:    &manualTTicker{},
+			tickerFrequency: 100 * time.Millisecond,
+			numTracesOnMap:  atomic.NewUint64(0),
+			decisionCache:   newDecisionCache(10, time.Minute),
+		}
+		require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+
+		traceIds, batches := generateIdsAndBatches(1)
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+		tsp.samplingPolicyOnTick()
+		tsp.samplingPolicyOnTick()
+		spansAfterDecision := msp.SpanCount()
+
+		// the trace is released from memory, and a late span arrives
+		tsp.dropTrace(traceIds[0], time.Now())
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+		tsp.samplingPolicyOnTick()
+		tsp.samplingPolicyOnTick()
+
+		_, found := tsp.idToTrace.Load(traceIds[0])
+		require.False(t, found, "late span shouldn't have created a new trace")
+		require.Equal(t, 1, mpe.EvaluationCount, "late span shouldn't have been evaluated again")
+		if decision == sampling.Sampled {
+			require.Equal(t, spansAfterDecision+1, msp.SpanCount(), "late span was not forwarded")
+		} else {
+			require.Equal(t, 0, msp.SpanCount(), "late span of a dropped trace was forwarded")
+		}
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}
+}
+
+func TestDecisionCachePersistedAcrossRestarts(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("decisions")
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DecisionWait:      defaultTestDecisionWait,
+		NumTraces:         10,
+		PolicyCfgs:        testPolicy,
+		DecisionCache: DecisionCacheCfg{
+			Size:    10,
+			TTL:     time.Hour,
+			Storage: &storageID,
+		},
+	}
+	newStartedProcessor := func() *tailSamplingSpanProcessor {
+		sp, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+		require.NoError(t, err)
+		tsp := sp.(*tailSamplingSpanProcessor)
+		host := storagetest.NewStorageHost().WithFileBackedStorageExtension("decisions", storageDir)
+		require.NoError(t, tsp.Start(context.Background(), host))
+		return tsp
+	}
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	tsp := newStartedProcessor()
+	tsp.decisionCache.put(traceID, sampling.Sampled)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	restarted := newStartedProcessor()
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	decision, ok := restarted.decisionCache.get(traceID)
+	require.True(t, ok)
+	require.Equal(t, sampling.Sampled, decision)
+}
+
+func TestDecisionCachePersistedOnTick(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("decisions")
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DecisionWait:      defaultTestDecisionWait,
+		NumTraces:         10,
+		PolicyCfgs:        testPolicy,
+		DecisionCache: DecisionCacheCfg{
+			Size:    10,
+			TTL:     time.Hour,
+			Storage: &storageID,
+		},
+	}
+	newStartedProcessor := func() *tailSamplingSpanProcessor {
+		sp, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+		require.NoError(t, err)
+		tsp := sp.(*tailSamplingSpanProcessor)
+		tsp.policyTicker = &manualTTicker{}
+		host := storagetest.NewStorageHost().WithFileBackedStorageExtension("decisions", storageDir)
+		require.NoError(t, tsp.Start(context.Background(), host))
+		return tsp
+	}
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	tsp := newStartedProcessor()
+	tsp.decisionCache.put(traceID, sampling.NotSampled)
+	tsp.samplingPolicyOnTick()
+
+	// the collector crashes: the storage is closed without shutting down the processor
+	tsp.decisionBatcher.Stop()
+	require.NoError(t, tsp.storageClient.Close(context.Background()))
+
+	restarted := newStartedProcessor()
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	decision, ok := restarted.decisionCache.get(traceID)
+	require.True(t, ok)
+	require.Equal(t, sampling.NotSampled, decision)
+}
+
+func TestDecisionCacheStorageErrors(t *testing.T) {
+	missing := storagetest.NewStorageID("missing")
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DecisionWait:      defaultTestDecisionWait,
+		NumTraces:         10,
+		PolicyCfgs:        testPolicy,
+		DecisionCache:     DecisionCacheCfg{Size: 10, Storage: &missing},
+	}
+	sp, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	require.ErrorIs(t, sp.Start(context.Background(), componenttest.NewNopHost()), errStorageNotFound)
+	sp.(*tailSamplingSpanProcessor).decisionBatcher.Stop()
+
+	other := storagetest.NewNonStorageID("other")
+	cfg.DecisionCache.Storage = &other
+	sp, err = newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	host := storagetest.NewStorageHost().WithNonStorageExtension("other")
+	require.ErrorIs(t, sp.Start(context.Background(), host), errNotStorage)
+	sp.(*tailSamplingSpanProcessor).decisionBatcher.Stop()
+}